@@ -0,0 +1,194 @@
+// Package batch provides a framework for long-running offline jobs (data migrations, backfills, ...).
+// A Job iterates a Source in chunks, processes every chunk, persists a checkpoint after each of them,
+// so an interrupted Job resumes where it stopped. The throughput can be rate limited and the progress
+// is logged and published as expvar metrics.
+// Use RunUntilSignal to stop the Job cleanly on SIGTERM or SIGINT
+package batch
+
+import (
+	"context"
+	"expvar"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/logger"
+	"golang.org/x/time/rate"
+)
+
+// DefaultChunkSize is the chunk size of a Job if none is set.
+const DefaultChunkSize = 100
+
+// ItemsProcessed counts the processed items by job name, published as the "batch_items_processed_total" expvar.
+var ItemsProcessed = expvar.NewMap("batch_items_processed_total")
+
+// ChunksFailed counts the failed chunks by job name, published as the "batch_chunks_failed_total" expvar.
+var ChunksFailed = expvar.NewMap("batch_chunks_failed_total")
+
+// Item is a single unit of work.
+type Item struct {
+	// Cursor is the position of the item in the Source, the Job resumes after the cursor of the
+	// last item of the last processed chunk.
+	Cursor string
+
+	// Value is the item itself, its type depends on the Source.
+	Value interface{}
+}
+
+// Source provides the items of a Job in a stable order.
+type Source interface {
+	// Next returns at most size items after cursor, an empty cursor means the beginning.
+	// An empty result means there are no more items.
+	Next(ctx context.Context, cursor string, size int) ([]Item, error)
+}
+
+// ProcessFunc processes a single chunk of items.
+type ProcessFunc func(ctx context.Context, chunk []Item) error
+
+// Stats are the statistics of a single Run.
+type Stats struct {
+	// Chunks is the number of the processed chunks.
+	Chunks int
+
+	// Items is the number of the processed items.
+	Items int
+
+	// Cursor is the cursor of the last processed item.
+	Cursor string
+
+	// Duration is the duration of the Run.
+	Duration time.Duration
+
+	// Stopped is true if the Run was stopped before the Source was exhausted.
+	Stopped bool
+}
+
+// Job is a long-running offline job.
+type Job struct {
+	// Name identifies the Job in the checkpoints, the log entries and the metrics.
+	Name string
+
+	// Source provides the items.
+	Source Source
+
+	// Process processes the chunks.
+	Process ProcessFunc
+
+	// Checkpoints persists the progress, nothing is persisted if nil.
+	Checkpoints CheckpointStore
+
+	// ChunkSize is the maximum number of items in a chunk, DefaultChunkSize if zero.
+	ChunkSize int
+
+	// RateLimit is the maximum number of items processed per second, unlimited if zero.
+	RateLimit float64
+
+	// Log is used to log the progress, nothing is logged if nil.
+	Log *logger.Logger
+}
+
+// Run processes the items of the Source chunk by chunk, starting after the saved checkpoint.
+// The cancellation of ctx is a stop request: the chunk being processed is finished and checkpointed
+// (its ProcessFunc receives a context which is not canceled), then Run returns with Stats.Stopped set.
+// A failing chunk stops the Job with an error, its items are processed again by the next Run.
+func (j *Job) Run(ctx context.Context) (Stats, error) {
+	start := time.Now()
+	stats := Stats{}
+
+	size := j.ChunkSize
+	if size <= 0 {
+		size = DefaultChunkSize
+	}
+	var limiter *rate.Limiter
+	if j.RateLimit > 0 {
+		// The burst has to hold a whole chunk, otherwise WaitN fails
+		limiter = rate.NewLimiter(rate.Limit(j.RateLimit), size)
+	}
+
+	if j.Checkpoints != nil {
+		cursor, err := j.Checkpoints.Load(ctx, j.Name)
+		if err != nil {
+			return stats, errors.Wrapf(err, "Failed to load checkpoint of %s", j.Name)
+		}
+		stats.Cursor = cursor
+	}
+	j.logProgress(stats, logrus.InfoLevel, "Batch job started")
+
+	// work is not canceled by the stop request, so the current chunk can be finished
+	work := context.WithoutCancel(ctx)
+	for {
+		if ctx.Err() != nil {
+			stats.Stopped = true
+			break
+		}
+
+		chunk, err := j.Source.Next(work, stats.Cursor, size)
+		if err != nil {
+			return j.finish(stats, start), errors.Wrapf(err, "Failed to read the next chunk of %s", j.Name)
+		}
+		if len(chunk) == 0 {
+			break
+		}
+
+		if limiter != nil {
+			if err := limiter.WaitN(ctx, len(chunk)); err != nil {
+				stats.Stopped = true
+				break
+			}
+		}
+
+		if err := j.Process(work, chunk); err != nil {
+			ChunksFailed.Add(j.Name, 1)
+			return j.finish(stats, start), errors.Wrapf(err, "Failed to process chunk of %s after %q", j.Name, stats.Cursor)
+		}
+
+		stats.Chunks++
+		stats.Items += len(chunk)
+		stats.Cursor = chunk[len(chunk)-1].Cursor
+		ItemsProcessed.Add(j.Name, int64(len(chunk)))
+
+		if j.Checkpoints != nil {
+			if err := j.Checkpoints.Save(work, j.Name, stats.Cursor); err != nil {
+				return j.finish(stats, start), errors.Wrapf(err, "Failed to save checkpoint of %s", j.Name)
+			}
+		}
+		j.logProgress(stats, logrus.DebugLevel, "Batch chunk processed")
+	}
+
+	stats = j.finish(stats, start)
+	if stats.Stopped {
+		j.logProgress(stats, logrus.WarnLevel, "Batch job stopped")
+	} else {
+		j.logProgress(stats, logrus.InfoLevel, "Batch job finished")
+	}
+	return stats, nil
+}
+
+// RunUntilSignal runs the Job and requests a clean stop when the process receives SIGTERM or SIGINT.
+func RunUntilSignal(ctx context.Context, job *Job) (Stats, error) {
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return job.Run(ctx)
+}
+
+// finish sets the duration of the stats.
+func (j *Job) finish(stats Stats, start time.Time) Stats {
+	stats.Duration = time.Since(start)
+	return stats
+}
+
+// logProgress logs the progress of the Job.
+func (j *Job) logProgress(stats Stats, level logrus.Level, msg string) {
+	if j.Log == nil {
+		return
+	}
+	j.Log.WithFields(logrus.Fields{
+		"job":    j.Name,
+		"chunks": stats.Chunks,
+		"items":  stats.Items,
+		"cursor": stats.Cursor,
+	}).Log(level, msg)
+}
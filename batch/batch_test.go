@@ -0,0 +1,181 @@
+package batch
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/pkg/errors"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// sliceSource is a Source of numbered items, the cursor is the zero padded number.
+type sliceSource struct {
+	count int
+}
+
+func (s sliceSource) Next(ctx context.Context, cursor string, size int) ([]Item, error) {
+	items := []Item{}
+	for i := 0; i < s.count && len(items) < size; i++ {
+		item := Item{Cursor: fmt.Sprintf("%04d", i), Value: i}
+		if item.Cursor > cursor {
+			items = append(items, item)
+		}
+	}
+	return items, nil
+}
+
+// fakeLister lists the keys of a single bucket.
+type fakeLister struct {
+	keys []string
+}
+
+func (f fakeLister) ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error) {
+	output := &s3.ListObjectsV2Output{}
+	for _, key := range f.keys {
+		if key > aws.ToString(params.StartAfter) && strings.HasPrefix(key, aws.ToString(params.Prefix)) &&
+			int32(len(output.Contents)) < aws.ToInt32(params.MaxKeys) {
+			output.Contents = append(output.Contents, types.Object{Key: aws.String(key)})
+		}
+	}
+	return output, nil
+}
+
+// BatchSuite extends testify's Suite.
+type BatchSuite struct {
+	suite.Suite
+}
+
+func (bs *BatchSuite) TestRunAndResume() {
+	nullLogger, hook := logrusTest.NewNullLogger()
+	store := NewMemoryCheckpointStore()
+	processed := []int{}
+	job := &Job{
+		Name:        "test-resume",
+		Source:      sliceSource{count: 10},
+		Checkpoints: store,
+		ChunkSize:   3,
+		Log:         logger.NewLogger(nullLogger, nil),
+		Process: func(ctx context.Context, chunk []Item) error {
+			for _, item := range chunk {
+				if item.Value.(int) == 7 {
+					return errors.New("Boom")
+				}
+				processed = append(processed, item.Value.(int))
+			}
+			return nil
+		},
+	}
+
+	stats, err := job.Run(context.Background())
+	bs.Error(err)
+	bs.Equal(2, stats.Chunks)
+	bs.Equal("0005", stats.Cursor)
+	cursor, _ := store.Load(context.Background(), "test-resume")
+	bs.Equal("0005", cursor, "Checkpoint should be saved after the last successful chunk")
+	bs.Equal("1", ChunksFailed.Get("test-resume").String())
+
+	job.Process = func(ctx context.Context, chunk []Item) error {
+		for _, item := range chunk {
+			processed = append(processed, item.Value.(int))
+		}
+		return nil
+	}
+	stats, err = job.Run(context.Background())
+	bs.NoError(err)
+	bs.False(stats.Stopped)
+	bs.Equal(4, stats.Items)
+	bs.Equal([]int{0, 1, 2, 3, 4, 5, 6, 6, 7, 8, 9}, processed, "Failed chunk should be processed again")
+	bs.Equal("10", ItemsProcessed.Get("test-resume").String())
+	bs.Equal("Batch job finished", hook.LastEntry().Message)
+	bs.Equal("0009", hook.LastEntry().Data["cursor"])
+}
+
+func (bs *BatchSuite) TestStop() {
+	ctx, cancel := context.WithCancel(context.Background())
+	job := &Job{
+		Name:      "test-stop",
+		Source:    sliceSource{count: 100},
+		ChunkSize: 10,
+		Process: func(ctx context.Context, chunk []Item) error {
+			cancel()
+			bs.NoError(ctx.Err(), "Current chunk should not be canceled")
+			return nil
+		},
+	}
+	stats, err := job.Run(ctx)
+	bs.NoError(err)
+	bs.True(stats.Stopped)
+	bs.Equal(1, stats.Chunks)
+	bs.Equal("0009", stats.Cursor)
+}
+
+func (bs *BatchSuite) TestRateLimit() {
+	mu := sync.Mutex{}
+	count := 0
+	job := &Job{
+		Name:      "test-rate",
+		Source:    sliceSource{count: 30},
+		ChunkSize: 10,
+		RateLimit: 100,
+		Process: func(ctx context.Context, chunk []Item) error {
+			mu.Lock()
+			defer mu.Unlock()
+			count += len(chunk)
+			return nil
+		},
+	}
+	stats, err := job.Run(context.Background())
+	bs.NoError(err)
+	bs.Equal(30, count)
+	bs.GreaterOrEqual(stats.Duration.Milliseconds(), int64(150), "Items after the burst should be rate limited")
+}
+
+func (bs *BatchSuite) TestFileCheckpointStore() {
+	store, err := NewFileCheckpointStore(bs.T().TempDir() + "/checkpoints")
+	bs.Require().NoError(err)
+
+	cursor, err := store.Load(context.Background(), "job")
+	bs.NoError(err)
+	bs.Empty(cursor)
+
+	bs.NoError(store.Save(context.Background(), "job", "0042"))
+	bs.NoError(store.Save(context.Background(), "job", "0043"))
+	cursor, err = store.Load(context.Background(), "job")
+	bs.NoError(err)
+	bs.Equal("0043", cursor)
+}
+
+func (bs *BatchSuite) TestS3Source() {
+	keys := []string{"exports/c", "exports/a", "other/x", "exports/b", "exports/d"}
+	sort.Strings(keys)
+	seen := []string{}
+	job := &Job{
+		Name:      "test-s3",
+		Source:    NewS3Source(fakeLister{keys: keys}, "bucket", "exports/"),
+		ChunkSize: 3,
+		Process: func(ctx context.Context, chunk []Item) error {
+			for _, item := range chunk {
+				seen = append(seen, aws.ToString(item.Value.(types.Object).Key))
+			}
+			return nil
+		},
+	}
+	stats, err := job.Run(context.Background())
+	bs.NoError(err)
+	bs.Equal(2, stats.Chunks)
+	bs.Equal([]string{"exports/a", "exports/b", "exports/c", "exports/d"}, seen)
+}
+
+// TestBatch runs the whole test suite
+func TestBatch(t *testing.T) {
+	suite.Run(t, new(BatchSuite))
+}
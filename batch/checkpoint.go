@@ -0,0 +1,96 @@
+package batch
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// CheckpointStore persists the cursor of the last processed item of the Jobs.
+type CheckpointStore interface {
+	// Load returns the saved cursor of the job, an empty string if there is none.
+	Load(ctx context.Context, job string) (string, error)
+
+	// Save persists the cursor of the job.
+	Save(ctx context.Context, job, cursor string) error
+}
+
+// FileCheckpointStore saves the checkpoints into files named after the jobs in Dir.
+type FileCheckpointStore struct {
+	Dir string
+}
+
+// NewFileCheckpointStore creates a new FileCheckpointStore, the directory is created if needed.
+func NewFileCheckpointStore(dir string) (*FileCheckpointStore, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, errors.Wrapf(err, "Failed to create checkpoint directory %s", dir)
+	}
+	return &FileCheckpointStore{Dir: dir}, nil
+}
+
+// Load implements the CheckpointStore interface.
+func (f *FileCheckpointStore) Load(ctx context.Context, job string) (string, error) {
+	content, err := ioutil.ReadFile(f.path(job))
+	if os.IsNotExist(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to read checkpoint of %s", job)
+	}
+	return strings.TrimSpace(string(content)), nil
+}
+
+// Save implements the CheckpointStore interface. The file is replaced atomically,
+// so a crash never leaves a partially written checkpoint.
+func (f *FileCheckpointStore) Save(ctx context.Context, job, cursor string) error {
+	tmp, err := ioutil.TempFile(f.Dir, ".checkpoint-*")
+	if err != nil {
+		return errors.Wrapf(err, "Failed to create checkpoint of %s", job)
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.WriteString(cursor + "\n"); err != nil {
+		tmp.Close()
+		return errors.Wrapf(err, "Failed to write checkpoint of %s", job)
+	}
+	if err := tmp.Close(); err != nil {
+		return errors.Wrapf(err, "Failed to write checkpoint of %s", job)
+	}
+	return errors.Wrapf(os.Rename(tmp.Name(), f.path(job)), "Failed to save checkpoint of %s", job)
+}
+
+// path returns the checkpoint file of the job.
+func (f *FileCheckpointStore) path(job string) string {
+	return filepath.Join(f.Dir, strings.ReplaceAll(job, string(filepath.Separator), "_")+".checkpoint")
+}
+
+// MemoryCheckpointStore keeps the checkpoints in memory, useful in tests and for jobs
+// which only need to survive a retry within the same process.
+type MemoryCheckpointStore struct {
+	mu      sync.Mutex
+	cursors map[string]string
+}
+
+// NewMemoryCheckpointStore creates a new, empty MemoryCheckpointStore.
+func NewMemoryCheckpointStore() *MemoryCheckpointStore {
+	return &MemoryCheckpointStore{cursors: map[string]string{}}
+}
+
+// Load implements the CheckpointStore interface.
+func (m *MemoryCheckpointStore) Load(ctx context.Context, job string) (string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.cursors[job], nil
+}
+
+// Save implements the CheckpointStore interface.
+func (m *MemoryCheckpointStore) Save(ctx context.Context, job, cursor string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cursors[job] = cursor
+	return nil
+}
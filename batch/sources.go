@@ -0,0 +1,94 @@
+package batch
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/pkg/errors"
+)
+
+// ScanFunc scans a single row of an SQLSource into an Item.
+type ScanFunc func(rows *sql.Rows) (Item, error)
+
+// SQLSource iterates the rows of a keyset paginated query.
+// The Query receives the cursor and the chunk size as its two arguments, and must return the rows
+// after the cursor ordered by the cursor column, e.g.
+//
+//	SELECT id, email FROM users WHERE id > $1 ORDER BY id LIMIT $2
+//
+// As the first cursor is an empty string, cast the cursor column if it is not textual, e.g.
+//
+//	WHERE id > COALESCE(NULLIF($1, ''), '0')::bigint
+type SQLSource struct {
+	DB    *sql.DB
+	Query string
+	Scan  ScanFunc
+}
+
+// NewSQLSource creates a new SQLSource.
+func NewSQLSource(db *sql.DB, query string, scan ScanFunc) *SQLSource {
+	return &SQLSource{DB: db, Query: query, Scan: scan}
+}
+
+// Next implements the Source interface.
+func (s *SQLSource) Next(ctx context.Context, cursor string, size int) ([]Item, error) {
+	rows, err := s.DB.QueryContext(ctx, s.Query, cursor, size)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to query the next chunk")
+	}
+	defer rows.Close()
+
+	items := []Item{}
+	for rows.Next() {
+		item, err := s.Scan(rows)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to scan row")
+		}
+		items = append(items, item)
+	}
+	return items, errors.Wrap(rows.Err(), "Failed to iterate rows")
+}
+
+// s3Lister is the part of the S3 client used by the S3Source.
+type s3Lister interface {
+	ListObjectsV2(ctx context.Context, params *s3.ListObjectsV2Input, optFns ...func(*s3.Options)) (*s3.ListObjectsV2Output, error)
+}
+
+// S3Source iterates the objects of an S3 bucket in lexicographical key order.
+// The cursor is the key of the object and the value of the items is the s3 types.Object.
+type S3Source struct {
+	Bucket string
+	Prefix string
+	client s3Lister
+}
+
+// NewS3Source creates a new S3Source listing the objects with prefix.
+func NewS3Source(client s3Lister, bucket, prefix string) *S3Source {
+	return &S3Source{Bucket: bucket, Prefix: prefix, client: client}
+}
+
+// Next implements the Source interface.
+func (s *S3Source) Next(ctx context.Context, cursor string, size int) ([]Item, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket:  aws.String(s.Bucket),
+		MaxKeys: aws.Int32(int32(size)),
+	}
+	if s.Prefix != "" {
+		input.Prefix = aws.String(s.Prefix)
+	}
+	if cursor != "" {
+		input.StartAfter = aws.String(cursor)
+	}
+
+	output, err := s.client.ListObjectsV2(ctx, input)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to list objects of s3://%s/%s", s.Bucket, s.Prefix)
+	}
+	items := make([]Item, 0, len(output.Contents))
+	for _, object := range output.Contents {
+		items = append(items, Item{Cursor: aws.ToString(object.Key), Value: object})
+	}
+	return items, nil
+}
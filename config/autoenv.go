@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// The priorities of the conventional envfiles of SetupAuto.
+const (
+	autoBasePriority = iota * 10
+	autoEnvPriority
+	autoLocalPriority
+)
+
+// WithSearchPaths sets the directories where SetupAuto looks for the envfiles, the working directory by default.
+// The envfiles of the later directories take precedence over the same envfiles of the earlier ones.
+func WithSearchPaths(paths ...string) SetupOption {
+	return func(o *setupOptions) {
+		o.searchPaths = append(o.searchPaths, paths...)
+	}
+}
+
+// SetupAuto sets up the Application's Configuration like SetupWithOptions, with the envfiles found by the
+// dotenv conventions in the search paths (see WithSearchPaths). The existing envfiles are loaded in the order
+// of precedence, the later ones take precedence:
+//  1. .env: the shared defaults
+//  2. .env.<APP_ENV>: the settings of the environment, e.g. .env.production
+//  3. .env.local: the local overrides of the machine, not loaded in the test environment, so the tests
+//     run with the same configuration everywhere
+//
+// The environment is APP_ENV of the environment variables, otherwise of the .env files, otherwise the default.
+// The envfiles are selected by the environment of the Setup, the files created later are loaded by Reload.
+func (appConf *AppConfig) SetupAuto(opts ...SetupOption) error {
+	searchPaths := newSetupOptions(opts...).searchPaths
+	if len(searchPaths) == 0 {
+		searchPaths = []string{"."}
+	}
+	return appConf.SetupWithOptions(append(opts, WithLayers(appConf.autoLayers(searchPaths)...))...)
+}
+
+// autoLayers returns the optional Layers of the conventional envfiles in the searchPaths, see SetupAuto.
+func (appConf *AppConfig) autoLayers(searchPaths []string) []Layer {
+	layers := []Layer{}
+	for _, path := range searchPaths {
+		layers = append(layers, Layer{Path: filepath.Join(path, ".env"), Priority: autoBasePriority, Optional: true})
+	}
+
+	environment := appConf.autoEnvironment(layers)
+	if environment != "" {
+		for _, path := range searchPaths {
+			layers = append(layers, Layer{Path: filepath.Join(path, ".env."+environment), Priority: autoEnvPriority, Optional: true})
+		}
+	}
+	if environment != constants.ENV_TEST {
+		for _, path := range searchPaths {
+			layers = append(layers, Layer{Path: filepath.Join(path, ".env.local"), Priority: autoLocalPriority, Optional: true})
+		}
+	}
+	return layers
+}
+
+// autoEnvironment returns APP_ENV of the environment variables, otherwise of the base Layers, otherwise the default.
+// The base Layers which cannot be read are skipped, they fail the Setup later.
+func (appConf *AppConfig) autoEnvironment(baseLayers []Layer) string {
+	name := appConf.externalName(constants.APP_ENV)
+	if environment := os.Getenv(name); environment != "" {
+		return environment
+	}
+	environment := ""
+	for _, layer := range baseLayers {
+		if values, err := ReadEnvfile(layer.Path); err == nil && values[name] != "" {
+			environment = values[name]
+		}
+	}
+	if environment != "" {
+		return environment
+	}
+	return appConf.defaultValues()[constants.APP_ENV]
+}
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSetupAuto() {
+	unsetEnv := func() {
+		for _, name := range constants.BasicEnvs {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+	shared, service := cts.T().TempDir(), cts.T().TempDir()
+	cts.writeEnvfile(filepath.Join(shared, ".env"), map[string]string{
+		constants.APP_ENV:       constants.ENV_PRODUCTION,
+		constants.APP_PORT:      "9090",
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN,
+	})
+	cts.writeEnvfile(filepath.Join(service, ".env.production"), map[string]string{
+		constants.APP_PORT: "7070",
+	})
+	cts.writeEnvfile(filepath.Join(service, ".env.test"), map[string]string{
+		constants.APP_PORT: "5050",
+	})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupAuto(WithSearchPaths(shared, service)), "Missing envfiles should be skipped")
+	cts.Equal("7070", conf.Port(), "The envfile of APP_ENV in the .env should take precedence")
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel())
+
+	local := filepath.Join(service, ".env.local")
+	cts.writeEnvfile(local, map[string]string{constants.APP_PORT: "6060"})
+	cts.NoError(conf.Reload())
+	cts.Equal("6060", conf.Port(), ".env.local should take precedence")
+	cts.Equal("envfile "+local, conf.Source(constants.APP_PORT))
+
+	unsetEnv()
+	cts.NoError(os.Setenv(constants.APP_ENV, constants.ENV_TEST), "Environment variable should have been set")
+	conf = NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupAuto(WithSearchPaths(shared, service)))
+	cts.Equal("5050", conf.Port(), "APP_ENV of the environment should select the envfile, .env.local should be skipped in tests")
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// clone returns a deep copy of the Variable, the maps of the copy can be modified independently.
+// The validation.Rules themselves are shared, as they are immutable.
+func (confVar *Variable) clone() *Variable {
+	copied := *confVar
+	if confVar.Rules != nil {
+		copied.Rules = make(map[string]validation.Rule, len(confVar.Rules))
+		for name, rule := range confVar.Rules {
+			copied.Rules[name] = rule
+		}
+	}
+	if confVar.DefaultsByEnv != nil {
+		copied.DefaultsByEnv = make(map[string]string, len(confVar.DefaultsByEnv))
+		for env, value := range confVar.DefaultsByEnv {
+			copied.DefaultsByEnv[env] = value
+		}
+	}
+	if confVar.Min != nil {
+		copied.Min = Limit(*confVar.Min)
+	}
+	if confVar.Max != nil {
+		copied.Max = Limit(*confVar.Max)
+	}
+	if confVar.RulesByEnv != nil {
+		copied.RulesByEnv = make(map[string]map[string]validation.Rule, len(confVar.RulesByEnv))
+		for env, rules := range confVar.RulesByEnv {
+			copied.RulesByEnv[env] = make(map[string]validation.Rule, len(rules))
+			for name, rule := range rules {
+				copied.RulesByEnv[env][name] = rule
+			}
+		}
+	}
+	return &copied
+}
+
+// Clone returns an independent copy of the AppConfig: its Variables (with their values, defaults and
+// rules), Constraints, validators, prefix, name normalization, schema version and the SetupOptions of the last Setup used by Reload.
+// The callbacks (e.g. OnChange and OnReloadError), the subscriptions and the watchers are not copied.
+func (appConf *AppConfig) Clone() *AppConfig {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	vars := make(map[string]*Variable, len(appConf.vars))
+	for name, confVar := range appConf.vars {
+		vars[name] = confVar.clone()
+	}
+	clone := NewConfig(vars, WithPrefix(appConf.prefix))
+	clone.normalizeNames = appConf.normalizeNames
+	clone.schemaVersion = appConf.schemaVersion
+	clone.migrations = appConf.migrations
+	clone.migrationWarning = appConf.migrationWarning
+	clone.setupOpts = append([]SetupOption{}, appConf.setupOpts...)
+	clone.constraints = append([]Constraint{}, appConf.constraints...)
+	clone.validators = appConf.validators
+	return clone
+}
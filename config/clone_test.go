@@ -0,0 +1,44 @@
+package config
+
+import (
+	"os"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestClone() {
+	for _, name := range constants.BasicEnvs {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	conf := NewConfig(cts.getDefaultConfigs())
+	conf.AddConstraints(Constraint{
+		Name: "Port is not 1234",
+		Validate: func(values map[string]string) error {
+			if values[constants.APP_PORT] == "1234" {
+				return validation.NewError("port", "must not be 1234")
+			}
+			return nil
+		},
+	})
+	cts.NoError(conf.Setup())
+
+	clone := conf.Clone()
+	cts.Equal(conf.Port(), clone.Port(), "The values should be copied")
+	cts.NoError(clone.Set(constants.APP_PORT, "9090"))
+	cts.Equal("8080", conf.Port(), "Setting the clone should not modify the original")
+
+	cts.NoError(clone.AddVariable("APP_DB_HOST", &Variable{}))
+	_, ok := conf.Lookup("APP_DB_HOST")
+	cts.False(ok, "Adding a Variable to the clone should not modify the original")
+
+	clone.vars[constants.APP_PORT].Rules["Required"] = validation.Length(10, 10)
+	clone.vars[constants.APP_PORT].DefaultValue = "1"
+	cts.Len(conf.vars[constants.APP_PORT].Rules, 2)
+	cts.Equal(validation.Required, conf.vars[constants.APP_PORT].Rules["Required"], "Rules should be copied")
+	cts.Equal("8080", conf.vars[constants.APP_PORT].DefaultValue, "Defaults should be copied")
+
+	clone = conf.Clone()
+	clone.Override(map[string]string{constants.APP_PORT: "1234"})
+	cts.Error(clone.Validate(), "Constraints should be copied")
+}
@@ -4,20 +4,23 @@ package config
 
 import (
 	"bufio"
+	"context"
 	"fmt"
+	"io/ioutil"
 	"os"
-	"sort"
 	"strconv"
 	"strings"
+	"sync"
 
 	validation "github.com/go-ozzo/ozzo-validation/v4"
-	"github.com/joho/godotenv"
-	"github.com/olekukonko/tablewriter"
 	"github.com/pkg/errors"
 	"github.com/sirupsen/logrus"
 	"github.com/universal-devs/go-utilities/constants"
 )
 
+// MaskedValue replaces the values of the Sensitive Variables, it matches the masking of the logger package.
+const MaskedValue = "*****"
+
 // Variable represents a single configuration item.
 type Variable struct {
 	// Value is the actual value of the Variable.
@@ -26,45 +29,282 @@ type Variable struct {
 	// DefaultValue is the default value of the Variable.
 	DefaultValue string
 
+	// DefaultFunc computes the default value at the first Setup, e.g. a generated instance ID or the detected
+	// number of CPUs. It takes precedence over DefaultValue, and it is called only once, the Reloads reuse
+	// its result.
+	DefaultFunc func() (string, error)
+
 	// Description is the brief description of the Variable.
 	Description string
 
 	// Rules are a map of named validation.Rules that should apply to the Variable's Value.
 	Rules map[string]validation.Rule
+
+	// Sensitive marks secret values (passwords, API keys, ...), which are masked in the dumps,
+	// sample files and error messages, but still returned by Get.
+	Sensitive bool
+
+	// Redact replaces the non-empty values shown to humans (the dumps, the debug Handler, the error messages
+	// and the LogFields) instead of the masking of Sensitive, so partially visible values remain debuggable,
+	// e.g. config.RedactKeepLast(4) for API keys. Get still returns the original value.
+	Redact func(value string) string
+
+	// Group is the name of the group of related Variables (e.g. "Database"), used by the documentation.
+	Group string
+
+	// DefaultsByEnv are the default values by environment (APP_ENV), they take precedence over DefaultValue.
+	// E.g. {"dev": "debug", "production": "info"}.
+	DefaultsByEnv map[string]string
+
+	// RulesByEnv are the additional validation.Rules by environment (APP_ENV), applied together with Rules
+	// when the application runs in the environment, e.g. stricter rules in production:
+	//	{constants.ENV_PRODUCTION: {"Disabled in production": rules.Falsy}}
+	RulesByEnv map[string]map[string]validation.Rule
+
+	// Hint describes the format of the Value in the sample file, e.g. DurationHint.
+	Hint string
+
+	// ExpandEnv expands the ${NAME} references of the default values (DefaultValue, DefaultsByEnv) to the
+	// environment variables at every Setup and Reload, e.g. "${HOME}/data". The references to the Variables
+	// and to the unset environment variables are kept, see NoExpand.
+	ExpandEnv bool
+
+	// NoExpand disables the expansion of the ${NAME} references in the Value, e.g. for passwords.
+	NoExpand bool
+
+	// Min and Max are the inclusive limits of a numeric Value, e.g. Min: config.Limit(1), not checked if nil.
+	// Unlike the Rules, the limits are listed in the sample file and the documentation.
+	Min, Max *float64
+
+	// MinLen and MaxLen are the limits of the length of the Value in characters, not checked if 0.
+	MinLen, MaxLen int
+
+	// Deprecated marks a Variable which is going to be removed, with a note for the users,
+	// e.g. "Use APP_LOG_LEVEL instead". The deprecated Variables set in an envfile are reported by Lint.
+	Deprecated string
+
+	// Derive computes the value of a derived Variable from the other Variables on every Setup and Reload,
+	// the derived value takes precedence over every other source. See DeriveFunc.
+	Derive DeriveFunc
+
+	// computedDefault is the result of DefaultFunc, valid if computed is true.
+	computedDefault string
+	computed        bool
+
+	// origin is the location the Value was resolved from, see AppConfig.Source.
+	origin string
+
+	// convert caches the conversion of the Value of a Typed Variable, see setValue.
+	convert func(value string)
+}
+
+// setValue sets the Value and its origin, and converts the Value of a Typed Variable.
+func (confVar *Variable) setValue(value, origin string) {
+	confVar.Value = value
+	confVar.origin = origin
+	if confVar.convert != nil {
+		confVar.convert(value)
+	}
+}
+
+// display returns value as it can be shown to humans, redacted by Redact or masked if the Variable is Sensitive.
+func (confVar *Variable) display(value string) string {
+	if confVar.Redact != nil && value != "" {
+		return confVar.Redact(value)
+	}
+	if confVar.Sensitive && value != "" {
+		return MaskedValue
+	}
+	return value
+}
+
+// validate applies the Variable's validation rules on value and returns the errors.
+// The rules of the limits and the RulesByEnv of the environment in values (APP_ENV) are applied too.
+// The context-aware rules (validation.RuleWithContext) receive values, the values of every Variable.
+func (confVar *Variable) validate(value string, values map[string]string) validation.Errors {
+	ctx := withValues(context.Background(), values)
+	// validationErrors collects all validation error associated with one variable
+	validationErrors := validation.Errors{}
+	// iterate over rules, including the rules of the current environment
+	for _, rules := range []map[string]validation.Rule{confVar.Rules, confVar.limitRules(), confVar.RulesByEnv[values[constants.APP_ENV]]} {
+		for ruleName, rule := range rules {
+			// call the rule on the value and collect errors
+			var err error
+			if contextRule, ok := rule.(validation.RuleWithContext); ok {
+				err = contextRule.ValidateWithContext(ctx, value)
+			} else {
+				err = rule.Validate(value)
+			}
+			if err != nil {
+				validationErrors[ruleName] = err
+			}
+		}
+	}
+	return validationErrors
 }
 
 // AppConfig is the collection of application configuration items of an application.
+//
+// AppConfig is safe for concurrent use: the getters (Get, Lookup and the helper functions) can be called
+// while Setup, Reload or the watchers update the configuration. The new values are loaded without holding
+// any lock, then applied together, so a reader observes either every old or every new value of a Reload.
+// The Variables passed to NewConfig must not be modified directly after the AppConfig is created.
 type AppConfig struct {
+	// mu guards vars, the Values of the Variables, setupOpts, envfileEnv and the callbacks
+	mu sync.RWMutex
+
+	// reloadMu serializes the Reloads
+	reloadMu sync.Mutex
+
 	vars map[string]*Variable
+
+	// setupOpts are the SetupOptions of the last Setup, used by Reload.
+	setupOpts []SetupOption
+
+	// onChange are the callbacks invoked after a Reload changed any value.
+	onChange []func(changed []string)
+
+	// onReloadError are the callbacks invoked after a failed Reload.
+	onReloadError []func(err error)
+
+	// onWatchError are the callbacks invoked with the errors of the watchers.
+	onWatchError []func(err error)
+
+	// onDroppedChange are the callbacks invoked with the Changes dropped by the full subscriptions.
+	onDroppedChange []func(change Change)
+
+	// onReload are the callbacks invoked after a successful Reload.
+	onReload []func()
+
+	// constraints are the validation rules spanning several Variables, including the validators.
+	constraints []Constraint
+
+	// validators is the number of the validators added by AddValidator.
+	validators int
+
+	// subMu guards the subscriptions, it is held while the Changes are delivered
+	subMu sync.Mutex
+
+	// subscriptions are the channels registered by Subscribe.
+	subscriptions []*subscription
+
+	// target is the struct of FromStruct, which is unmarshalled after the Setup.
+	target interface{}
+
+	// prefix replaces the DefaultPrefix of the Variable names outside of the application, set by WithPrefix.
+	prefix string
+
+	// envfileEnv are the environment variables set from the envfiles with WithEnvPrecedence.
+	envfileEnv map[string]string
+
+	// normalizeNames makes Lookup resolve the normalized names, set by WithNormalizedNames.
+	normalizeNames bool
+
+	// schemaVersion is the version of the config schema, set by WithSchemaVersion.
+	schemaVersion int
+
+	// migrations migrate the envfiles of the earlier schema versions, ordered by version.
+	migrations []Migration
+
+	// migrationWarning is called with the warnings of the migrated envfiles, set by WithMigrationWarning.
+	migrationWarning func(warning string)
 }
 
-// NewConfig creates a new AppConfig with the supplied default Variables.
-func NewConfig(defaults map[string]*Variable) *AppConfig {
+// NewConfig creates a new AppConfig with the supplied default Variables and ConfigOptions.
+func NewConfig(defaults map[string]*Variable, opts ...ConfigOption) *AppConfig {
 	conf := &AppConfig{
 		vars: make(map[string]*Variable),
 	}
 	if defaults != nil {
 		conf.vars = defaults
 	}
+	for _, opt := range opts {
+		opt(conf)
+	}
 	return conf
 }
 
 // Setup the Application's Configuration according to the defaults, environment variables and the envfile(s).
 // If no env file supplied, only the defaults and environment variables will be checked.
 // Return an error if the config file(s) cannot be loaded, or the configurations are invalid.
-// On invalid configs the returned error will be the type validation.Errors.
+// On invalid configs the returned error will be the type *SetupError, listing every failed Variable.
 func (appConf *AppConfig) Setup(envfiles ...string) error {
-	if err := appConf.loadEnv(envfiles...); err != nil {
+	return appConf.SetupWithOptions(WithEnvfiles(envfiles...))
+}
+
+// SetupWithOptions sets up the Application's Configuration like Setup, but the sources of the
+// variables (envfiles, YAML files, Sources, ...) are selected by the supplied SetupOptions.
+// Return an error if any of the sources cannot be loaded, or the configurations are invalid.
+func (appConf *AppConfig) SetupWithOptions(opts ...SetupOption) error {
+	values, origins, err := appConf.resolve(opts...)
+	if err != nil {
 		return errors.Wrap(err, "Failed to set Application Configuration")
 	}
-	return appConf.Validate()
+
+	appConf.mu.Lock()
+	appConf.setupOpts = opts
+	appConf.applyValues(values, origins)
+	appConf.mu.Unlock()
+
+	if setupErr := appConf.setupError(); setupErr != nil {
+		return setupErr
+	}
+	if appConf.target != nil {
+		return appConf.Unmarshal(appConf.target)
+	}
+	return nil
+}
+
+// resolve loads the value of every Variable from the locations selected by the SetupOptions,
+// without modifying the AppConfig. It also returns the origin of every value, see Source.
+func (appConf *AppConfig) resolve(opts ...SetupOption) (map[string]string, map[string]string, error) {
+	options := newSetupOptions(opts...)
+	if err := appConf.computeDefaults(); err != nil {
+		return nil, nil, err
+	}
+	values := appConf.defaultValues()
+	origins := make(map[string]string, len(values))
+	setOrigins(origins, values, values, OriginDefault)
+	layerFiles, err := options.layerFiles()
+	if err != nil {
+		return nil, nil, err
+	}
+	envfiles := append(append([]string{}, options.envfiles...), layerFiles...)
+	env, err := appConf.loadEnv(values, origins, options.envPrecedence, envfiles...)
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := appConf.checkUnknownEnv(values, env, options); err != nil {
+		return nil, nil, err
+	}
+	// The files, the Sources and the flags use the external names of the Variables
+	external, externalOrigins := appConf.externalValues(values), appConf.externalValues(origins)
+	if err := appConf.loadYAML(external, externalOrigins, options.yamlFiles...); err != nil {
+		return nil, nil, err
+	}
+	if err := loadSources(external, externalOrigins, options.sources...); err != nil {
+		return nil, nil, err
+	}
+	loadFlags(external, externalOrigins, options.flags)
+	values, origins = appConf.internalValues(external), appConf.internalValues(externalOrigins)
+	// The defaults of the environment follow APP_ENV resolved by every location above
+	appConf.applyEnvDefaults(values, origins, values[constants.APP_ENV])
+	if err := appConf.expandValues(values); err != nil {
+		return nil, nil, err
+	}
+	if err := appConf.deriveValues(values, origins); err != nil {
+		return nil, nil, err
+	}
+	return values, origins, nil
 }
 
 // Lookup returns the named Application Configuration Variable's value (or an empty string),
 // and a boolean indicating if it was find or not.
 func (appConf *AppConfig) Lookup(name string) (string, bool) {
-	if val, ok := appConf.vars[name]; ok {
-		return val.Value, true
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	if name, ok := appConf.variableName(name); ok {
+		return appConf.vars[name].Value, true
 	}
 	return "", false
 }
@@ -77,30 +317,19 @@ func (appConf *AppConfig) Get(name string) string {
 
 // ValidationErrors applies on each Variable its own validation rules, unifies the errors and returns them.
 func (appConf *AppConfig) ValidationErrors() validation.Errors {
-	// allErrors collects all validation errors
-	allErrors := validation.Errors{}
-
-	// iterate over variables
-	for confKey, confVar := range appConf.vars {
-		// validationErrors collects all validation error associated with one variable
-		validationErrors := validation.Errors{}
-		// iterate over rules
-		for ruleName, rule := range confVar.Rules {
-			// call the rule on the value and collect errors
-			if err := rule.Validate(confVar.Value); err != nil {
-				validationErrors[ruleName] = err
-			}
-		}
-		// if there were any validation error add them to the top level collection
-		if len(validationErrors) > 0 {
-			allErrors[fmt.Sprintf("%s = %s", confKey, confVar.Value)] = validationErrors.Filter()
-		}
-	}
+	appConf.mu.RLock()
+	values := appConf.values()
+	appConf.mu.RUnlock()
+	return appConf.validateValues(values)
+}
 
-	if len(allErrors) > 0 {
-		return allErrors
+// validateValues applies on each value the validation rules of its Variable and the Constraints,
+// unifies the errors and returns them.
+// The caller must not hold the lock, see validateConstraints.
+func (appConf *AppConfig) validateValues(values map[string]string) validation.Errors {
+	if errs, _ := appConf.validate(values); len(errs) > 0 {
+		return errs
 	}
-
 	return nil
 }
 
@@ -113,28 +342,172 @@ func (appConf *AppConfig) Validate() error {
 	return nil
 }
 
-// loadEnv loads variables from the envfile(s) and the environment, into the AppConfig.
-// Variables in the envfile(s) takes precedence over environment variables.
-func (appConf *AppConfig) loadEnv(envfiles ...string) error {
-	// If any env file is provided try load it.
-	if len(envfiles) > 0 {
-		// Overload existing environment variables with the ones in the envfile(s).
-		if err := godotenv.Overload(envfiles...); err != nil {
-			return errors.Wrap(err, "Failed to overload variables with envfile(s)")
+// defaultValues returns the default value of every Variable.
+func (appConf *AppConfig) defaultValues() map[string]string {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	values := make(map[string]string, len(appConf.vars))
+	for confKey, confVar := range appConf.vars {
+		values[confKey] = appConf.expandEnv(confVar, confVar.DefaultValue)
+		if confVar.computed {
+			values[confKey] = confVar.computedDefault
 		}
 	}
+	return values
+}
 
-	// Iterate over all Variables
+// computeDefaults calls the DefaultFunc of the Variables which are not computed yet, and caches the results.
+// The functions are called without holding the lock, so they may use the AppConfig.
+func (appConf *AppConfig) computeDefaults() error {
+	computed, err := appConf.callDefaultFuncs()
+	if err != nil || len(computed) == 0 {
+		return err
+	}
+
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	for confKey, value := range computed {
+		if confVar, ok := appConf.vars[confKey]; ok && !confVar.computed {
+			confVar.computedDefault, confVar.computed = value, true
+		}
+	}
+	return nil
+}
+
+// callDefaultFuncs calls the DefaultFunc of the Variables which are not computed yet, and returns the results
+// without caching them. The functions are called without holding the lock.
+func (appConf *AppConfig) callDefaultFuncs() (map[string]string, error) {
+	appConf.mu.RLock()
+	funcs := map[string]func() (string, error){}
+	for confKey, confVar := range appConf.vars {
+		if confVar.DefaultFunc != nil && !confVar.computed {
+			funcs[confKey] = confVar.DefaultFunc
+		}
+	}
+	appConf.mu.RUnlock()
+
+	computed := make(map[string]string, len(funcs))
+	for confKey, fn := range funcs {
+		value, err := fn()
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to compute the default value of %s", appConf.externalName(confKey))
+		}
+		computed[confKey] = value
+	}
+	return computed, nil
+}
+
+// applyEnvDefaults sets the DefaultsByEnv of the environment in values. If origins is not nil, only the values
+// still having their default origin are set, and their origins are updated.
+func (appConf *AppConfig) applyEnvDefaults(values, origins map[string]string, environment string) {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
 	for confKey, confVar := range appConf.vars {
-		// Set default
-		appConf.vars[confKey].Value = confVar.DefaultValue
+		if origins != nil && origins[confKey] != OriginDefault {
+			continue
+		}
+		if val, ok := confVar.DefaultsByEnv[environment]; ok {
+			values[confKey] = appConf.expandEnv(confVar, val)
+			if origins != nil {
+				origins[confKey] = fmt.Sprintf("%s (%s)", OriginDefault, environment)
+			}
+		}
+	}
+}
+
+// loadEnv loads variables from the envfile(s) and the environment, into values and their origins.
+// Variables in the envfile(s) takes precedence over environment variables, unless envPrecedence is true.
+// The envfiles set their variables in the environment of the process, like godotenv.Overload, or like
+// godotenv.Load with WithEnvPrecedence.
+// A Variable can also be read from the file named by its name + FileSuffix variable (e.g. Docker secrets).
+// It returns the envLookup of the environment variables.
+func (appConf *AppConfig) loadEnv(values, origins map[string]string, envPrecedence bool, envfiles ...string) (envLookup, error) {
+	env, err := appConf.readEnvfiles(envPrecedence, envfiles...)
+	if err != nil {
+		return env, err
+	}
+
+	// Iterate over all Variables
+	for confKey := range values {
+		name := appConf.externalName(confKey)
 		// Check in environment
-		if val := os.Getenv(confKey); val != "" {
-			appConf.vars[confKey].Value = val
+		if val, origin := env.lookup(name); val != "" {
+			values[confKey] = val
+			origins[confKey] = origin
+		}
+		// Check the file referenced by the environment
+		val, err := loadEnvFile(env, name)
+		if err != nil {
+			return env, err
+		}
+		if val != "" {
+			values[confKey] = val
+			origins[confKey] = "file " + name + FileSuffix
 		}
 	}
 
-	return nil
+	return env, nil
+}
+
+// readEnvfiles reads the variables of the envfile(s), later files take precedence over earlier ones.
+func (appConf *AppConfig) readEnvfiles(envPrecedence bool, envfiles ...string) (envLookup, error) {
+	env := envLookup{envfiles: map[string]string{}, origins: map[string]string{}}
+	for _, envfile := range envfiles {
+		var loaded map[string]string
+		if envPrecedence {
+			var err error
+			if loaded, err = ReadEnvfile(envfile); err != nil {
+				return env, err
+			}
+		} else {
+			content, err := ioutil.ReadFile(envfile)
+			if err != nil {
+				return env, errors.Wrap(err, "Failed to overload variables with envfile(s)")
+			}
+			if loaded, err = ParseEnvfile(content); err != nil {
+				return env, errors.Wrapf(err, "Failed to parse envfile %s", envfile)
+			}
+		}
+		loaded, err := appConf.migrateEnvfile(envfile, loaded)
+		if err != nil {
+			return env, err
+		}
+		for name, value := range loaded {
+			env.envfiles[name] = value
+			env.origins[name] = "envfile " + envfile
+		}
+	}
+	if envPrecedence {
+		// Only set the variables missing from the environment.
+		return env, appConf.underloadEnv(env)
+	}
+	for name, value := range env.envfiles {
+		if err := os.Setenv(name, value); err != nil {
+			return env, errors.Wrapf(err, "Failed to set %s from %s", name, env.origins[name])
+		}
+	}
+	return env, nil
+}
+
+// FileSuffix is the suffix of the environment variables referencing the file which contains the value of
+// a Variable, e.g. the value of APP_DB_PASSWORD is read from the file named by APP_DB_PASSWORD_FILE.
+const FileSuffix = "_FILE"
+
+// loadEnvFile returns the trimmed content of the file named by the name + FileSuffix environment variable,
+// or an empty string if it is not set. Setting both name and name + FileSuffix is an error.
+func loadEnvFile(env envLookup, name string) (string, error) {
+	filename, _ := env.lookup(name + FileSuffix)
+	if filename == "" {
+		return "", nil
+	}
+	if val, _ := env.lookup(name); val != "" {
+		return "", errors.Errorf("Both %s and %s%s are set", name, name, FileSuffix)
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return "", errors.Wrapf(err, "Failed to read %s%s", name, FileSuffix)
+	}
+	return strings.TrimSpace(string(content)), nil
 }
 
 /////////////////////////////////////////
@@ -228,67 +601,16 @@ func (appConf *AppConfig) Address() string {
 }
 
 // DumpTable creates a string table with all the config variable names,
-// descriptions, constraints and default values
+// descriptions, constraints, default values and the origins of the values. The values of the Sensitive variables are masked.
+// The Variables are ordered by their Group, if any.
 func (appConf *AppConfig) DumpTable() string {
-	// Add the config variables to data in alphabetic order
-	data := [][]string{}
-	keys := []string{}
-	for key := range appConf.vars {
-		keys = append(keys, key)
-	}
-	// Sort is needed because maps always return values in random order
-	sort.Strings(keys)
-	for _, key := range keys {
-		elem := appConf.vars[key]
-		// Collect constraints
-		constraints := []string{}
-		for rule := range elem.Rules {
-			constraints = append(constraints, rule)
-		}
-		// Sort is needed because maps always return values in random order
-		sort.Strings(constraints)
-		constraintList := strings.Join(constraints, ", ")
-		data = append(data, []string{key, elem.Description, constraintList, elem.DefaultValue})
-	}
-
-	// Create the table
-	tableString := &strings.Builder{}
-	table := tablewriter.NewWriter(tableString)
-	table.SetHeader([]string{"Variable Name", "Description", "Constraints", "Default Value"})
-	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
-	table.SetRowSeparator("-")
-	table.SetRowLine(true)
-	table.SetAutoWrapText(false)
-	table.SetAlignment(tablewriter.ALIGN_LEFT)
-	table.AppendBulk(data)
-	table.Render()
-
-	return tableString.String()
+	return dumpTable(appConf.dumpRows())
 }
 
 // CreateSampleFile creates the .env.sample file based on the AppConfig variables with description and constraints.
+// The Variables are ordered by their Group, every group starts with a header comment.
+// The default values of the Sensitive variables are left empty.
 func (appConf *AppConfig) CreateSampleFile(filename string) error {
-	// Add the config variables to data in alphabetic order
-	data := [][]string{}
-	keys := []string{}
-	for key := range appConf.vars {
-		keys = append(keys, key)
-	}
-	// Sort is needed because maps always return values in random order
-	sort.Strings(keys)
-	for _, key := range keys {
-		elem := appConf.vars[key]
-		// Collect constraints
-		constraints := []string{}
-		for rule := range elem.Rules {
-			constraints = append(constraints, rule)
-		}
-		// Sort is needed because maps always return values in random order
-		sort.Strings(constraints)
-		constraintList := strings.Join(constraints, ", ")
-		data = append(data, []string{key, elem.DefaultValue, elem.Description, constraintList})
-	}
-
 	// Open the file for read and write, this will overwrite already existing files
 	file, err := os.OpenFile(filename, os.O_RDWR|os.O_CREATE|os.O_TRUNC, 0644)
 	if err != nil {
@@ -306,14 +628,44 @@ func (appConf *AppConfig) CreateSampleFile(filename string) error {
 	if _, err := datawriter.WriteString("# Automatically created by the application from the config object\n\n"); err != nil {
 		return errors.Wrap(err, "Failed to write line into buffer")
 	}
-	for _, elem := range data {
+	if appConf.schemaVersion > 0 {
+		line := fmt.Sprintf("# Version of the config schema\n%s=%d\n\n", appConf.externalName(constants.APP_CONFIG_SCHEMA_VERSION), appConf.schemaVersion)
+		if _, err := datawriter.WriteString(line); err != nil {
+			return errors.Wrap(err, "Failed to write line into buffer")
+		}
+	}
+	rows := appConf.dumpRows()
+	// The rows are sorted by name already, the stable sort keeps that order within the groups
+	sortByGroup(rows)
+	for i, row := range rows {
+		// Write the header of the group
+		if row.Group != "" && (i == 0 || rows[i-1].Group != row.Group) {
+			_, err = datawriter.WriteString(fmt.Sprintf("# ---- %s ----\n\n", row.Group))
+			if err != nil {
+				return errors.Wrap(err, "Failed to write line into buffer")
+			}
+		}
 		// Write description line
-		_, err = datawriter.WriteString(fmt.Sprintf("# Description: %s # Constraints: %s\n", elem[2], elem[3]))
+		_, err = datawriter.WriteString(fmt.Sprintf("# Description: %s # Constraints: %s\n", row.Description, strings.Join(row.Constraints, ", ")))
 		if err != nil {
 			return errors.Wrap(err, "Failed to write line into buffer")
 		}
+		// Document the format of the value
+		if row.Hint != "" {
+			_, err = datawriter.WriteString(fmt.Sprintf("# Format: %s\n", row.Hint))
+			if err != nil {
+				return errors.Wrap(err, "Failed to write line into buffer")
+			}
+		}
+		// Document the file alternative of the secrets
+		if row.Sensitive {
+			_, err = datawriter.WriteString(fmt.Sprintf("# Or set %s%s to read it from a file\n", row.Name, FileSuffix))
+			if err != nil {
+				return errors.Wrap(err, "Failed to write line into buffer")
+			}
+		}
 		// Write variable line
-		_, err = datawriter.WriteString(fmt.Sprintf("%s=%s\n\n", elem[0], elem[1]))
+		_, err = datawriter.WriteString(fmt.Sprintf("%s=%s\n\n", row.Name, row.sample))
 		if err != nil {
 			return errors.Wrap(err, "Failed to write line into buffer")
 		}
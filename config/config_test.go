@@ -3,6 +3,7 @@ package config
 import (
 	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/go-ozzo/ozzo-validation/is"
@@ -131,7 +132,7 @@ func (cts *ConfigTestSuite) TestDefaults() {
 
 	conf := NewConfig(cts.getDefaultConfigs())
 
-	cts.NoError(conf.loadEnv(), "Defaults and environment variables should have been loaded")
+	cts.NoError(conf.Setup(), "Defaults and environment variables should have been loaded")
 	cts.NoError(conf.Validate(), "The default configs should be valid")
 	cts.Equalf(cts.hostname(), conf.Hostname(), "Hostname should return %s", cts.hostname())
 	cts.Equal("8080", conf.Port(), "Port should return 8080")
@@ -155,6 +156,103 @@ func (cts *ConfigTestSuite) TestDefaults() {
 	cts.Contains(tab, "TCP/IP Port where the application listens", "TCP Port where the application listens should be on the table")
 }
 
+func (cts *ConfigTestSuite) TestDefaultsByEnv() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer func(fileName string) {
+		cts.NoErrorf(os.Remove(fileName), "Temp envfile (%s) should have been removed", fileName)
+	}(envFile)
+
+	defaults := cts.getDefaultConfigs()
+	defaults[constants.APP_LOG_LEVEL].DefaultsByEnv = map[string]string{
+		constants.ENV_DEV:        constants.LOG_LEVEL_DEBUG,
+		constants.ENV_PRODUCTION: constants.LOG_LEVEL_INFO,
+	}
+	defaults[constants.APP_DEBUG].DefaultsByEnv = map[string]string{
+		constants.ENV_PRODUCTION: "0",
+	}
+	conf := NewConfig(defaults)
+	cts.NoError(conf.Setup(), "Defaults and environment variables should have been loaded")
+	cts.Equal(constants.LOG_LEVEL_DEBUG, conf.LogLevel(), "DefaultValue should be used without environment default")
+
+	cts.writeEnvfile(envFile, map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION})
+	cts.NoError(conf.Setup(envFile), "Defaults, environment variables and envfile should have been loaded")
+	cts.Equal(constants.LOG_LEVEL_INFO, conf.LogLevel(), "Production default should be used")
+	cts.False(conf.IsDebug(), "Production default should be used")
+
+	cts.setEnvVars(map[string]string{constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN})
+	cts.NoError(conf.Setup(envFile), "Defaults, environment variables and envfile should have been loaded")
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel(), "Environment variable should take precedence over the defaults")
+	cts.NoError(os.Unsetenv(constants.APP_LOG_LEVEL), "Environment variable should have been unset")
+
+	yamlFile := filepath.Join(cts.T().TempDir(), "config.yaml")
+	cts.NoError(ioutil.WriteFile(yamlFile, []byte("app:\n  env: production\n"), 0600))
+	cts.NoError(conf.SetupWithOptions(WithYAMLFiles(yamlFile)))
+	cts.Equal(constants.LOG_LEVEL_INFO, conf.LogLevel(), "APP_ENV of the YAML files should select the defaults")
+	cts.Equal(OriginDefault+" ("+constants.ENV_PRODUCTION+")", conf.Source(constants.APP_LOG_LEVEL))
+	cts.NoError(os.Unsetenv(constants.APP_ENV), "Environment variable should have been unset")
+	cts.NoError(os.Unsetenv(constants.APP_LOG_LEVEL), "Environment variable should have been unset")
+}
+
+func (cts *ConfigTestSuite) TestRulesByEnv() {
+	conf := NewConfig(map[string]*Variable{
+		constants.APP_ENV: {DefaultValue: constants.ENV_DEV},
+		constants.APP_DEBUG: {
+			DefaultValue: "true",
+			RulesByEnv: map[string]map[string]validation.Rule{
+				constants.ENV_PRODUCTION: {"Disabled in production": validation.In("0", "false")},
+			},
+		},
+		"APP_DB_SSL_MODE": {
+			DefaultValue: constants.SSL_MODE_DISABLE,
+			Rules: map[string]validation.Rule{
+				"Valid SSL mode": validation.In(constants.ValidSSLModes...),
+			},
+			RulesByEnv: map[string]map[string]validation.Rule{
+				constants.ENV_PRODUCTION: {"SSL in production": validation.NotIn(constants.SSL_MODE_DISABLE)},
+			},
+		},
+	})
+	conf.Override(conf.defaultValues())
+	cts.NoError(conf.Validate(), "Production rules should not apply in dev")
+
+	conf.Override(map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION})
+	cts.EqualError(conf.Validate(), "APP_DB_SSL_MODE = disable: (SSL in production: must not be in list.); "+
+		"APP_DEBUG = true: (Disabled in production: must be a valid value.).", "Production rules should apply in production")
+
+	conf.Override(map[string]string{constants.APP_DEBUG: "0", "APP_DB_SSL_MODE": constants.SSL_MODE_VERIFY_FULL})
+	cts.NoError(conf.Validate(), "Valid production values should be accepted")
+	cts.Contains(conf.DumpTable(), "SSL in production (production)", "Environment rules should be listed")
+}
+
+func (cts *ConfigTestSuite) TestFileSuffix() {
+	secretFile := filepath.Join(cts.T().TempDir(), "db_password")
+	cts.NoError(ioutil.WriteFile(secretFile, []byte("s3cr3t\n"), 0600))
+	cts.setEnvVars(map[string]string{"APP_DB_PASSWORD_FILE": secretFile})
+	defer func() {
+		cts.NoError(os.Unsetenv("APP_DB_PASSWORD_FILE"), "Environment variable should have been unset")
+		cts.NoError(os.Unsetenv("APP_DB_PASSWORD"), "Environment variable should have been unset")
+	}()
+
+	conf := NewConfig(map[string]*Variable{
+		"APP_DB_PASSWORD": {Sensitive: true},
+	})
+	cts.NoError(conf.Setup())
+	cts.Equal("s3cr3t", conf.Get("APP_DB_PASSWORD"), "Value should be read from the file and trimmed")
+
+	cts.setEnvVars(map[string]string{"APP_DB_PASSWORD": "other"})
+	cts.EqualError(conf.Setup(), "Failed to set Application Configuration: Both APP_DB_PASSWORD and APP_DB_PASSWORD_FILE are set")
+
+	cts.NoError(os.Unsetenv("APP_DB_PASSWORD"), "Environment variable should have been unset")
+	cts.setEnvVars(map[string]string{"APP_DB_PASSWORD_FILE": secretFile + ".missing"})
+	cts.Error(conf.Setup(), "Missing file should fail the Setup")
+
+	sampleFile := filepath.Join(cts.T().TempDir(), ".env.sample")
+	cts.NoError(conf.CreateSampleFile(sampleFile), "The sample file should have been created")
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err, "The sample file should be readable")
+	cts.Contains(string(content), "# Or set APP_DB_PASSWORD_FILE to read it from a file\nAPP_DB_PASSWORD=\n")
+}
+
 func (cts *ConfigTestSuite) TestCreateSampleFile() {
 	sampleFile := cts.setupEnvTest(constants.BasicEnvs...)
 	cts.T().Logf("sampleFile: %s", sampleFile)
@@ -177,6 +275,40 @@ func (cts *ConfigTestSuite) TestCreateSampleFile() {
 	}
 }
 
+func (cts *ConfigTestSuite) TestSensitive() {
+	sampleFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer func(fileName string) {
+		cts.NoErrorf(os.Remove(fileName), "Temp sampleFile (%s) should have been removed", fileName)
+	}(sampleFile)
+
+	defaults := cts.getDefaultConfigs()
+	defaults["APP_API_KEY"] = &Variable{
+		DefaultValue: "s3cr3t-default",
+		Description:  "The key of the API",
+		Rules: map[string]validation.Rule{
+			"Length": validation.Length(10, 20),
+		},
+		Sensitive: true,
+	}
+	conf := NewConfig(defaults)
+	cts.NoError(conf.Setup(), "Defaults and environment variables should have been loaded")
+	cts.Equal("s3cr3t-default", conf.Get("APP_API_KEY"), "Get should return the real value")
+
+	cts.NotContains(conf.DumpTable(), "s3cr3t-default", "Sensitive value should be masked in the table")
+	cts.Contains(conf.DumpTable(), "*****")
+
+	cts.NoError(conf.CreateSampleFile(sampleFile), "The sample file should have been created")
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err, "The sample file should be readable")
+	cts.Contains(string(content), "APP_API_KEY=\n", "Sensitive value should be left empty in the sample file")
+
+	err = conf.Set("APP_API_KEY", "short")
+	cts.EqualError(err, "Invalid value for APP_API_KEY = *****: Length: the length must be between 10 and 20.")
+	conf.Override(map[string]string{"APP_API_KEY": "short"})
+	cts.Contains(conf.Validate().Error(), "APP_API_KEY = *****")
+	cts.NotContains(conf.Validate().Error(), "short")
+}
+
 func (cts *ConfigTestSuite) TestWrongEnvfile() {
 	conf := NewConfig(cts.getDefaultConfigs())
 
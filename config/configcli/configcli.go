@@ -0,0 +1,205 @@
+// Package configcli provides an embeddable command-line interface for the config schema of an application.
+// It generates the sample envfile and the markdown documentation, and validates envfiles against the
+// registered Variables, so CI can lint the environment files.
+//
+//	func main() {
+//	    conf := config.NewConfig(variables)
+//	    if len(os.Args) > 1 && os.Args[1] == "config" {
+//	        os.Exit(configcli.Main(conf, os.Args[2:]))
+//	    }
+//	    ...
+//	}
+package configcli
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+// DefaultSampleFile is the sample file created by the sample command if none supplied.
+const DefaultSampleFile = ".env.sample"
+
+// usage is the help text of the commands.
+const usage = `Usage: <command> [arguments]
+
+Commands:
+  sample [file]          create the sample envfile (default .env.sample)
+  docs [-o file]         write the markdown documentation (default stdout)
+  dump [-format format]  print the variables as table, markdown, json or csv
+  validate <file>...     validate envfile, JSON or YAML files against the schema
+  lint <file>...         report the unknown, missing, invalid and deprecated variables of the files
+  diff <file> <file>     compare two files, the sensitive values are masked
+`
+
+// Main runs the command in args with the standard outputs and returns the exit code of the process.
+func Main(conf *config.AppConfig, args []string) int {
+	if err := Run(conf, args, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "%s\n", err)
+		return 1
+	}
+	return 0
+}
+
+// Run runs the command in args and writes its output into out.
+func Run(conf *config.AppConfig, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New(usage)
+	}
+	command, args := args[0], args[1:]
+	switch command {
+	case "sample":
+		return runSample(conf, args, out)
+	case "docs":
+		return runDocs(conf, args, out)
+	case "dump":
+		return runDump(conf, args, out)
+	case "validate":
+		return runValidate(conf, args, out)
+	case "lint":
+		return runLint(conf, args, out)
+	case "diff":
+		return runDiff(conf, args, out)
+	case "help", "-h", "--help":
+		_, err := io.WriteString(out, usage)
+		return err
+	}
+	return errors.Errorf("Unknown command %s\n%s", command, usage)
+}
+
+// runSample creates the sample envfile.
+func runSample(conf *config.AppConfig, args []string, out io.Writer) error {
+	filename := DefaultSampleFile
+	if len(args) > 0 {
+		filename = args[0]
+	}
+	if err := conf.CreateSampleFile(filename); err != nil {
+		return err
+	}
+	_, err := fmt.Fprintf(out, "Created %s\n", filename)
+	return err
+}
+
+// runDocs writes the markdown documentation.
+func runDocs(conf *config.AppConfig, args []string, out io.Writer) error {
+	fs := newFlagSet("docs", out)
+	output := fs.String("o", "", "the output file, stdout if empty")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *output == "" {
+		return conf.CreateMarkdownDoc(out)
+	}
+	file, err := os.Create(*output)
+	if err != nil {
+		return errors.Wrapf(err, "Failed to create %s file", *output)
+	}
+	if err := conf.CreateMarkdownDoc(file); err != nil {
+		file.Close()
+		return err
+	}
+	return errors.Wrapf(file.Close(), "Failed to write %s file", *output)
+}
+
+// runDump prints the variables in the requested format.
+func runDump(conf *config.AppConfig, args []string, out io.Writer) error {
+	fs := newFlagSet("dump", out)
+	format := fs.String("format", string(config.DumpFormatTable), "table, markdown, json or csv")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	dump, err := conf.Dump(config.DumpFormat(*format))
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, dump)
+	return err
+}
+
+// runValidate validates every file, and fails if any of them is invalid.
+func runValidate(conf *config.AppConfig, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("Missing file to validate")
+	}
+	invalid := []string{}
+	for _, filename := range args {
+		if err := conf.ValidateFile(filename); err != nil {
+			invalid = append(invalid, filename)
+			fmt.Fprintf(out, "%s: INVALID\n  %s\n", filename, err)
+			continue
+		}
+		fmt.Fprintf(out, "%s: OK\n", filename)
+	}
+	if len(invalid) > 0 {
+		return errors.Errorf("Invalid configuration file(s): %s", strings.Join(invalid, ", "))
+	}
+	return nil
+}
+
+// runLint reports the problems of every file, and fails if any of them is not OK.
+func runLint(conf *config.AppConfig, args []string, out io.Writer) error {
+	if len(args) == 0 {
+		return errors.New("Missing file to lint")
+	}
+	failed := []string{}
+	for _, filename := range args {
+		report := conf.Lint(filename)
+		status := "OK"
+		if !report.OK() {
+			status = "FAILED"
+			failed = append(failed, filename)
+		}
+		fmt.Fprintf(out, "%s: %s\n", filename, status)
+		if report.Err != nil {
+			fmt.Fprintf(out, "  %s\n", report.Err)
+		}
+		for _, name := range report.Unknown {
+			fmt.Fprintf(out, "  unknown: %s\n", name)
+		}
+		for _, name := range report.Missing {
+			fmt.Fprintf(out, "  missing: %s\n", name)
+		}
+		for _, issue := range report.Invalid {
+			fmt.Fprintf(out, "  invalid: %s = %s: %s: %s\n", issue.Variable, issue.Value, issue.Rule, issue.Message)
+		}
+		deprecated := make([]string, 0, len(report.Deprecated))
+		for name := range report.Deprecated {
+			deprecated = append(deprecated, name)
+		}
+		// Sort is needed because maps always return values in random order
+		sort.Strings(deprecated)
+		for _, name := range deprecated {
+			fmt.Fprintf(out, "  deprecated: %s: %s\n", name, report.Deprecated[name])
+		}
+	}
+	if len(failed) > 0 {
+		return errors.Errorf("Failed to lint configuration file(s): %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// runDiff prints the differences of two files.
+func runDiff(conf *config.AppConfig, args []string, out io.Writer) error {
+	if len(args) != 2 {
+		return errors.New("Diff needs two files to compare")
+	}
+	diff, err := conf.Diff(args[0], args[1])
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(out, diff.String())
+	return err
+}
+
+// newFlagSet creates a FlagSet of a command, which returns the parse errors instead of exiting.
+func newFlagSet(command string, out io.Writer) *flag.FlagSet {
+	fs := flag.NewFlagSet(command, flag.ContinueOnError)
+	fs.SetOutput(out)
+	return fs
+}
@@ -0,0 +1,132 @@
+package configcli
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+// ConfigCLISuite extends testify's Suite.
+type ConfigCLISuite struct {
+	suite.Suite
+	conf *config.AppConfig
+	dir  string
+}
+
+func (cs *ConfigCLISuite) SetupTest() {
+	cs.dir = cs.T().TempDir()
+	cs.conf = config.NewConfig(map[string]*config.Variable{
+		"APP_PORT": {
+			DefaultValue: "8080",
+			Description:  "TCP/IP Port where the application listens",
+			Rules: map[string]validation.Rule{
+				"Valid port": is.Port,
+			},
+		},
+	})
+}
+
+func (cs *ConfigCLISuite) run(args ...string) (string, error) {
+	out := &strings.Builder{}
+	err := Run(cs.conf, args, out)
+	return out.String(), err
+}
+
+func (cs *ConfigCLISuite) TestSample() {
+	filename := filepath.Join(cs.dir, ".env.sample")
+	out, err := cs.run("sample", filename)
+	cs.NoError(err)
+	cs.Equal("Created "+filename+"\n", out)
+	content, err := ioutil.ReadFile(filename)
+	cs.NoError(err, "The sample file should have been created")
+	cs.Contains(string(content), "APP_PORT=8080")
+}
+
+func (cs *ConfigCLISuite) TestDocs() {
+	out, err := cs.run("docs")
+	cs.NoError(err)
+	cs.Contains(out, "| `APP_PORT` |  | TCP/IP Port where the application listens | Valid port | `8080` |  |")
+
+	filename := filepath.Join(cs.dir, "CONFIG.md")
+	_, err = cs.run("docs", "-o", filename)
+	cs.NoError(err)
+	content, err := ioutil.ReadFile(filename)
+	cs.NoError(err, "The documentation should have been written")
+	cs.Equal(out, string(content))
+}
+
+func (cs *ConfigCLISuite) TestDump() {
+	out, err := cs.run("dump", "-format", "csv")
+	cs.NoError(err)
+	cs.Equal("Variable Name,Description,Constraints,Default Value\nAPP_PORT,TCP/IP Port where the application listens,Valid port,8080\n", out)
+
+	_, err = cs.run("dump", "-format", "xml")
+	cs.EqualError(err, "Unknown dump format xml")
+}
+
+func (cs *ConfigCLISuite) TestValidate() {
+	valid := filepath.Join(cs.dir, "valid.env")
+	invalid := filepath.Join(cs.dir, "invalid.env")
+	cs.NoError(ioutil.WriteFile(valid, []byte("APP_PORT=9090\n"), 0600))
+	cs.NoError(ioutil.WriteFile(invalid, []byte("APP_PORT=http\n"), 0600))
+
+	out, err := cs.run("validate", valid)
+	cs.NoError(err)
+	cs.Equal(valid+": OK\n", out)
+
+	out, err = cs.run("validate", valid, invalid)
+	cs.EqualError(err, "Invalid configuration file(s): "+invalid)
+	cs.Contains(out, invalid+": INVALID\n  APP_PORT = http: (Valid port: must be a valid port number.).")
+
+	_, err = cs.run("validate")
+	cs.EqualError(err, "Missing file to validate")
+}
+
+func (cs *ConfigCLISuite) TestLint() {
+	valid := filepath.Join(cs.dir, "valid.env")
+	invalid := filepath.Join(cs.dir, "invalid.env")
+	cs.NoError(ioutil.WriteFile(valid, []byte("APP_PORT=9090\n"), 0600))
+	cs.NoError(ioutil.WriteFile(invalid, []byte("APP_PORT=http\nAPP_PROT=80\n"), 0600))
+
+	out, err := cs.run("lint", valid, invalid)
+	cs.EqualError(err, "Failed to lint configuration file(s): "+invalid)
+	cs.Equal(valid+": OK\n"+invalid+": FAILED\n  unknown: APP_PROT\n  invalid: APP_PORT = http: Valid port: must be a valid port number\n", out)
+
+	_, err = cs.run("lint")
+	cs.EqualError(err, "Missing file to lint")
+}
+
+func (cs *ConfigCLISuite) TestDiff() {
+	staging := filepath.Join(cs.dir, "staging.env")
+	production := filepath.Join(cs.dir, "production.env")
+	cs.NoError(ioutil.WriteFile(staging, []byte("APP_PORT=8080\nAPP_DEBUG=1\n"), 0600))
+	cs.NoError(ioutil.WriteFile(production, []byte("APP_PORT=80\nAPP_ENV=production\n"), 0600))
+
+	out, err := cs.run("diff", staging, production)
+	cs.NoError(err)
+	cs.Equal("- APP_DEBUG=1\n+ APP_ENV=production\n~ APP_PORT: 8080 -> 80\n", out)
+
+	_, err = cs.run("diff", staging)
+	cs.EqualError(err, "Diff needs two files to compare")
+}
+
+func (cs *ConfigCLISuite) TestUsage() {
+	_, err := cs.run()
+	cs.Error(err)
+	_, err = cs.run("unknown")
+	cs.Contains(err.Error(), "Unknown command unknown")
+	out, err := cs.run("help")
+	cs.NoError(err)
+	cs.Contains(out, "validate <file>...")
+}
+
+// TestConfigCLI runs the whole test suite
+func TestConfigCLI(t *testing.T) {
+	suite.Run(t, new(ConfigCLISuite))
+}
@@ -0,0 +1,86 @@
+// Package configprom exports the health of the configuration of an AppConfig as Prometheus metrics,
+// so the dashboards can alert when the hot reloads start failing:
+//   - app_config_valid: 1 if the last Setup or Reload passed the validation, 0 after a failed Reload
+//   - app_config_last_reload_timestamp: the Unix time of the last successful Reload (or the registration)
+//   - app_config_reload_failures_total: the number of the failed Reloads, the errors of the watchers and the
+//     dropped Changes of the subscriptions are not counted
+//
+// Register the metrics after the Setup:
+//
+//	if err := configprom.Register(conf, prometheus.DefaultRegisterer); err != nil {
+//	    ...
+//	}
+package configprom
+
+import (
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+// Metrics are the Prometheus metrics of an AppConfig, updated by its Reloads.
+type Metrics struct {
+	// Valid is the app_config_valid gauge.
+	Valid prometheus.Gauge
+
+	// LastReload is the app_config_last_reload_timestamp gauge.
+	LastReload prometheus.Gauge
+
+	// ReloadFailures is the app_config_reload_failures_total counter.
+	ReloadFailures prometheus.Counter
+}
+
+// New creates the Metrics of conf and hooks them to its Reloads with the OnReload and OnReloadError callbacks.
+// The validity is initialized by validating the current values.
+func New(conf *config.AppConfig) *Metrics {
+	metrics := &Metrics{
+		Valid: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "app_config_valid",
+			Help: "Whether the last Setup or Reload of the configuration passed the validation (1) or not (0).",
+		}),
+		LastReload: prometheus.NewGauge(prometheus.GaugeOpts{
+			Name: "app_config_last_reload_timestamp",
+			Help: "Unix time of the last successful configuration reload.",
+		}),
+		ReloadFailures: prometheus.NewCounter(prometheus.CounterOpts{
+			Name: "app_config_reload_failures_total",
+			Help: "Number of the failed configuration reloads.",
+		}),
+	}
+	if conf.Validate() == nil {
+		metrics.Valid.Set(1)
+	}
+	metrics.LastReload.Set(float64(time.Now().Unix()))
+
+	conf.OnReload(func() {
+		metrics.Valid.Set(1)
+		metrics.LastReload.Set(float64(time.Now().Unix()))
+	})
+	conf.OnReloadError(func(err error) {
+		metrics.Valid.Set(0)
+		metrics.ReloadFailures.Inc()
+	})
+	return metrics
+}
+
+// Collectors returns the collectors of the Metrics, to be registered on a prometheus.Registerer.
+func (metrics *Metrics) Collectors() []prometheus.Collector {
+	return []prometheus.Collector{metrics.Valid, metrics.LastReload, metrics.ReloadFailures}
+}
+
+// Register creates the Metrics of conf and registers them on registerer, see New. On failure the already
+// registered collectors are unregistered.
+func Register(conf *config.AppConfig, registerer prometheus.Registerer) error {
+	collectors := New(conf).Collectors()
+	for i, collector := range collectors {
+		if err := registerer.Register(collector); err != nil {
+			for _, registered := range collectors[:i] {
+				registerer.Unregister(registered)
+			}
+			return errors.Wrap(err, "Failed to register configuration metrics")
+		}
+	}
+	return nil
+}
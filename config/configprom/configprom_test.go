@@ -0,0 +1,80 @@
+package configprom
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+// ConfigPromSuite extends testify's Suite.
+type ConfigPromSuite struct {
+	suite.Suite
+}
+
+func (cs *ConfigPromSuite) TestMetrics() {
+	envfile := filepath.Join(cs.T().TempDir(), ".env")
+	cs.NoError(ioutil.WriteFile(envfile, []byte("APP_TEST_PROM_PORT=8080\n"), 0600))
+	conf := config.NewConfig(map[string]*config.Variable{
+		"APP_TEST_PROM_PORT": {Rules: map[string]validation.Rule{"Valid port": is.Port}},
+	})
+	cs.NoError(conf.Setup(envfile))
+
+	registry := prometheus.NewRegistry()
+	cs.NoError(Register(conf, registry))
+	cs.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP app_config_reload_failures_total Number of the failed configuration reloads.
+# TYPE app_config_reload_failures_total counter
+app_config_reload_failures_total 0
+# HELP app_config_valid Whether the last Setup or Reload of the configuration passed the validation (1) or not (0).
+# TYPE app_config_valid gauge
+app_config_valid 1
+`), "app_config_valid", "app_config_reload_failures_total"))
+
+	cs.NoError(ioutil.WriteFile(envfile, []byte("APP_TEST_PROM_PORT=http\n"), 0600))
+	cs.Error(conf.Reload())
+	cs.Error(conf.Reload())
+	cs.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP app_config_reload_failures_total Number of the failed configuration reloads.
+# TYPE app_config_reload_failures_total counter
+app_config_reload_failures_total 2
+# HELP app_config_valid Whether the last Setup or Reload of the configuration passed the validation (1) or not (0).
+# TYPE app_config_valid gauge
+app_config_valid 0
+`), "app_config_valid", "app_config_reload_failures_total"))
+
+	metrics, err := registry.Gather()
+	cs.NoError(err)
+	cs.Len(metrics, 3)
+	cs.Equal("app_config_last_reload_timestamp", metrics[0].GetName())
+	cs.Greater(metrics[0].GetMetric()[0].GetGauge().GetValue(), float64(0), "The last reload time should be set")
+
+	cs.NoError(ioutil.WriteFile(envfile, []byte("APP_TEST_PROM_PORT=9090\n"), 0600))
+	cs.NoError(conf.Reload())
+	cs.NoError(testutil.GatherAndCompare(registry, strings.NewReader(`
+# HELP app_config_valid Whether the last Setup or Reload of the configuration passed the validation (1) or not (0).
+# TYPE app_config_valid gauge
+app_config_valid 1
+`), "app_config_valid"))
+
+	cs.Error(Register(conf, registry), "The metrics should be registered once")
+
+	registry = prometheus.NewRegistry()
+	cs.NoError(registry.Register(prometheus.NewCounter(prometheus.CounterOpts{Name: "app_config_reload_failures_total"})))
+	cs.Error(Register(conf, registry), "The conflicting metric should fail the registration")
+	metrics, err = registry.Gather()
+	cs.NoError(err)
+	cs.Len(metrics, 1, "The registered metrics should be unregistered on failure")
+}
+
+// TestConfigProm runs the whole test suite
+func TestConfigProm(t *testing.T) {
+	suite.Run(t, new(ConfigPromSuite))
+}
@@ -0,0 +1,123 @@
+// Package configtest provides helpers for testing the services configured by an AppConfig: building a
+// validated AppConfig from plain values, setting and unsetting environment variables with automatic
+// cleanup, and asserting on the validation errors.
+package configtest
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+// valuesSource is a config.Source of fixed values.
+type valuesSource map[string]string
+
+// Name implements the config.Source interface.
+func (s valuesSource) Name() string {
+	return "configtest values"
+}
+
+// Load implements the config.Source interface.
+func (s valuesSource) Load(ctx context.Context) (map[string]string, error) {
+	return s, nil
+}
+
+// Load creates an AppConfig of copies of vars and sets it up with values, like Setup would load them
+// from the environment. The environment variables of vars are unset for the duration of the test, so
+// the values of the host don't leak into the test. It returns the error of the Setup.
+func Load(t testing.TB, vars map[string]*config.Variable, values map[string]string) (*config.AppConfig, error) {
+	t.Helper()
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		names = append(names, name)
+	}
+	UnsetEnv(t, names...)
+
+	// The Clone copies the Variables, so the tests sharing vars don't affect each other
+	conf := config.NewConfig(vars).Clone()
+	err := conf.SetupWithOptions(config.WithSources(valuesSource(values)))
+	return conf, err
+}
+
+// New is like Load, but fails the test immediately if the AppConfig is invalid.
+func New(t testing.TB, vars map[string]*config.Variable, values map[string]string) *config.AppConfig {
+	t.Helper()
+	conf, err := Load(t, vars, values)
+	if err != nil {
+		t.Fatalf("Invalid configuration: %v", err)
+	}
+	return conf
+}
+
+// SetEnv sets the environment variables for the duration of the test.
+func SetEnv(t testing.TB, values map[string]string) {
+	t.Helper()
+	for name, value := range values {
+		setEnv(t, name, value, true)
+	}
+}
+
+// UnsetEnv unsets the environment variables for the duration of the test.
+func UnsetEnv(t testing.TB, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		setEnv(t, name, "", false)
+	}
+}
+
+// setEnv sets or unsets the environment variable, and restores its previous state after the test.
+func setEnv(t testing.TB, name, value string, set bool) {
+	t.Helper()
+	previous, existed := os.LookupEnv(name)
+	var err error
+	if set {
+		err = os.Setenv(name, value)
+	} else {
+		err = os.Unsetenv(name)
+	}
+	if err != nil {
+		t.Fatalf("Failed to set environment variable %s: %v", name, err)
+	}
+	t.Cleanup(func() {
+		if existed {
+			_ = os.Setenv(name, previous)
+		} else {
+			_ = os.Unsetenv(name)
+		}
+	})
+}
+
+// AssertValidationErrors asserts that err holds validation errors for exactly the named Variables or
+// Constraints, e.g. AssertValidationErrors(t, conf.Validate(), "APP_PORT"). The Variables are matched by
+// name, regardless of their values in the error keys. It returns whether the assertion succeeded.
+func AssertValidationErrors(t testing.TB, err error, names ...string) bool {
+	t.Helper()
+	errs, ok := errors.Cause(err).(validation.Errors)
+	if !ok {
+		t.Errorf("Expected validation errors for %v, got: %v", names, err)
+		return false
+	}
+
+	failed := map[string]bool{}
+	for key := range errs {
+		failed[strings.SplitN(key, " = ", 2)[0]] = true
+	}
+	success := true
+	for _, name := range names {
+		if !failed[name] {
+			t.Errorf("Expected validation error for %s, got: %v", name, err)
+			success = false
+		}
+		delete(failed, name)
+	}
+	for name := range failed {
+		t.Errorf("Unexpected validation error for %s: %v", name, errs)
+		success = false
+	}
+	return success
+}
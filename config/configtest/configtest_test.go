@@ -0,0 +1,77 @@
+package configtest
+
+import (
+	"os"
+	"testing"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+// ConfigTestSuite extends testify's Suite.
+type ConfigTestSuite struct {
+	suite.Suite
+	vars map[string]*config.Variable
+}
+
+func (cs *ConfigTestSuite) SetupTest() {
+	cs.vars = map[string]*config.Variable{
+		"APP_PORT": {
+			DefaultValue: "8080",
+			Rules: map[string]validation.Rule{
+				"Valid port": is.Port,
+			},
+		},
+		"APP_HOST": {
+			Rules: map[string]validation.Rule{
+				"Required": validation.Required,
+			},
+		},
+	}
+}
+
+func (cs *ConfigTestSuite) TestNew() {
+	cs.T().Setenv("APP_PORT", "7070")
+	conf := New(cs.T(), cs.vars, map[string]string{"APP_HOST": "localhost"})
+	cs.Equal("localhost", conf.Get("APP_HOST"))
+	cs.Equal("8080", conf.Get("APP_PORT"), "The environment should not leak into the AppConfig")
+	cs.Empty(cs.vars["APP_HOST"].Value, "The Variables should be copied")
+}
+
+func (cs *ConfigTestSuite) TestLoad() {
+	_, err := Load(cs.T(), cs.vars, map[string]string{"APP_PORT": "not-a-port"})
+	cs.Error(err)
+	cs.True(AssertValidationErrors(cs.T(), err, "APP_PORT", "APP_HOST"))
+
+	mock := &testing.T{}
+	cs.False(AssertValidationErrors(mock, err, "APP_PORT"), "Missing names should fail the assertion")
+	cs.False(AssertValidationErrors(mock, err, "APP_PORT", "APP_HOST", "APP_ENV"), "Unexpected names should fail the assertion")
+	cs.False(AssertValidationErrors(mock, nil, "APP_PORT"), "Nil error should fail the assertion")
+}
+
+func (cs *ConfigTestSuite) TestEnv() {
+	cs.NoError(os.Setenv("CONFIGTEST_EXISTING", "before"))
+	defer os.Unsetenv("CONFIGTEST_EXISTING")
+
+	cs.Run("set", func() {
+		SetEnv(cs.T(), map[string]string{"CONFIGTEST_EXISTING": "during", "CONFIGTEST_NEW": "new"})
+		cs.Equal("during", os.Getenv("CONFIGTEST_EXISTING"))
+		cs.Equal("new", os.Getenv("CONFIGTEST_NEW"))
+	})
+	cs.Equal("before", os.Getenv("CONFIGTEST_EXISTING"), "The previous value should be restored")
+	_, ok := os.LookupEnv("CONFIGTEST_NEW")
+	cs.False(ok, "The new variable should be unset")
+
+	cs.Run("unset", func() {
+		UnsetEnv(cs.T(), "CONFIGTEST_EXISTING")
+		_, ok := os.LookupEnv("CONFIGTEST_EXISTING")
+		cs.False(ok)
+	})
+	cs.Equal("before", os.Getenv("CONFIGTEST_EXISTING"), "The previous value should be restored")
+}
+
+func TestConfigTest(t *testing.T) {
+	suite.Run(t, new(ConfigTestSuite))
+}
@@ -0,0 +1,190 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// Constraint is a validation rule spanning several Variables, which cannot be expressed by the
+// Rules of a single Variable.
+type Constraint struct {
+	// Name identifies the Constraint in the validation errors.
+	Name string
+
+	// Validate checks the values of the Variables by name, and returns an error if they are invalid together.
+	Validate func(values map[string]string) error
+
+	// validator is the validator of AddValidator, called instead of Validate.
+	validator func(c *AppConfig) error
+}
+
+// AddConstraints adds Constraints to the AppConfig, they are validated together with the Rules of the
+// Variables (during Setup, Reload and Validate).
+func (appConf *AppConfig) AddConstraints(constraints ...Constraint) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.constraints = append(appConf.constraints, constraints...)
+}
+
+// AddValidator adds a validator of invariants spanning several Variables (e.g. the TLS cert and key must
+// both be set or both be empty) as a Constraint, it is invoked with the AppConfig holding the values to
+// validate (during Setup, Reload and Validate). A returned validation.Errors is merged into the validation
+// errors, other errors are added as "Validator N", where N is the position of the validator.
+// The validator must read the values from c, not from the AppConfig it was added to.
+func (appConf *AppConfig) AddValidator(validator func(c *AppConfig) error) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.validators++
+	appConf.constraints = append(appConf.constraints, Constraint{
+		Name:      fmt.Sprintf("Validator %d", appConf.validators),
+		validator: validator,
+	})
+}
+
+// validateConstraints applies the Constraints on values and collects the errors into allErrors.
+// The Constraints are called without the lock, so the caller must not hold it.
+func (appConf *AppConfig) validateConstraints(values map[string]string, allErrors map[string]error) {
+	appConf.mu.RLock()
+	constraints := append([]Constraint{}, appConf.constraints...)
+	var view *AppConfig
+	if appConf.validators > 0 {
+		view = appConf.view(values)
+	}
+	appConf.mu.RUnlock()
+	applyConstraints(constraints, view, values, allErrors)
+}
+
+// applyConstraints applies the constraints on values and collects the errors into allErrors, the validators are
+// invoked with view.
+func applyConstraints(constraints []Constraint, view *AppConfig, values map[string]string, allErrors map[string]error) {
+	for _, constraint := range constraints {
+		if constraint.validator == nil {
+			if err := constraint.Validate(values); err != nil {
+				allErrors[constraint.Name] = err
+			}
+			continue
+		}
+		err := constraint.validator(view)
+		if errs, ok := err.(validation.Errors); ok {
+			for key, err := range errs {
+				allErrors[key] = err
+			}
+		} else if err != nil {
+			allErrors[constraint.Name] = err
+		}
+	}
+}
+
+// view returns a new AppConfig with copies of the Variables holding values, so the validators can
+// inspect the values of a Reload before they are applied. The caller must hold the lock.
+func (appConf *AppConfig) view(values map[string]string) *AppConfig {
+	vars := make(map[string]*Variable, len(appConf.vars))
+	for name, confVar := range appConf.vars {
+		copied := *confVar
+		copied.Value = values[name]
+		vars[name] = &copied
+	}
+	return NewConfig(vars)
+}
+
+// LoggerConstraints are the Constraints of the logger related Variables, which would be silently
+// ignored or misbehave at runtime. The log format is APP_LOG_FORMAT if set, text if APP_LOG_DEV is enabled,
+// JSON otherwise, the same as the format of the Common Logger:
+//   - APP_LOG_DEV and the text and pretty formats are not allowed in production
+//   - APP_LOG_FORMAT_ERRORS requires a JSON format (not text, logfmt or pretty)
+//   - APP_LOG_FILE must be writable
+var LoggerConstraints = []Constraint{
+	{
+		Name: "Log dev mode outside production",
+		Validate: func(values map[string]string) error {
+			if values[constants.APP_ENV] != constants.ENV_PRODUCTION {
+				return nil
+			}
+			if isTruthy(values[constants.APP_LOG_DEV]) {
+				return errors.Errorf("%s must not be enabled in %s", constants.APP_LOG_DEV, constants.ENV_PRODUCTION)
+			}
+			if format := logFormat(values); format == constants.LOG_FORMAT_TEXT || format == constants.LOG_FORMAT_PRETTY {
+				return errors.Errorf("%s %s must not be used in %s", constants.APP_LOG_FORMAT, format, constants.ENV_PRODUCTION)
+			}
+			return nil
+		},
+	},
+	{
+		Name: "Error formatting with JSON formatter",
+		Validate: func(values map[string]string) error {
+			if !isTruthy(values[constants.APP_LOG_FORMAT_ERRORS]) {
+				return nil
+			}
+			switch format := logFormat(values); format {
+			case constants.LOG_FORMAT_TEXT, constants.LOG_FORMAT_LOGFMT, constants.LOG_FORMAT_PRETTY:
+				return errors.Errorf("%s requires a JSON format, the log format is %s", constants.APP_LOG_FORMAT_ERRORS, format)
+			}
+			return nil
+		},
+	},
+	{
+		Name: "Writable log file",
+		Validate: func(values map[string]string) error {
+			filename := values[constants.APP_LOG_FILE]
+			if filename == "" {
+				return nil
+			}
+			if err := checkWritable(filename); err != nil {
+				return errors.Errorf("%s is not writable: %s", constants.APP_LOG_FILE, err)
+			}
+			return nil
+		},
+	},
+}
+
+// logFormat returns the format of the Common Logger: APP_LOG_FORMAT if set, text if APP_LOG_DEV is enabled, JSON
+// otherwise.
+func logFormat(values map[string]string) string {
+	if format := values[constants.APP_LOG_FORMAT]; format != "" {
+		return format
+	}
+	if isTruthy(values[constants.APP_LOG_DEV]) {
+		return constants.LOG_FORMAT_TEXT
+	}
+	return constants.LOG_FORMAT_JSON
+}
+
+// checkWritable returns an error if the file cannot be opened for appending, or if it does not exist, if it
+// cannot be created in its directory. The file is not created.
+func checkWritable(filename string) error {
+	file, err := os.OpenFile(filename, os.O_APPEND|os.O_WRONLY, 0)
+	if err == nil {
+		return file.Close()
+	}
+	if !os.IsNotExist(err) {
+		return err
+	}
+	dir := filepath.Dir(filename)
+	info, err := os.Stat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return errors.Errorf("%s is not a directory", dir)
+	}
+	// Creating a file is the only portable way to check the permissions
+	probe, err := ioutil.TempFile(dir, ".writable-*")
+	if err != nil {
+		return err
+	}
+	probe.Close()
+	return os.Remove(probe.Name())
+}
+
+// isTruthy returns true if value is a true boolean.
+func isTruthy(value string) bool {
+	truthy, _ := strconv.ParseBool(value)
+	return truthy
+}
@@ -0,0 +1,150 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestConstraints() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_TLS_CERT": {DefaultValue: "cert.pem"},
+		"APP_TLS_KEY":  {},
+	})
+	conf.AddConstraints(Constraint{
+		Name: "TLS pair",
+		Validate: func(values map[string]string) error {
+			if (values["APP_TLS_CERT"] == "") != (values["APP_TLS_KEY"] == "") {
+				return errors.New("cert and key must be set together")
+			}
+			return nil
+		},
+	})
+	cts.EqualError(errors.Cause(conf.Setup()), "TLS pair: cert and key must be set together.")
+
+	conf.Override(map[string]string{"APP_TLS_KEY": "key.pem"})
+	cts.NoError(conf.Validate())
+}
+
+func (cts *ConfigTestSuite) TestLoggerConstraints() {
+	dir := cts.T().TempDir()
+	testCases := map[string]struct {
+		values map[string]string
+		err    string
+	}{
+		"valid": {
+			values: map[string]string{
+				constants.APP_ENV:      constants.ENV_PRODUCTION,
+				constants.APP_LOG_FILE: filepath.Join(dir, "app.log"),
+			},
+		},
+		"dev log in production": {
+			values: map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION, constants.APP_LOG_DEV: "true"},
+			err:    "Log dev mode outside production: APP_LOG_DEV must not be enabled in production.",
+		},
+		"JSON formats in production": {
+			values: map[string]string{
+				constants.APP_ENV:               constants.ENV_PRODUCTION,
+				constants.APP_LOG_FORMAT:        constants.LOG_FORMAT_GCP,
+				constants.APP_LOG_FORMAT_ERRORS: "true",
+			},
+		},
+		"text format in production": {
+			values: map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION, constants.APP_LOG_FORMAT: constants.LOG_FORMAT_TEXT},
+			err:    "Log dev mode outside production: APP_LOG_FORMAT text must not be used in production.",
+		},
+		"pretty format in production": {
+			values: map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION, constants.APP_LOG_FORMAT: constants.LOG_FORMAT_PRETTY},
+			err:    "Log dev mode outside production: APP_LOG_FORMAT pretty must not be used in production.",
+		},
+		"logfmt format in production": {
+			values: map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION, constants.APP_LOG_FORMAT: constants.LOG_FORMAT_LOGFMT},
+		},
+		"format errors with dev mode": {
+			values: map[string]string{constants.APP_LOG_DEV: "1", constants.APP_LOG_FORMAT_ERRORS: "1"},
+			err:    "Error formatting with JSON formatter: APP_LOG_FORMAT_ERRORS requires a JSON format, the log format is text.",
+		},
+		"format errors with dev mode and JSON format": {
+			values: map[string]string{
+				constants.APP_LOG_DEV:           "1",
+				constants.APP_LOG_FORMAT:        constants.LOG_FORMAT_JSON,
+				constants.APP_LOG_FORMAT_ERRORS: "1",
+			},
+		},
+		"format errors with text format": {
+			values: map[string]string{constants.APP_LOG_FORMAT: constants.LOG_FORMAT_TEXT, constants.APP_LOG_FORMAT_ERRORS: "1"},
+			err:    "Error formatting with JSON formatter: APP_LOG_FORMAT_ERRORS requires a JSON format, the log format is text.",
+		},
+		"format errors with logfmt format": {
+			values: map[string]string{constants.APP_LOG_FORMAT: constants.LOG_FORMAT_LOGFMT, constants.APP_LOG_FORMAT_ERRORS: "1"},
+			err:    "Error formatting with JSON formatter: APP_LOG_FORMAT_ERRORS requires a JSON format, the log format is logfmt.",
+		},
+		"format errors with pretty format": {
+			values: map[string]string{constants.APP_LOG_FORMAT: constants.LOG_FORMAT_PRETTY, constants.APP_LOG_FORMAT_ERRORS: "1"},
+			err:    "Error formatting with JSON formatter: APP_LOG_FORMAT_ERRORS requires a JSON format, the log format is pretty.",
+		},
+		"format errors with gelf format": {
+			values: map[string]string{constants.APP_LOG_FORMAT: constants.LOG_FORMAT_GELF, constants.APP_LOG_FORMAT_ERRORS: "1"},
+		},
+		"unwritable log file": {
+			values: map[string]string{constants.APP_LOG_FILE: filepath.Join(dir, "missing", "app.log")},
+			err:    "Writable log file: APP_LOG_FILE is not writable: stat " + filepath.Join(dir, "missing") + ": no such file or directory.",
+		},
+	}
+
+	for name, tc := range testCases {
+		conf := NewConfig(nil)
+		conf.AddConstraints(LoggerConstraints...)
+		conf.Override(tc.values)
+		if tc.err == "" {
+			cts.NoError(conf.Validate(), name)
+			_, err := os.Stat(tc.values[constants.APP_LOG_FILE])
+			cts.True(os.IsNotExist(err), "Log file should not be created by the validation")
+		} else {
+			cts.EqualError(conf.Validate(), tc.err, name)
+		}
+	}
+}
+
+func (cts *ConfigTestSuite) TestAddValidator() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_TLS_CERT": {DefaultValue: "cert.pem"},
+		"APP_TLS_KEY":  {},
+		"APP_MIN":      {DefaultValue: "5"},
+		"APP_MAX":      {DefaultValue: "1"},
+	})
+	conf.AddValidator(func(c *AppConfig) error {
+		if (c.Get("APP_TLS_CERT") == "") != (c.Get("APP_TLS_KEY") == "") {
+			return errors.New("TLS cert and key must be set together")
+		}
+		return nil
+	})
+	conf.AddValidator(func(c *AppConfig) error {
+		if c.Get("APP_MIN") > c.Get("APP_MAX") {
+			return validation.Errors{"APP_MIN > APP_MAX": errors.New("minimum must not exceed maximum")}
+		}
+		return nil
+	})
+
+	errs := conf.ValidationErrors()
+	cts.Len(errs, 0, "Validators should see the values before Setup")
+	cts.Error(conf.Setup())
+	errs = conf.ValidationErrors()
+	cts.Len(errs, 2)
+	cts.EqualError(errs["Validator 1"], "TLS cert and key must be set together")
+	cts.EqualError(errs["APP_MIN > APP_MAX"], "minimum must not exceed maximum")
+
+	conf.Override(map[string]string{"APP_TLS_KEY": "key.pem", "APP_MAX": "9"})
+	cts.NoError(conf.Validate())
+
+	conf.AddValidator(func(c *AppConfig) error {
+		// Reading the AppConfig the validator was added to should not deadlock
+		_ = conf.Get("APP_MIN")
+		return nil
+	})
+	cts.NoError(conf.Validate())
+	cts.Len(conf.ValidationReport(), 0)
+}
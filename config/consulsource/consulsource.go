@@ -0,0 +1,214 @@
+// Package consulsource provides a config.WatchableSource which loads the variables from the Consul KV store.
+// Use the New or NewFromEnv constructors to create the Source and pass it to config.WithSources
+// Use config.AppConfig.WatchSources to reload the configuration when any key changes, the changes are
+// detected by blocking queries
+package consulsource
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config/internal/poll"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+const (
+	// AddressEnv is the environment variable of the Consul agent's address, the same as the Consul CLI uses.
+	AddressEnv = "CONSUL_HTTP_ADDR"
+
+	// TokenEnv is the environment variable of the ACL token, the same as the Consul CLI uses.
+	TokenEnv = "CONSUL_HTTP_TOKEN"
+
+	// DefaultAddress is the address of the local Consul agent.
+	DefaultAddress = "http://127.0.0.1:8500"
+
+	// DefaultWaitTime is the maximum duration of the blocking queries of Watch if no wait time is set.
+	DefaultWaitTime = 5 * time.Minute
+
+	// DefaultTimeout is the timeout of the requests if no timeout is set.
+	DefaultTimeout = 10 * time.Second
+
+	// retryInterval is the first wait after a failed blocking query, the next waits double.
+	retryInterval = time.Second
+)
+
+// kvPair is a key of the response of the Consul KV API, the Value is base64 encoded in the JSON.
+type kvPair struct {
+	Key   string
+	Value []byte
+}
+
+// Source loads the variables from the keys under a prefix, the name of the variable is the key without
+// the prefix, e.g. services/billing/APP_PORT is APP_PORT with the services/billing/ prefix.
+type Source struct {
+	// Address is the URL of the Consul agent, e.g. http://127.0.0.1:8500.
+	Address string
+
+	// Prefix is the common prefix of the keys.
+	Prefix string
+
+	// Token is the optional ACL token.
+	Token string
+
+	// WaitTime is the maximum duration of the blocking queries of Watch, DefaultWaitTime if zero.
+	WaitTime time.Duration
+
+	// Timeout is the timeout of the requests, DefaultTimeout if zero. The blocking queries of Watch may take
+	// WaitTime (plus the jitter added by Consul) longer.
+	Timeout time.Duration
+
+	client *http.Client
+
+	// mu guards the index of the last Load
+	mu    sync.Mutex
+	index uint64
+}
+
+// New creates a new Source which reads the keys from the Consul agent at address.
+func New(address, prefix string) *Source {
+	return &Source{Address: address, Prefix: prefix, client: http.DefaultClient}
+}
+
+// NewFromEnv creates a new Source with the environment variables CONSUL_HTTP_ADDR (the local agent if not set),
+// CONSUL_HTTP_TOKEN and APP_CONFIG_CONSUL_PREFIX.
+// The environment is read directly, as the Source is needed before the AppConfig is set up.
+func NewFromEnv() (*Source, error) {
+	prefix := os.Getenv(constants.APP_CONFIG_CONSUL_PREFIX)
+	if prefix == "" {
+		return nil, errors.Errorf("%s must be set", constants.APP_CONFIG_CONSUL_PREFIX)
+	}
+	address := os.Getenv(AddressEnv)
+	if address == "" {
+		address = DefaultAddress
+	}
+	// The Consul CLI accepts addresses without scheme
+	if !strings.Contains(address, "://") {
+		address = "http://" + address
+	}
+	source := New(address, prefix)
+	source.Token = os.Getenv(TokenEnv)
+	return source, nil
+}
+
+// Name implements the config.Source interface.
+func (s *Source) Name() string {
+	return "consul " + s.Prefix
+}
+
+// Load implements the config.Source interface.
+func (s *Source) Load(ctx context.Context) (map[string]string, error) {
+	pairs, index, err := s.list(ctx, url.Values{}, s.timeout())
+	if err != nil {
+		return nil, err
+	}
+	s.mu.Lock()
+	s.index = index
+	s.mu.Unlock()
+
+	values := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		// The keys ending with / are folders
+		if strings.HasSuffix(pair.Key, "/") {
+			continue
+		}
+		values[strings.TrimPrefix(pair.Key, s.Prefix)] = string(pair.Value)
+	}
+	return values, nil
+}
+
+// Watch implements the config.WatchableSource interface, it runs blocking queries and calls changed
+// whenever the index of the prefix differs from the index of the last Load. The failed queries are passed to
+// failed, and the next query waits with a backoff.
+func (s *Source) Watch(ctx context.Context, changed func(), failed func(err error)) error {
+	waitTime := s.WaitTime
+	if waitTime <= 0 {
+		waitTime = DefaultWaitTime
+	}
+	// Consul adds up to WaitTime/16 of jitter to the blocking queries
+	timeout := s.timeout() + waitTime + waitTime/16
+	backoff := poll.Backoff{Min: retryInterval}
+	for {
+		s.mu.Lock()
+		last := s.index
+		s.mu.Unlock()
+
+		query := url.Values{}
+		query.Set("index", strconv.FormatUint(last, 10))
+		query.Set("wait", fmt.Sprintf("%dms", waitTime.Milliseconds()))
+		_, index, err := s.list(ctx, query, timeout)
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		// Without an index the queries do not block, e.g. behind a proxy dropping the header
+		if err == nil && index == 0 {
+			err = errors.Errorf("Failed to watch %s: the response has no X-Consul-Index", s.Name())
+		}
+		if err != nil {
+			failed(err)
+			if !poll.Sleep(ctx, backoff.Failure()) {
+				return ctx.Err()
+			}
+			continue
+		}
+		backoff.Success()
+		if index != last {
+			// The Load of the Reload stores the new index, until then the next query returns immediately
+			s.mu.Lock()
+			s.index = index
+			s.mu.Unlock()
+			changed()
+		}
+	}
+}
+
+// timeout returns the timeout of the requests.
+func (s *Source) timeout() time.Duration {
+	if s.Timeout <= 0 {
+		return DefaultTimeout
+	}
+	return s.Timeout
+}
+
+// list returns the keys under the prefix and the index of the response, the request times out after timeout.
+func (s *Source) list(ctx context.Context, query url.Values, timeout time.Duration) ([]kvPair, uint64, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	query.Set("recurse", "true")
+	endpoint := fmt.Sprintf("%s/v1/kv/%s?%s", strings.TrimSuffix(s.Address, "/"), s.Prefix, query.Encode())
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "Failed to create request of %s", s.Name())
+	}
+	if s.Token != "" {
+		request.Header.Set("X-Consul-Token", s.Token)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return nil, 0, errors.Wrapf(err, "Failed to get %s", s.Name())
+	}
+	defer response.Body.Close()
+
+	index, _ := strconv.ParseUint(response.Header.Get("X-Consul-Index"), 10, 64)
+	// A missing prefix is an empty list
+	if response.StatusCode == http.StatusNotFound {
+		return nil, index, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return nil, 0, errors.Errorf("Failed to get %s: %s", s.Name(), response.Status)
+	}
+	pairs := []kvPair{}
+	if err := json.NewDecoder(response.Body).Decode(&pairs); err != nil {
+		return nil, 0, errors.Wrapf(err, "Failed to parse %s", s.Name())
+	}
+	return pairs, index, nil
+}
@@ -0,0 +1,201 @@
+package consulsource
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// ConsulSourceSuite extends testify's Suite.
+type ConsulSourceSuite struct {
+	suite.Suite
+}
+
+// fakeConsul serves the KV API from memory, with blocking queries
+type fakeConsul struct {
+	mu      sync.Mutex
+	kvs     map[string]string
+	index   uint64
+	updated chan struct{}
+	token   string
+}
+
+func newFakeConsul(kvs map[string]string) *fakeConsul {
+	return &fakeConsul{kvs: kvs, index: 1, updated: make(chan struct{})}
+}
+
+func (f *fakeConsul) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	f.token = r.Header.Get("X-Consul-Token")
+	updated := f.updated
+	blocking := r.URL.Query().Get("index") == strconv.FormatUint(f.index, 10)
+	f.mu.Unlock()
+	if blocking {
+		wait, _ := time.ParseDuration(r.URL.Query().Get("wait"))
+		select {
+		case <-updated:
+		case <-time.After(wait):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	prefix := strings.TrimPrefix(r.URL.Path, "/v1/kv/")
+	pairs := []kvPair{}
+	for key, value := range f.kvs {
+		if strings.HasPrefix(key, prefix) {
+			pairs = append(pairs, kvPair{Key: key, Value: []byte(value)})
+		}
+	}
+	w.Header().Set("X-Consul-Index", strconv.FormatUint(f.index, 10))
+	if len(pairs) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	_ = json.NewEncoder(w).Encode(pairs)
+}
+
+// put sets the key and wakes up the blocking queries
+func (f *fakeConsul) put(key, value string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.kvs[key] = value
+	f.index++
+	close(f.updated)
+	f.updated = make(chan struct{})
+}
+
+func (cs *ConsulSourceSuite) TestLoad() {
+	consul := newFakeConsul(map[string]string{
+		"services/billing/":         "",
+		"services/billing/APP_PORT": "9090",
+		"services/billing/APP_ENV":  "stage",
+		"services/other/APP_PORT":   "7070",
+	})
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	source := New(server.URL, "services/billing/")
+	source.Token = "secret"
+	cs.Equal("consul services/billing/", source.Name())
+	values, err := source.Load(context.Background())
+	cs.NoError(err, "Keys should have been loaded")
+	cs.Equal(map[string]string{"APP_PORT": "9090", "APP_ENV": "stage"}, values)
+	cs.Equal("secret", consul.token, "The ACL token should be sent")
+
+	values, err = New(server.URL, "services/missing/").Load(context.Background())
+	cs.NoError(err, "Missing prefix should be empty")
+	cs.Empty(values)
+}
+
+func (cs *ConsulSourceSuite) TestWatch() {
+	consul := newFakeConsul(map[string]string{"billing/APP_PORT": "9090"})
+	server := httptest.NewServer(consul)
+	defer server.Close()
+
+	conf := config.NewConfig(map[string]*config.Variable{
+		constants.APP_PORT: {DefaultValue: "8080"},
+	})
+	cs.NoError(os.Unsetenv(constants.APP_PORT))
+	cs.NoError(conf.SetupWithOptions(config.WithSources(New(server.URL, "billing/"))))
+	cs.Equal("9090", conf.Port())
+
+	changes := conf.Subscribe(constants.APP_PORT)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.WatchSources(ctx)
+
+	consul.put("billing/APP_PORT", "7070")
+	select {
+	case change := <-changes:
+		cs.Equal(config.Change{Name: constants.APP_PORT, OldValue: "9090", NewValue: "7070"}, change)
+	case <-time.After(5 * time.Second):
+		cs.Fail("The change should have been delivered")
+	}
+}
+
+func (cs *ConsulSourceSuite) TestWatchFailure() {
+	consul := newFakeConsul(map[string]string{"billing/APP_PORT": "9090"})
+	var mu sync.Mutex
+	failing, requests := true, 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if r.URL.Query().Get("wait") != "" && failing {
+			requests++
+			_, _ = w.Write([]byte("[]"))
+			return
+		}
+		consul.ServeHTTP(w, r)
+	}))
+	defer server.Close()
+
+	source := New(server.URL, "billing/")
+	_, err := source.Load(context.Background())
+	cs.NoError(err)
+	failures := make(chan error, 10)
+	changes := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = source.Watch(ctx, func() {
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}, func(err error) {
+			failures <- err
+		})
+	}()
+
+	select {
+	case err := <-failures:
+		cs.EqualError(err, "Failed to watch consul billing/: the response has no X-Consul-Index")
+	case <-time.After(5 * time.Second):
+		cs.Fail("The response without index should have been reported")
+	}
+	mu.Lock()
+	cs.Equal(1, requests, "The query should wait before the retry")
+	failing = false
+	mu.Unlock()
+
+	go consul.put("billing/APP_PORT", "7070")
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		cs.Fail("Watch should keep watching after a failed query")
+	}
+}
+
+func (cs *ConsulSourceSuite) TestNewFromEnv() {
+	cs.NoError(os.Unsetenv(constants.APP_CONFIG_CONSUL_PREFIX))
+	_, err := NewFromEnv()
+	cs.EqualError(err, "APP_CONFIG_CONSUL_PREFIX must be set")
+
+	cs.NoError(os.Setenv(constants.APP_CONFIG_CONSUL_PREFIX, "billing/"))
+	cs.NoError(os.Setenv(AddressEnv, "consul:8500"))
+	defer func() {
+		cs.NoError(os.Unsetenv(constants.APP_CONFIG_CONSUL_PREFIX))
+		cs.NoError(os.Unsetenv(AddressEnv))
+	}()
+	source, err := NewFromEnv()
+	cs.NoError(err)
+	cs.Equal("http://consul:8500", source.Address)
+}
+
+func TestConsulSource(t *testing.T) {
+	suite.Run(t, new(ConsulSourceSuite))
+}
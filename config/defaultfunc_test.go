@@ -0,0 +1,47 @@
+package config
+
+import (
+	"os"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestDefaultFunc() {
+	cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	calls := 0
+	conf := NewConfig(map[string]*Variable{
+		"APP_INSTANCE_ID": {
+			DefaultValue: "static",
+			DefaultFunc: func() (string, error) {
+				calls++
+				return "instance-" + strconv.Itoa(calls), nil
+			},
+		},
+		constants.APP_PORT: {
+			DefaultFunc: func() (string, error) { return "8080", nil },
+		},
+	})
+	cts.NoError(conf.Setup())
+	cts.Equal("instance-1", conf.Get("APP_INSTANCE_ID"), "DefaultFunc should take precedence over DefaultValue")
+	cts.Equal("8080", conf.Port())
+	cts.Equal(OriginDefault, conf.Source(constants.APP_PORT))
+
+	cts.NoError(conf.Reload())
+	cts.NoError(conf.Setup())
+	cts.Equal("instance-1", conf.Get("APP_INSTANCE_ID"), "DefaultFunc should be called only once")
+	cts.Equal(1, calls)
+
+	cts.setEnvVars(map[string]string{constants.APP_PORT: "9090"})
+	defer func() {
+		cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	}()
+	cts.NoError(conf.Setup())
+	cts.Equal("9090", conf.Port(), "The environment should override the computed default")
+
+	failing := NewConfig(map[string]*Variable{
+		"APP_REGION": {DefaultFunc: func() (string, error) { return "", errors.New("no metadata service") }},
+	})
+	cts.EqualError(failing.Setup(), "Failed to set Application Configuration: Failed to compute the default value of APP_REGION: no metadata service")
+}
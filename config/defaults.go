@@ -0,0 +1,66 @@
+package config
+
+import (
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// BasicVariables returns the standard Variables of every service (APP_PORT, APP_ENV, APP_DEBUG, APP_LOG_LEVEL,
+// APP_LOG_DEV, APP_LOG_FORMAT_ERRORS and APP_DB_SECRET_NAME, see constants.BasicEnvs) with their rules.
+// The service specific Variables can be added to the returned map, or registered with AddVariable.
+func BasicVariables() map[string]*Variable {
+	return map[string]*Variable{
+		constants.APP_PORT: {
+			DefaultValue: "8080",
+			Description:  "TCP/IP Port where the application listens",
+			Rules: map[string]validation.Rule{
+				"Required":   validation.Required,
+				"Valid port": is.Port,
+			},
+		},
+		constants.APP_ENV: {
+			DefaultValue: constants.ENV_DEV,
+			Description:  "The environment of the application",
+			Rules: map[string]validation.Rule{
+				"Required":          validation.Required,
+				"Valid environment": validation.In(constants.ValidEnvironments...),
+			},
+		},
+		constants.APP_DEBUG: {
+			DefaultValue: "0",
+			Description:  "Debug mode",
+			Rules: map[string]validation.Rule{
+				"Truthy value": validation.In(constants.TruthyValues...),
+			},
+		},
+		constants.APP_LOG_LEVEL: {
+			DefaultValue: constants.LOG_LEVEL_INFO,
+			Description:  "Level of logging",
+			Rules: map[string]validation.Rule{
+				"Required":        validation.Required,
+				"Valid log level": validation.In(constants.ValidLogLevels...),
+			},
+		},
+		constants.APP_LOG_DEV: {
+			Description: "Log development mode (Text formatter instead of JSON)",
+			Rules: map[string]validation.Rule{
+				"Truthy value": validation.In(constants.TruthyValues...),
+			},
+		},
+		constants.APP_LOG_FORMAT_ERRORS: {
+			Description: "Format error log entries by switching newlines to --- and tabs to spaces",
+			Rules: map[string]validation.Rule{
+				"Truthy value": validation.In(constants.TruthyValues...),
+			},
+		},
+		constants.APP_DB_SECRET_NAME: {
+			Description: "The Database's secret's name in AWS SecretsManager",
+		},
+	}
+}
+
+// NewDefaultConfig creates a new AppConfig with the BasicVariables and the supplied ConfigOptions.
+func NewDefaultConfig(opts ...ConfigOption) *AppConfig {
+	return NewConfig(BasicVariables(), opts...)
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestNewDefaultConfig() {
+	unsetEnv := func() {
+		for _, name := range constants.BasicEnvs {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+
+	conf := NewDefaultConfig()
+	cts.NoError(conf.Setup())
+	for _, name := range constants.BasicEnvs {
+		if name == constants.EC2_ID {
+			continue
+		}
+		_, ok := conf.Lookup(name)
+		cts.True(ok, "%s should be registered", name)
+	}
+	cts.Equal("8080", conf.Port())
+	cts.True(conf.IsDev())
+	cts.False(conf.IsDebug())
+	cts.Equal(constants.LOG_LEVEL_INFO, conf.LogLevel())
+
+	cts.setEnvVars(map[string]string{
+		constants.APP_ENV:   "space",
+		constants.APP_DEBUG: "maybe",
+	})
+	cts.EqualError(errors.Cause(conf.Setup()), "APP_DEBUG = maybe: (Truthy value: must be a valid value.); APP_ENV = space: (Valid environment: must be a valid value.).")
+
+	cts.NotSame(BasicVariables()[constants.APP_PORT], BasicVariables()[constants.APP_PORT], "Every call should return new Variables")
+}
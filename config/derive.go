@@ -0,0 +1,80 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DeriveFunc computes the value of a derived Variable from the other Variables, get returns the (expanded)
+// value of the named Variable, the derived Variables included. E.g. APP_BASE_URL from the scheme, host and port:
+//
+//	func(get func(name string) string) (string, error) {
+//		return get("APP_SCHEME") + "://" + get("APP_HOST") + ":" + get("APP_PORT"), nil
+//	}
+type DeriveFunc func(get func(name string) string) (string, error)
+
+// deriveValues replaces the values of the derived Variables with the result of their Derive function and
+// sets their origins to OriginDerived. The functions are called without holding the lock, on every Setup and Reload.
+// It returns an error if a function fails or the derived Variables depend on each other in a cycle.
+func (appConf *AppConfig) deriveValues(values, origins map[string]string) error {
+	appConf.mu.RLock()
+	funcs := map[string]DeriveFunc{}
+	for confKey, confVar := range appConf.vars {
+		if confVar.Derive != nil {
+			funcs[confKey] = confVar.Derive
+		}
+	}
+	appConf.mu.RUnlock()
+	if len(funcs) == 0 {
+		return nil
+	}
+
+	derived := map[string]bool{}
+	// path is the chain of the names being derived, used for the cycle detection
+	path := []string{}
+	var derive func(name string) error
+	derive = func(name string) error {
+		if derived[name] {
+			return nil
+		}
+		for i, visiting := range path {
+			if visiting == name {
+				return errors.Errorf("Cycle in derived variables: %s -> %s", strings.Join(path[i:], " -> "), name)
+			}
+		}
+
+		path = append(path, name)
+		var err error
+		value, fnErr := funcs[name](func(refName string) string {
+			if _, ok := funcs[refName]; ok && err == nil {
+				err = derive(refName)
+			}
+			return values[refName]
+		})
+		path = path[:len(path)-1]
+		if err != nil {
+			return err
+		}
+		if fnErr != nil {
+			return errors.Wrapf(fnErr, "Failed to derive %s", appConf.externalName(name))
+		}
+		values[name], origins[name] = value, OriginDerived
+		derived[name] = true
+		return nil
+	}
+
+	names := []string{}
+	for name := range funcs {
+		names = append(names, name)
+	}
+	// Sort is needed to report the same error on every run
+	sort.Strings(names)
+	for _, name := range names {
+		if err := derive(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package config
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+)
+
+func (cts *ConfigTestSuite) TestDerive() {
+	for _, name := range []string{"APP_SCHEME", "APP_HOST", "APP_PORT", "APP_BASE_URL", "APP_HEALTH_URL"} {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	defer func() {
+		cts.NoError(os.Unsetenv("APP_HOST"), "Environment variable should have been unset")
+	}()
+
+	conf := NewConfig(map[string]*Variable{
+		"APP_SCHEME": {DefaultValue: "http"},
+		"APP_HOST":   {DefaultValue: "localhost"},
+		"APP_PORT":   {DefaultValue: "8080"},
+		"APP_BASE_URL": {
+			Description: "Base URL of the service",
+			Derive: func(get func(name string) string) (string, error) {
+				return get("APP_SCHEME") + "://" + get("APP_HOST") + ":" + get("APP_PORT"), nil
+			},
+		},
+		"APP_HEALTH_URL": {
+			Derive: func(get func(name string) string) (string, error) {
+				return get("APP_BASE_URL") + "/health", nil
+			},
+		},
+	})
+	cts.Contains(conf.DumpTable(), "derived", "Derived variables should be marked before the Setup")
+
+	cts.NoError(os.Setenv("APP_BASE_URL", "ignored"), "Environment variable should have been set")
+	cts.NoError(conf.Setup())
+	cts.NoError(os.Unsetenv("APP_BASE_URL"), "Environment variable should have been unset")
+	cts.Equal("http://localhost:8080", conf.Get("APP_BASE_URL"), "Derived value should take precedence")
+	cts.Equal("http://localhost:8080/health", conf.Get("APP_HEALTH_URL"), "Derived variables should be derived in order")
+	cts.Equal(OriginDerived, conf.Source("APP_BASE_URL"))
+	cts.Contains(conf.DumpTable(), "derived")
+
+	cts.NoError(os.Setenv("APP_HOST", "api.internal"), "Environment variable should have been set")
+	cts.NoError(conf.Reload())
+	cts.Equal("http://api.internal:8080/health", conf.Get("APP_HEALTH_URL"), "Derived values should be recomputed on Reload")
+}
+
+func (cts *ConfigTestSuite) TestDeriveErrors() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_A": {Derive: func(get func(name string) string) (string, error) {
+			return get("APP_B"), nil
+		}},
+		"APP_B": {Derive: func(get func(name string) string) (string, error) {
+			return get("APP_A"), nil
+		}},
+	})
+	cts.EqualError(conf.Setup(), "Failed to set Application Configuration: Cycle in derived variables: APP_A -> APP_B -> APP_A")
+
+	conf = NewConfig(map[string]*Variable{
+		"APP_A": {Derive: func(get func(name string) string) (string, error) {
+			return "", errors.New("No host")
+		}},
+	})
+	cts.EqualError(conf.Setup(), "Failed to set Application Configuration: Failed to derive APP_A: No host")
+}
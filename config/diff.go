@@ -0,0 +1,132 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// DiffKind is the kind of a difference between two configuration files.
+type DiffKind string
+
+const (
+	// DiffAdded is a key which is only in the second file.
+	DiffAdded DiffKind = "added"
+
+	// DiffRemoved is a key which is only in the first file.
+	DiffRemoved DiffKind = "removed"
+
+	// DiffChanged is a key which has different values in the files.
+	DiffChanged DiffKind = "changed"
+)
+
+// DiffEntry is a single difference of an EnvDiff.
+type DiffEntry struct {
+	// Name is the key as it is in the files.
+	Name string `json:"name"`
+
+	// Kind is the kind of the difference.
+	Kind DiffKind `json:"kind"`
+
+	// Old is the value in the first file, masked if the Variable is Sensitive (or redacted by its Redact).
+	Old string `json:"old,omitempty"`
+
+	// New is the value in the second file, masked if the Variable is Sensitive (or redacted by its Redact).
+	New string `json:"new,omitempty"`
+
+	// Sensitive is true if the values are masked, the changed Sensitive values are reported with masked values.
+	Sensitive bool `json:"sensitive"`
+}
+
+// EnvDiff is the list of the differences of two configuration files, sorted by Name.
+type EnvDiff []DiffEntry
+
+// String renders the differences line by line: "+ NAME=value" for the added, "- NAME=value" for the removed
+// and "~ NAME: old -> new" for the changed keys.
+func (diff EnvDiff) String() string {
+	builder := &strings.Builder{}
+	for _, entry := range diff {
+		switch entry.Kind {
+		case DiffAdded:
+			fmt.Fprintf(builder, "+ %s=%s\n", entry.Name, entry.New)
+		case DiffRemoved:
+			fmt.Fprintf(builder, "- %s=%s\n", entry.Name, entry.Old)
+		case DiffChanged:
+			fmt.Fprintf(builder, "~ %s: %s -> %s\n", entry.Name, entry.Old, entry.New)
+		}
+	}
+	return builder.String()
+}
+
+// Diff compares two configuration files (envfile, JSON or YAML by their extension), e.g. the envfiles of
+// staging and production, and returns the added, removed and changed keys. The values of the Sensitive
+// Variables are masked, the keys unknown to the AppConfig are compared too. Only the files are compared,
+// the defaults and the environment are ignored. The files of the earlier schema versions are migrated first.
+func (appConf *AppConfig) Diff(fileA, fileB string) (EnvDiff, error) {
+	valuesA, err := appConf.readMigratedDocument(fileA)
+	if err != nil {
+		return nil, err
+	}
+	valuesB, err := appConf.readMigratedDocument(fileB)
+	if err != nil {
+		return nil, err
+	}
+
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+
+	diff := EnvDiff{}
+	entry := func(name string, kind DiffKind, oldValue, newValue string) DiffEntry {
+		entry := DiffEntry{Name: name, Kind: kind, Old: oldValue, New: newValue}
+		if confVar, ok := appConf.vars[appConf.internalName(name)]; ok && (confVar.Sensitive || confVar.Redact != nil) {
+			entry.Old, entry.New, entry.Sensitive = confVar.display(oldValue), confVar.display(newValue), true
+		}
+		return entry
+	}
+	for name, oldValue := range valuesA {
+		newValue, ok := valuesB[name]
+		switch {
+		case !ok:
+			diff = append(diff, entry(name, DiffRemoved, oldValue, ""))
+		case oldValue != newValue:
+			diff = append(diff, entry(name, DiffChanged, oldValue, newValue))
+		}
+	}
+	for name, newValue := range valuesB {
+		if _, ok := valuesA[name]; !ok {
+			diff = append(diff, entry(name, DiffAdded, "", newValue))
+		}
+	}
+
+	// Sort is needed because maps always return values in random order
+	sort.Slice(diff, func(i, j int) bool {
+		return diff[i].Name < diff[j].Name
+	})
+	return diff, nil
+}
+
+// readMigratedDocument reads and parses the configuration file by its extension, and migrates its values to
+// the schema version of the AppConfig, see WithSchemaVersion.
+func (appConf *AppConfig) readMigratedDocument(filename string) (map[string]string, error) {
+	values, err := readDocument(filename)
+	if err != nil {
+		return nil, err
+	}
+	return appConf.migrateEnvfile(filename, values)
+}
+
+// readDocument reads and parses the configuration file by its extension.
+func readDocument(filename string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read %s", filename)
+	}
+	values, err := ParseDocument(FormatFromPath(filename), content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse %s", filename)
+	}
+	return values, nil
+}
@@ -0,0 +1,38 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+)
+
+func (cts *ConfigTestSuite) TestDiff() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_PORT":        {DefaultValue: "8080"},
+		"APP_ENV":         {},
+		"APP_DB_PASSWORD": {Sensitive: true},
+		"APP_API_KEY":     {Sensitive: true},
+	})
+
+	dir := cts.T().TempDir()
+	staging := filepath.Join(dir, "staging.env")
+	production := filepath.Join(dir, "production.yaml")
+	cts.NoError(ioutil.WriteFile(staging, []byte("APP_PORT=8080\nAPP_ENV=staging\nAPP_DB_PASSWORD=staging-pass\nAPP_DEBUG=1\nAPP_API_KEY=same\n"), 0600))
+	cts.NoError(ioutil.WriteFile(production, []byte("app:\n  port: 8080\n  env: production\n  db_password: prod-pass\n  api_key: same\n  replicas: 3\n"), 0600))
+
+	diff, err := conf.Diff(staging, production)
+	cts.NoError(err)
+	cts.Equal(EnvDiff{
+		{Name: "APP_DB_PASSWORD", Kind: DiffChanged, Old: MaskedValue, New: MaskedValue, Sensitive: true},
+		{Name: "APP_DEBUG", Kind: DiffRemoved, Old: "1"},
+		{Name: "APP_ENV", Kind: DiffChanged, Old: "staging", New: "production"},
+		{Name: "APP_REPLICAS", Kind: DiffAdded, New: "3"},
+	}, diff, "Files of different formats should be compared by the Variable names")
+	cts.Equal("~ APP_DB_PASSWORD: ***** -> *****\n- APP_DEBUG=1\n~ APP_ENV: staging -> production\n+ APP_REPLICAS=3\n", diff.String())
+
+	diff, err = conf.Diff(staging, staging)
+	cts.NoError(err)
+	cts.Empty(diff)
+
+	_, err = conf.Diff(staging, filepath.Join(dir, "missing.env"))
+	cts.Error(err)
+}
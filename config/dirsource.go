@@ -0,0 +1,135 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+)
+
+// DockerSecretsDir is the directory where Docker Swarm and Compose mount the secrets.
+const DockerSecretsDir = "/run/secrets"
+
+// DirSource is a Source which reads every file of a directory as a variable: the name of the file is the
+// name of the variable, its trimmed content is the value. Subdirectories and hidden files are skipped
+// (e.g. the ..data directory of the Kubernetes volumes, the files are symlinks into it).
+type DirSource struct {
+	// Dir is the directory to read.
+	Dir string
+
+	// Uppercase converts the file names to upper case, e.g. app_db_password is APP_DB_PASSWORD.
+	Uppercase bool
+
+	// Optional makes a missing directory an empty Source instead of an error.
+	Optional bool
+}
+
+// LoadFromDir reads every file of dir as a variable like a DirSource, e.g. the files of a mounted Kubernetes
+// ConfigMap or Secret volume. If uppercase is true the file names are converted to upper case.
+func LoadFromDir(dir string, uppercase bool) (map[string]string, error) {
+	return (&DirSource{Dir: dir, Uppercase: uppercase}).Load(context.Background())
+}
+
+// WithDir loads the files of dir as variables (see LoadFromDir), after the other Sources.
+// The directory is watched by WatchSources, so the updates of a mounted ConfigMap or Secret are reloaded.
+func WithDir(dir string, uppercase bool) SetupOption {
+	return WithSources(&DirSource{Dir: dir, Uppercase: uppercase})
+}
+
+// NewDockerSecretsSource creates a DirSource reading the Docker secrets mounted into dir, or into
+// DockerSecretsDir if dir is empty. The names of the secrets are converted to upper case, and a missing
+// directory is not an error, so the same setup works outside of Docker.
+func NewDockerSecretsSource(dir string) *DirSource {
+	if dir == "" {
+		dir = DockerSecretsDir
+	}
+	return &DirSource{Dir: dir, Uppercase: true, Optional: true}
+}
+
+// Name implements the Source interface.
+func (d *DirSource) Name() string {
+	return "directory " + d.Dir
+}
+
+// Load implements the Source interface.
+func (d *DirSource) Load(ctx context.Context) (map[string]string, error) {
+	entries, err := ioutil.ReadDir(d.Dir)
+	if os.IsNotExist(err) && d.Optional {
+		return map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read directory %s", d.Dir)
+	}
+
+	values := map[string]string{}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), ".") {
+			continue
+		}
+		filename := filepath.Join(d.Dir, entry.Name())
+		// Stat follows the symlinks, the mounted files are often links
+		info, err := os.Stat(filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read %s", filename)
+		}
+		if info.IsDir() {
+			continue
+		}
+		content, err := ioutil.ReadFile(filename)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read %s", filename)
+		}
+		name := entry.Name()
+		if d.Uppercase {
+			name = strings.ToUpper(name)
+		}
+		values[name] = strings.TrimSpace(string(content))
+	}
+	return values, nil
+}
+
+// Watch implements the WatchableSource interface, changed is called after the files of the directory
+// are modified, including the atomic updates of the Kubernetes volumes. The errors of the watcher, e.g. an
+// overflow of its event queue, are passed to failed and changed is called, as events may have been lost.
+func (d *DirSource) Watch(ctx context.Context, changed func(), failed func(err error)) error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file watcher")
+	}
+	defer watcher.Close()
+	if err := watcher.Add(d.Dir); err != nil {
+		return errors.Wrapf(err, "Failed to watch %s", d.Dir)
+	}
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+	defer debounce.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			// Kubernetes writes the files into a hidden directory, then swaps the ..data symlink
+			name := filepath.Base(event.Name)
+			if name == k8sDataDir || !strings.HasPrefix(name, ".") {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			failed(errors.Wrapf(err, "Failed to watch %s", d.Dir))
+			debounce.Reset(watchDebounce)
+		case <-debounce.C:
+			changed()
+		}
+	}
+}
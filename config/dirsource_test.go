@@ -0,0 +1,93 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestDockerSecretsSource() {
+	dir := cts.T().TempDir()
+	cts.NoError(ioutil.WriteFile(filepath.Join(dir, "app_db_password"), []byte("s3cr3t\n"), 0600))
+	cts.NoError(ioutil.WriteFile(filepath.Join(dir, ".hidden"), []byte("hidden"), 0600))
+	cts.NoError(os.Mkdir(filepath.Join(dir, "nested"), 0700))
+
+	source := NewDockerSecretsSource(dir)
+	values, err := source.Load(context.Background())
+	cts.NoError(err)
+	cts.Equal(map[string]string{"APP_DB_PASSWORD": "s3cr3t"}, values)
+
+	conf := NewConfig(map[string]*Variable{
+		"APP_DB_PASSWORD": {Sensitive: true},
+	})
+	cts.NoError(conf.SetupWithOptions(WithSources(source)))
+	cts.Equal("s3cr3t", conf.Get("APP_DB_PASSWORD"))
+
+	missing := NewDockerSecretsSource(filepath.Join(dir, "missing"))
+	values, err = missing.Load(context.Background())
+	cts.NoError(err, "Missing Docker secrets directory should not be an error")
+	cts.Empty(values)
+	cts.Equal(DockerSecretsDir, NewDockerSecretsSource("").Dir)
+
+	_, err = (&DirSource{Dir: filepath.Join(dir, "missing")}).Load(context.Background())
+	cts.Error(err, "Missing directory should be an error unless Optional")
+}
+
+// writeK8sVolume writes files like the Kubernetes volumes: into a hidden versioned directory, linked by the
+// ..data symlink, and links every file through it.
+func (cts *ConfigTestSuite) writeK8sVolume(dir, version string, files map[string]string) {
+	versionDir := filepath.Join(dir, "..version_"+version)
+	cts.NoError(os.Mkdir(versionDir, 0700))
+	for name, content := range files {
+		cts.NoError(ioutil.WriteFile(filepath.Join(versionDir, name), []byte(content), 0600))
+		link := filepath.Join(dir, name)
+		if _, err := os.Lstat(link); os.IsNotExist(err) {
+			cts.NoError(os.Symlink(filepath.Join("..data", name), link))
+		}
+	}
+	// Swap the ..data symlink atomically
+	tmpLink := filepath.Join(dir, "..data_tmp")
+	cts.NoError(os.Symlink(filepath.Base(versionDir), tmpLink))
+	cts.NoError(os.Rename(tmpLink, filepath.Join(dir, "..data")))
+}
+
+func (cts *ConfigTestSuite) TestLoadFromDir() {
+	dir := cts.T().TempDir()
+	cts.writeK8sVolume(dir, "1", map[string]string{"app_port": "9090", "APP_ENV": "production"})
+
+	values, err := LoadFromDir(dir, true)
+	cts.NoError(err)
+	cts.Equal(map[string]string{"APP_PORT": "9090", "APP_ENV": "production"}, values, "Hidden directories should be skipped")
+
+	values, err = LoadFromDir(dir, false)
+	cts.NoError(err)
+	cts.Equal("9090", values["app_port"])
+
+	for _, name := range constants.BasicEnvs {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupWithOptions(WithDir(dir, true)))
+	cts.Equal("9090", conf.Port())
+
+	changes := make(chan []string, 1)
+	conf.OnChange(func(changed []string) { changes <- changed })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.WatchSources(ctx)
+	// Give the watcher time to start
+	time.Sleep(50 * time.Millisecond)
+
+	cts.writeK8sVolume(dir, "2", map[string]string{"app_port": "7070", "APP_ENV": "production"})
+	select {
+	case changed := <-changes:
+		cts.Equal([]string{constants.APP_PORT}, changed)
+		cts.Equal("7070", conf.Port())
+	case <-time.After(5 * time.Second):
+		cts.Fail("Update of the volume should have been detected")
+	}
+}
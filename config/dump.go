@@ -0,0 +1,222 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+)
+
+// DumpFormat is the output format of Dump.
+type DumpFormat string
+
+const (
+	// DumpFormatTable is an ASCII table, the format of DumpTable.
+	DumpFormatTable DumpFormat = "table"
+
+	// DumpFormatMarkdown is a GitHub flavored markdown table, e.g. for READMEs.
+	DumpFormatMarkdown DumpFormat = "markdown"
+
+	// DumpFormatJSON is a JSON array of objects, for machine consumption.
+	DumpFormatJSON DumpFormat = "json"
+
+	// DumpFormatCSV is a CSV document with a header row.
+	DumpFormatCSV DumpFormat = "csv"
+)
+
+// dumpHeader is the header of the tabular dump formats.
+var dumpHeader = []string{"Variable Name", "Description", "Constraints", "Default Value"}
+
+// dumpRow is the dumped data of a single Variable.
+type dumpRow struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description"`
+	Constraints  []string `json:"constraints"`
+	DefaultValue string   `json:"default_value"`
+	Sensitive    bool     `json:"sensitive"`
+	Group        string   `json:"group,omitempty"`
+	Source       string   `json:"-"`
+	Hint         string   `json:"hint,omitempty"`
+
+	// displayed is the default value redacted for the human-readable dumps, see Variable.Redact.
+	displayed string
+
+	// sample is the default value written to the sample file, empty for the Sensitive Variables.
+	sample string
+}
+
+// fields returns the row in the order of the dumpHeader, with the redacted default value.
+func (row dumpRow) fields() []string {
+	return []string{row.Name, row.Description, strings.Join(row.Constraints, ", "), row.displayed}
+}
+
+// Dump returns all the config variable names, descriptions, constraints and default values in the
+// requested format, the table has the origins of the values too (see Source). The values of the Sensitive
+// variables are masked, the human-readable formats (all but JSON) show the values redacted by Redact.
+func (appConf *AppConfig) Dump(format DumpFormat) (string, error) {
+	rows := appConf.dumpRows()
+	switch format {
+	case DumpFormatTable:
+		return dumpTable(rows), nil
+	case DumpFormatMarkdown:
+		return dumpMarkdown(rows), nil
+	case DumpFormatJSON:
+		content, err := json.MarshalIndent(rows, "", "  ")
+		if err != nil {
+			return "", errors.Wrap(err, "Failed to encode configuration as JSON")
+		}
+		return string(content) + "\n", nil
+	case DumpFormatCSV:
+		return dumpCSV(rows)
+	}
+	return "", errors.Errorf("Unknown dump format %s", format)
+}
+
+// dumpRows collects the data of the Variables in alphabetic order.
+func (appConf *AppConfig) dumpRows() []dumpRow {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+
+	keys := []string{}
+	for key := range appConf.vars {
+		keys = append(keys, key)
+	}
+	// Sort is needed because maps always return values in random order
+	sort.Strings(keys)
+
+	rows := make([]dumpRow, 0, len(keys))
+	for _, key := range keys {
+		elem := appConf.vars[key]
+		// Collect constraints
+		constraints := []string{}
+		for rule := range elem.Rules {
+			constraints = append(constraints, rule)
+		}
+		for rule := range elem.limitRules() {
+			constraints = append(constraints, rule)
+		}
+		for env, rules := range elem.RulesByEnv {
+			for rule := range rules {
+				constraints = append(constraints, fmt.Sprintf("%s (%s)", rule, env))
+			}
+		}
+		// Sort is needed because maps always return values in random order
+		sort.Strings(constraints)
+		source := elem.origin
+		if source == "" && elem.Derive != nil {
+			// Not set up yet, the value will be derived
+			source = OriginDerived
+		}
+		defaultValue, sample := elem.DefaultValue, elem.DefaultValue
+		if elem.Sensitive && defaultValue != "" {
+			defaultValue, sample = MaskedValue, ""
+		}
+		rows = append(rows, dumpRow{
+			Name:         appConf.externalName(key),
+			Description:  elem.Description,
+			Constraints:  constraints,
+			DefaultValue: defaultValue,
+			Sensitive:    elem.Sensitive,
+			Group:        elem.Group,
+			Source:       source,
+			Hint:         elem.Hint,
+			displayed:    elem.display(elem.DefaultValue),
+			sample:       sample,
+		})
+	}
+	return rows
+}
+
+// sortByGroup sorts the rows by their Group, keeping the order of the rows within the groups.
+func sortByGroup(rows []dumpRow) {
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].Group < rows[j].Group
+	})
+}
+
+// hasGroups returns true if any row belongs to a Group.
+func hasGroups(rows []dumpRow) bool {
+	for _, row := range rows {
+		if row.Group != "" {
+			return true
+		}
+	}
+	return false
+}
+
+// dumpTable renders the rows as an ASCII table with a Source column. If any Variable belongs to a Group, the
+// rows are ordered by group and a Group column names the group at its first row.
+func dumpTable(rows []dumpRow) string {
+	grouped := hasGroups(rows)
+	header := append(append([]string{}, dumpHeader...), "Source")
+	if grouped {
+		rows = append([]dumpRow{}, rows...)
+		sortByGroup(rows)
+		header = append([]string{"Group"}, header...)
+	}
+
+	tableString := &strings.Builder{}
+	table := tablewriter.NewWriter(tableString)
+	table.SetHeader(header)
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetRowSeparator("-")
+	table.SetRowLine(true)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	for i, row := range rows {
+		fields := append(row.fields(), row.Source)
+		if !grouped {
+			table.Append(fields)
+			continue
+		}
+		group := ""
+		if i == 0 || rows[i-1].Group != row.Group {
+			group = row.Group
+		}
+		table.Append(append([]string{group}, fields...))
+	}
+	table.Render()
+
+	return tableString.String()
+}
+
+// dumpMarkdown renders the rows as a markdown table, the default values are formatted as code.
+func dumpMarkdown(rows []dumpRow) string {
+	builder := &strings.Builder{}
+	builder.WriteString("| " + strings.Join(dumpHeader, " | ") + " |\n")
+	builder.WriteString(strings.Repeat("| --- ", len(dumpHeader)) + "|\n")
+	for _, row := range rows {
+		fields := row.fields()
+		if fields[3] != "" {
+			fields[3] = "`" + fields[3] + "`"
+		}
+		for i, field := range fields {
+			fields[i] = markdownEscaper.Replace(field)
+		}
+		builder.WriteString("| " + strings.Join(fields, " | ") + " |\n")
+	}
+	return builder.String()
+}
+
+// markdownEscaper escapes the characters which would break a markdown table cell.
+var markdownEscaper = strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+
+// dumpCSV renders the rows as CSV with a header row.
+func dumpCSV(rows []dumpRow) (string, error) {
+	builder := &strings.Builder{}
+	writer := csv.NewWriter(builder)
+	if err := writer.Write(dumpHeader); err != nil {
+		return "", errors.Wrap(err, "Failed to encode configuration as CSV")
+	}
+	for _, row := range rows {
+		if err := writer.Write(row.fields()); err != nil {
+			return "", errors.Wrap(err, "Failed to encode configuration as CSV")
+		}
+	}
+	writer.Flush()
+	return builder.String(), errors.Wrap(writer.Error(), "Failed to encode configuration as CSV")
+}
@@ -0,0 +1,99 @@
+package config
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) dumpConfig() *AppConfig {
+	defaults := cts.getDefaultConfigs()
+	defaults["APP_API_KEY"] = &Variable{
+		DefaultValue: "s3cr3t",
+		Description:  "The key of the API | the one from the vault",
+		Rules: map[string]validation.Rule{
+			"Required": validation.Required,
+		},
+		Sensitive: true,
+	}
+	return NewConfig(defaults)
+}
+
+func (cts *ConfigTestSuite) TestDumpTableFormat() {
+	conf := cts.dumpConfig()
+	table, err := conf.Dump(DumpFormatTable)
+	cts.NoError(err)
+	cts.Equal(conf.DumpTable(), table, "Table format should match DumpTable")
+	cts.Contains(strings.Split(table, "\n")[1], "| DEFAULT VALUE | SOURCE |", "Table should have a Source column")
+}
+
+func (cts *ConfigTestSuite) TestDumpMarkdown() {
+	markdown, err := cts.dumpConfig().Dump(DumpFormatMarkdown)
+	cts.NoError(err)
+	lines := strings.Split(strings.TrimSpace(markdown), "\n")
+	cts.Equal("| Variable Name | Description | Constraints | Default Value |", lines[0])
+	cts.Equal("| --- | --- | --- | --- |", lines[1])
+	cts.Equal(`| APP_API_KEY | The key of the API \| the one from the vault | Required | `+"`*****`"+` |`, lines[2])
+	cts.Contains(markdown, "| APP_PORT | TCP/IP Port where the application listens | Required, Valid port | `8080` |")
+	cts.Contains(markdown, "| APP_DB_SECRET_NAME | The Database's secret's name in AWS SecretsManager |  |  |")
+}
+
+func (cts *ConfigTestSuite) TestDumpJSON() {
+	content, err := cts.dumpConfig().Dump(DumpFormatJSON)
+	cts.NoError(err)
+
+	rows := []map[string]interface{}{}
+	cts.NoError(json.Unmarshal([]byte(content), &rows), "Dump should be valid JSON")
+	cts.Len(rows, 8)
+	cts.Equal("APP_API_KEY", rows[0]["name"])
+	cts.Equal("*****", rows[0]["default_value"])
+	cts.Equal(true, rows[0]["sensitive"])
+	cts.Equal(constants.APP_DB_SECRET_NAME, rows[1]["name"])
+	cts.Equal([]interface{}{}, rows[1]["constraints"], "Constraints should be an empty array, not null")
+}
+
+func (cts *ConfigTestSuite) TestDumpCSV() {
+	content, err := cts.dumpConfig().Dump(DumpFormatCSV)
+	cts.NoError(err)
+
+	records, err := csv.NewReader(strings.NewReader(content)).ReadAll()
+	cts.NoError(err, "Dump should be valid CSV")
+	cts.Len(records, 9)
+	cts.Equal([]string{"Variable Name", "Description", "Constraints", "Default Value"}, records[0])
+	cts.Equal([]string{"APP_DEBUG", "Debug mode", "Truthy value", "true"}, records[3])
+}
+
+func (cts *ConfigTestSuite) TestDumpUnknownFormat() {
+	_, err := cts.dumpConfig().Dump("xml")
+	cts.EqualError(err, "Unknown dump format xml")
+}
+
+func (cts *ConfigTestSuite) TestDumpGroups() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_PORT":        {DefaultValue: "8080", Group: "Server"},
+		"APP_DB_HOST":     {DefaultValue: "localhost", Group: "Database"},
+		"APP_DB_PASSWORD": {Group: "Database", Sensitive: true},
+		"APP_NAME":        {DefaultValue: "orders"},
+	})
+
+	lines := strings.Split(conf.DumpTable(), "\n")
+	cts.Contains(lines[1], "| GROUP    | VARIABLE NAME", "Table should have a Group column")
+	cts.Contains(lines[3], "|          | APP_NAME")
+	cts.Contains(lines[5], "| Database | APP_DB_HOST")
+	cts.Contains(lines[7], "|          | APP_DB_PASSWORD")
+	cts.Contains(lines[9], "| Server   | APP_PORT")
+
+	sampleFile := filepath.Join(cts.T().TempDir(), ".env.sample")
+	cts.NoError(conf.CreateSampleFile(sampleFile))
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err)
+	sample := string(content)
+	cts.Regexp(`(?s)APP_NAME=orders\n\n# ---- Database ----\n\n.*APP_DB_HOST=localhost\n\n.*APP_DB_PASSWORD=\n\n# ---- Server ----\n\n.*APP_PORT=8080`, sample)
+
+	cts.NotContains(cts.dumpConfig().DumpTable(), "GROUP", "Table without groups should not have a Group column")
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/pkg/errors"
+)
+
+const (
+	// AgeKeyEnv is the environment variable holding the age identities (AGE-SECRET-KEY-1... lines),
+	// the same as sops uses.
+	AgeKeyEnv = "SOPS_AGE_KEY"
+
+	// AgeKeyFileEnv is the environment variable naming the file of the age identities, the same as sops uses.
+	AgeKeyFileEnv = "SOPS_AGE_KEY_FILE"
+)
+
+// Decrypter decrypts an encrypted configuration file in memory, the plaintext is never written to disk.
+type Decrypter interface {
+	// Decrypt returns the plaintext content of the encrypted file.
+	Decrypt(ctx context.Context, filename string) ([]byte, error)
+}
+
+// AgeDecrypter decrypts the files encrypted with age (binary or armored), e.g. by
+//
+//	age -r age1... -a -o .env.staging.age .env.staging
+type AgeDecrypter struct {
+	identities []age.Identity
+}
+
+// NewAgeDecrypter creates an AgeDecrypter from the identities, in the format of the age key files.
+func NewAgeDecrypter(identities string) (*AgeDecrypter, error) {
+	parsed, err := age.ParseIdentities(strings.NewReader(identities))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to parse age identities")
+	}
+	return &AgeDecrypter{identities: parsed}, nil
+}
+
+// NewAgeDecrypterFromEnv creates an AgeDecrypter from the identities of the AgeKeyEnv environment variable,
+// or the file named by AgeKeyFileEnv.
+func NewAgeDecrypterFromEnv() (*AgeDecrypter, error) {
+	if identities := os.Getenv(AgeKeyEnv); identities != "" {
+		return NewAgeDecrypter(identities)
+	}
+	filename := os.Getenv(AgeKeyFileEnv)
+	if filename == "" {
+		return nil, errors.Errorf("Neither %s nor %s is set", AgeKeyEnv, AgeKeyFileEnv)
+	}
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read %s", AgeKeyFileEnv)
+	}
+	return NewAgeDecrypter(string(content))
+}
+
+// Decrypt implements the Decrypter interface.
+func (d *AgeDecrypter) Decrypt(ctx context.Context, filename string) ([]byte, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to open %s", filename)
+	}
+	defer file.Close()
+
+	var in io.Reader = bufio.NewReader(file)
+	if header, _ := in.(*bufio.Reader).Peek(len(armor.Header)); string(header) == armor.Header {
+		in = armor.NewReader(in)
+	}
+	plaintext, err := age.Decrypt(in, d.identities...)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to decrypt %s", filename)
+	}
+	content, err := ioutil.ReadAll(plaintext)
+	return content, errors.Wrapf(err, "Failed to decrypt %s", filename)
+}
+
+// SOPSDecrypter decrypts the files encrypted with SOPS by running sops --decrypt, so every key type of
+// SOPS (age, AWS KMS, GCP KMS, PGP, ...) is supported with its usual configuration (e.g. SOPS_AGE_KEY or
+// the AWS credentials).
+type SOPSDecrypter struct {
+	// Binary is the path of the sops executable, "sops" from the PATH if empty.
+	Binary string
+}
+
+// Decrypt implements the Decrypter interface.
+func (d *SOPSDecrypter) Decrypt(ctx context.Context, filename string) ([]byte, error) {
+	binary := d.Binary
+	if binary == "" {
+		binary = "sops"
+	}
+	args := []string{"--decrypt"}
+	// sops detects the dotenv format only by the .env extension, e.g. not for .env.staging
+	if FormatFromPath(filename) == FormatEnv {
+		args = append(args, "--input-type", "dotenv", "--output-type", "dotenv")
+	}
+	stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+	cmd := exec.CommandContext(ctx, binary, append(args, filename)...)
+	cmd.Stdout, cmd.Stderr = stdout, stderr
+	if err := cmd.Run(); err != nil {
+		return nil, errors.Wrapf(err, "Failed to decrypt %s: %s", filename, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.Bytes(), nil
+}
+
+// EncryptedFileSource is a Source which decrypts a configuration file (envfile, JSON or YAML by its
+// extension, without the .age or .enc suffix) in memory, so the plaintext is neither written to disk nor
+// set in the environment.
+type EncryptedFileSource struct {
+	// Path is the path of the encrypted file.
+	Path string
+
+	// Decrypter decrypts the file.
+	Decrypter Decrypter
+}
+
+// WithEncryptedEnvfiles loads the supplied encrypted files (see EncryptedFileSource) as Sources, so they
+// take precedence over the environment, the envfiles and the YAML files, e.g.
+//
+//	decrypter, err := config.NewAgeDecrypterFromEnv()
+//	...
+//	err = conf.SetupWithOptions(config.WithEncryptedEnvfiles(decrypter, ".env.staging.age"))
+func WithEncryptedEnvfiles(decrypter Decrypter, files ...string) SetupOption {
+	sources := make([]Source, 0, len(files))
+	for _, file := range files {
+		sources = append(sources, &EncryptedFileSource{Path: file, Decrypter: decrypter})
+	}
+	return WithSources(sources...)
+}
+
+// Name implements the Source interface.
+func (s *EncryptedFileSource) Name() string {
+	return "encrypted file " + s.Path
+}
+
+// Load implements the Source interface.
+func (s *EncryptedFileSource) Load(ctx context.Context) (map[string]string, error) {
+	content, err := s.Decrypter.Decrypt(ctx, s.Path)
+	if err != nil {
+		return nil, err
+	}
+	path := s.Path
+	if ext := filepath.Ext(path); ext == ".age" || ext == ".enc" {
+		path = strings.TrimSuffix(path, ext)
+	}
+	values, err := ParseDocument(FormatFromPath(path), content)
+	return values, errors.Wrapf(err, "Failed to parse %s", s.Path)
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"filippo.io/age"
+	"filippo.io/age/armor"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) encryptAge(filename, plaintext string, recipient age.Recipient, armored bool) {
+	out := &bytes.Buffer{}
+	var writer io.Writer = out
+	var armorWriter io.WriteCloser
+	if armored {
+		armorWriter = armor.NewWriter(out)
+		writer = armorWriter
+	}
+	encrypter, err := age.Encrypt(writer, recipient)
+	cts.Require().NoError(err)
+	_, err = encrypter.Write([]byte(plaintext))
+	cts.Require().NoError(err)
+	cts.Require().NoError(encrypter.Close())
+	if armorWriter != nil {
+		cts.Require().NoError(armorWriter.Close())
+	}
+	cts.Require().NoError(ioutil.WriteFile(filename, out.Bytes(), 0600))
+}
+
+func (cts *ConfigTestSuite) TestAgeEncryptedEnvfile() {
+	for _, name := range constants.BasicEnvs {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	identity, err := age.GenerateX25519Identity()
+	cts.Require().NoError(err)
+	dir := cts.T().TempDir()
+	envFile := filepath.Join(dir, ".env.staging.age")
+	cts.encryptAge(envFile, "APP_PORT=9090\n", identity.Recipient(), false)
+	yamlFile := filepath.Join(dir, "config.yaml.age")
+	cts.encryptAge(yamlFile, "app:\n  log_level: warn\n", identity.Recipient(), true)
+
+	cts.setEnvVars(map[string]string{AgeKeyEnv: identity.String()})
+	defer func() {
+		cts.NoError(os.Unsetenv(AgeKeyEnv), "Environment variable should have been unset")
+	}()
+	decrypter, err := NewAgeDecrypterFromEnv()
+	cts.Require().NoError(err)
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupWithOptions(WithEncryptedEnvfiles(decrypter, envFile, yamlFile)))
+	cts.Equal("9090", conf.Port())
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel(), "Armored YAML file should be decrypted")
+	cts.Empty(os.Getenv(constants.APP_PORT), "The decrypted values should not be set in the environment")
+
+	other, err := age.GenerateX25519Identity()
+	cts.Require().NoError(err)
+	wrongDecrypter, err := NewAgeDecrypter(other.String())
+	cts.Require().NoError(err)
+	cts.Error(conf.SetupWithOptions(WithEncryptedEnvfiles(wrongDecrypter, envFile)), "Wrong key should fail")
+
+	cts.NoError(os.Unsetenv(AgeKeyEnv))
+	_, err = NewAgeDecrypterFromEnv()
+	cts.EqualError(err, "Neither SOPS_AGE_KEY nor SOPS_AGE_KEY_FILE is set")
+}
+
+func (cts *ConfigTestSuite) TestSOPSDecrypter() {
+	dir := cts.T().TempDir()
+	binary := filepath.Join(dir, "sops")
+	script := "#!/bin/sh\n[ \"$*\" = \"--decrypt --input-type dotenv --output-type dotenv $6\" ] || { echo \"bad args: $*\" >&2; exit 1; }\necho APP_PORT=9090\n"
+	cts.Require().NoError(ioutil.WriteFile(binary, []byte(script), 0700))
+
+	content, err := (&SOPSDecrypter{Binary: binary}).Decrypt(context.Background(), filepath.Join(dir, ".env.staging"))
+	cts.NoError(err)
+	cts.Equal("APP_PORT=9090\n", string(content))
+
+	_, err = (&SOPSDecrypter{Binary: binary}).Decrypt(context.Background(), filepath.Join(dir, "config.yaml"))
+	cts.Error(err)
+	cts.Contains(err.Error(), "bad args: --decrypt")
+}
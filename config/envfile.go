@@ -0,0 +1,58 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// ValidateFile validates a configuration file (envfile, JSON or YAML by its extension) against the
+// Variables of the AppConfig, without modifying the AppConfig or the environment.
+// The values missing from the file are taken from the defaults (of the file's APP_ENV), the environment is ignored, so the
+// result does not depend on the machine where it runs (e.g. a CI job linting the envfiles). The files of the
+// earlier schema versions are migrated first, see WithSchemaVersion.
+// On invalid values the returned error is the type validation.Errors.
+func (appConf *AppConfig) ValidateFile(filename string) error {
+	values, _, err := appConf.fileValues(filename)
+	if err != nil {
+		return err
+	}
+
+	if errs := appConf.validateValues(values); len(errs) > 0 {
+		return errs.Filter()
+	}
+	return nil
+}
+
+// fileValues loads the configuration file like ValidateFile, and returns the value of every Variable and the
+// values of the file by their (external) names as they are in the file.
+func (appConf *AppConfig) fileValues(filename string) (map[string]string, map[string]string, error) {
+	loaded, err := appConf.readMigratedDocument(filename)
+	if err != nil {
+		return nil, nil, err
+	}
+	// The defaults are computed without caching them, the AppConfig is not modified
+	computed, err := appConf.callDefaultFuncs()
+	if err != nil {
+		return nil, nil, err
+	}
+	values := appConf.defaultValues()
+	for confKey, value := range computed {
+		values[confKey] = value
+	}
+	environment := values[constants.APP_ENV]
+	if val, ok := loaded[appConf.externalName(constants.APP_ENV)]; ok {
+		environment = val
+	}
+	appConf.applyEnvDefaults(values, nil, environment)
+	// The file uses the external names of the Variables
+	external := appConf.externalValues(values)
+	mergeValues(external, loaded)
+	values = appConf.internalValues(external)
+	if err := appConf.expandValues(values); err != nil {
+		return nil, nil, errors.Wrapf(err, "Failed to expand %s", filename)
+	}
+	if err := appConf.deriveValues(values, map[string]string{}); err != nil {
+		return nil, nil, err
+	}
+	return values, loaded, nil
+}
@@ -0,0 +1,28 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestValidateFile() {
+	dir := cts.T().TempDir()
+	valid := filepath.Join(dir, "valid.env")
+	invalid := filepath.Join(dir, "invalid.yaml")
+	cts.NoError(ioutil.WriteFile(valid, []byte("APP_PORT=9090\nAPP_UNKNOWN=1\n"), 0600))
+	cts.NoError(ioutil.WriteFile(invalid, []byte("app:\n  port: not-a-port\n"), 0600))
+
+	cts.NoError(os.Setenv(constants.APP_PORT, "not-a-port"), "Environment variable should have been set")
+	defer func() {
+		cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	}()
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.ValidateFile(valid), "The environment should be ignored")
+	cts.EqualError(conf.ValidateFile(invalid), "APP_PORT = not-a-port: (Valid port: must be a valid port number.).")
+	cts.Error(conf.ValidateFile(filepath.Join(dir, "missing.env")))
+	cts.Empty(conf.Port(), "ValidateFile should not modify the AppConfig")
+}
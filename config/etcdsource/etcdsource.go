@@ -0,0 +1,173 @@
+// Package etcdsource provides a config.WatchableSource which loads the variables from etcd v3, so they can
+// be managed centrally and updated live across a fleet.
+// Use the New or NewFromEnv constructors to create the Source and pass it to config.WithSources
+// Use config.AppConfig.WatchSources to reload the configuration when any key changes, the changes are
+// delivered to the OnChange callbacks and the subscriptions of config.AppConfig.Subscribe
+package etcdsource
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config/internal/poll"
+	"github.com/universal-devs/go-utilities/constants"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// DialTimeout is the timeout of the connection of NewFromEnv.
+const DialTimeout = 5 * time.Second
+
+// retryInterval is the first wait after a failed watch, the next waits double.
+const retryInterval = time.Second
+
+// API is the part of the etcd client used by the Source, e.g. a *clientv3.Client.
+type API interface {
+	Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error)
+	Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan
+}
+
+// Source loads the variables from the keys under a prefix, the name of the variable is the key without
+// the prefix, e.g. /services/billing/APP_PORT is APP_PORT with the /services/billing/ prefix.
+type Source struct {
+	// Prefix is the common prefix of the keys.
+	Prefix string
+
+	client API
+
+	// closer closes the client created by NewFromEnv
+	closer func() error
+
+	// mu guards the revision of the last Load
+	mu       sync.Mutex
+	revision int64
+}
+
+// New creates a new Source which reads the keys with the supplied etcd client.
+func New(client API, prefix string) *Source {
+	return &Source{Prefix: prefix, client: client}
+}
+
+// NewFromEnv creates a new Source connected to the etcd cluster of the environment variables
+// APP_CONFIG_ETCD_ENDPOINTS (comma separated), APP_CONFIG_ETCD_PREFIX, APP_CONFIG_ETCD_USERNAME and
+// APP_CONFIG_ETCD_PASSWORD. The environment is read directly, as the Source is needed before the AppConfig
+// is set up. Close the Source to close the connection.
+func NewFromEnv() (*Source, error) {
+	endpoints := os.Getenv(constants.APP_CONFIG_ETCD_ENDPOINTS)
+	prefix := os.Getenv(constants.APP_CONFIG_ETCD_PREFIX)
+	if endpoints == "" || prefix == "" {
+		return nil, errors.Errorf("Both %s and %s must be set", constants.APP_CONFIG_ETCD_ENDPOINTS, constants.APP_CONFIG_ETCD_PREFIX)
+	}
+	client, err := clientv3.New(clientv3.Config{
+		Endpoints:   strings.Split(endpoints, ","),
+		Username:    os.Getenv(constants.APP_CONFIG_ETCD_USERNAME),
+		Password:    os.Getenv(constants.APP_CONFIG_ETCD_PASSWORD),
+		DialTimeout: DialTimeout,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to connect to etcd")
+	}
+	source := New(client, prefix)
+	source.closer = client.Close
+	return source, nil
+}
+
+// Close closes the connection created by NewFromEnv, the clients passed to New are not closed.
+func (s *Source) Close() error {
+	if s.closer == nil {
+		return nil
+	}
+	return errors.Wrap(s.closer(), "Failed to close the etcd client")
+}
+
+// Name implements the config.Source interface.
+func (s *Source) Name() string {
+	return "etcd " + s.Prefix
+}
+
+// Load implements the config.Source interface.
+func (s *Source) Load(ctx context.Context) (map[string]string, error) {
+	response, err := s.client.Get(ctx, s.Prefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get %s", s.Name())
+	}
+	values := make(map[string]string, len(response.Kvs))
+	for _, kv := range response.Kvs {
+		values[strings.TrimPrefix(string(kv.Key), s.Prefix)] = string(kv.Value)
+	}
+	s.mu.Lock()
+	s.revision = response.Header.GetRevision()
+	s.mu.Unlock()
+	return values, nil
+}
+
+// Watch implements the config.WatchableSource interface, it calls changed whenever any key under the
+// prefix is put or deleted after the last Load. If the watch fails, e.g. its revision was compacted, the error
+// is passed to failed, and after a backoff the keys are listed again (changed is called) and watched from the
+// current revision.
+func (s *Source) Watch(ctx context.Context, changed func(), failed func(err error)) error {
+	s.mu.Lock()
+	revision := s.revision
+	s.mu.Unlock()
+
+	backoff := poll.Backoff{Min: retryInterval}
+	for {
+		err := s.watch(ctx, revision, func() {
+			backoff.Success()
+			changed()
+		})
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		failed(err)
+		for {
+			if !poll.Sleep(ctx, backoff.Failure()) {
+				return ctx.Err()
+			}
+			response, err := s.client.Get(ctx, s.Prefix, clientv3.WithPrefix(), clientv3.WithCountOnly())
+			if err == nil {
+				revision = response.Header.GetRevision()
+				break
+			}
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			failed(errors.Wrapf(err, "Failed to get %s", s.Name()))
+		}
+		// The changes missed during the failure are reloaded, the next ones are watched
+		changed()
+	}
+}
+
+// watch calls changed whenever any key under the prefix changes after revision, the latest one if zero.
+// It returns when ctx is done or the watch fails.
+func (s *Source) watch(ctx context.Context, revision int64, changed func()) error {
+	// The watch is canceled with the context
+	watchCtx, cancel := context.WithCancel(clientv3.WithRequireLeader(ctx))
+	defer cancel()
+
+	opts := []clientv3.OpOption{clientv3.WithPrefix()}
+	if revision > 0 {
+		opts = append(opts, clientv3.WithRev(revision+1))
+	}
+	watchChan := s.client.Watch(watchCtx, s.Prefix, opts...)
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case response, ok := <-watchChan:
+			if !ok {
+				return errors.Errorf("Watch of %s was closed", s.Name())
+			}
+			if err := response.Err(); err != nil {
+				return errors.Wrapf(err, "Failed to watch %s", s.Name())
+			}
+			if len(response.Events) > 0 {
+				changed()
+			}
+		}
+	}
+}
@@ -0,0 +1,157 @@
+package etcdsource
+
+import (
+	"context"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"go.etcd.io/etcd/api/v3/etcdserverpb"
+	"go.etcd.io/etcd/api/v3/mvccpb"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// EtcdSourceSuite extends testify's Suite.
+type EtcdSourceSuite struct {
+	suite.Suite
+}
+
+// fakeEtcd serves the keys from memory
+type fakeEtcd struct {
+	mu        sync.Mutex
+	kvs       map[string]string
+	revision  int64
+	watches   chan clientv3.WatchResponse
+	watchRevs []int64
+}
+
+func (f *fakeEtcd) Get(ctx context.Context, key string, opts ...clientv3.OpOption) (*clientv3.GetResponse, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	response := &clientv3.GetResponse{Header: &etcdserverpb.ResponseHeader{Revision: f.revision}}
+	for k, v := range f.kvs {
+		if strings.HasPrefix(k, key) {
+			response.Kvs = append(response.Kvs, &mvccpb.KeyValue{Key: []byte(k), Value: []byte(v)})
+		}
+	}
+	return response, nil
+}
+
+func (f *fakeEtcd) Watch(ctx context.Context, key string, opts ...clientv3.OpOption) clientv3.WatchChan {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.watchRevs = append(f.watchRevs, clientv3.OpGet(key, opts...).Rev())
+	return f.watches
+}
+
+// put sets the key and notifies the watch
+func (f *fakeEtcd) put(key, value string) {
+	f.mu.Lock()
+	f.kvs[key] = value
+	f.revision++
+	f.mu.Unlock()
+	f.watches <- clientv3.WatchResponse{Events: []*clientv3.Event{{
+		Type: clientv3.EventTypePut,
+		Kv:   &mvccpb.KeyValue{Key: []byte(key), Value: []byte(value)},
+	}}}
+}
+
+func (es *EtcdSourceSuite) TestLoad() {
+	client := &fakeEtcd{kvs: map[string]string{
+		"/services/billing/APP_PORT": "9090",
+		"/services/billing/APP_ENV":  "stage",
+		"/services/other/APP_PORT":   "7070",
+	}}
+	source := New(client, "/services/billing/")
+	es.Equal("etcd /services/billing/", source.Name())
+
+	values, err := source.Load(context.Background())
+	es.NoError(err, "Keys should have been loaded")
+	es.Equal(map[string]string{"APP_PORT": "9090", "APP_ENV": "stage"}, values)
+	es.NoError(source.Close(), "Closing a Source of New should be a no-op")
+}
+
+func (es *EtcdSourceSuite) TestWatch() {
+	client := &fakeEtcd{
+		kvs:     map[string]string{"/billing/APP_PORT": "9090"},
+		watches: make(chan clientv3.WatchResponse),
+	}
+	conf := config.NewConfig(map[string]*config.Variable{
+		constants.APP_PORT: {DefaultValue: "8080"},
+	})
+	es.NoError(os.Unsetenv(constants.APP_PORT))
+	es.NoError(conf.SetupWithOptions(config.WithSources(New(client, "/billing/"))))
+	es.Equal("9090", conf.Port())
+
+	changes := conf.Subscribe(constants.APP_PORT)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.WatchSources(ctx)
+
+	client.put("/billing/APP_PORT", "7070")
+	select {
+	case change := <-changes:
+		es.Equal(config.Change{Name: constants.APP_PORT, OldValue: "9090", NewValue: "7070"}, change)
+	case <-time.After(5 * time.Second):
+		es.Fail("The change should have been delivered")
+	}
+}
+
+func (es *EtcdSourceSuite) TestWatchCompacted() {
+	client := &fakeEtcd{
+		kvs:      map[string]string{"/billing/APP_PORT": "9090"},
+		revision: 3,
+		watches:  make(chan clientv3.WatchResponse),
+	}
+	source := New(client, "/billing/")
+	_, err := source.Load(context.Background())
+	es.NoError(err)
+
+	failures := make(chan error, 1)
+	changes := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = source.Watch(ctx, func() {
+			changes <- struct{}{}
+		}, func(err error) {
+			failures <- err
+		})
+	}()
+
+	client.mu.Lock()
+	client.revision = 12
+	client.mu.Unlock()
+	client.watches <- clientv3.WatchResponse{CompactRevision: 10}
+	select {
+	case err := <-failures:
+		es.EqualError(err, "Failed to watch etcd /billing/: etcdserver: mvcc: required revision has been compacted")
+	case <-time.After(5 * time.Second):
+		es.Fail("The compaction should have been reported")
+	}
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		es.Fail("The keys should have been listed again")
+	}
+	client.put("/billing/APP_PORT", "7070")
+	<-changes
+	client.mu.Lock()
+	defer client.mu.Unlock()
+	es.Equal([]int64{4, 13}, client.watchRevs, "The watch should restart from the revision of the listing")
+}
+
+func (es *EtcdSourceSuite) TestNewFromEnv() {
+	es.NoError(os.Unsetenv(constants.APP_CONFIG_ETCD_ENDPOINTS))
+	_, err := NewFromEnv()
+	es.EqualError(err, "Both APP_CONFIG_ETCD_ENDPOINTS and APP_CONFIG_ETCD_PREFIX must be set")
+}
+
+func TestEtcdSource(t *testing.T) {
+	suite.Run(t, new(EtcdSourceSuite))
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"os"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// expandPattern matches the ${NAME} references in the values.
+var expandPattern = regexp.MustCompile(`\$\{([A-Za-z_][A-Za-z0-9_]*)\}`)
+
+// expandValues replaces the ${NAME} references in values with the (expanded) value of the named Variable,
+// e.g. postgres://${APP_DB_USER}@${APP_DB_HOST}/app. The references use the external names (see WithPrefix).
+// References to unknown names are kept as they are, so values containing a literal "${" (e.g. passwords) only
+// change if they refer to a Variable.
+// The Variables with NoExpand are not expanded, but they can be referenced.
+// It returns an error if the references form a cycle.
+func (appConf *AppConfig) expandValues(values map[string]string) error {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+
+	expanded := map[string]bool{}
+	// path is the chain of the names being expanded, used for the cycle detection
+	path := []string{}
+	var expand func(name string) error
+	expand = func(name string) error {
+		if expanded[name] {
+			return nil
+		}
+		for i, visiting := range path {
+			if visiting == name {
+				return errors.Errorf("Cycle in variable expansion: %s -> %s", strings.Join(path[i:], " -> "), name)
+			}
+		}
+		confVar, ok := appConf.vars[name]
+		if !ok || confVar.NoExpand {
+			expanded[name] = true
+			return nil
+		}
+
+		path = append(path, name)
+		var err error
+		values[name] = expandPattern.ReplaceAllStringFunc(values[name], func(reference string) string {
+			refName := appConf.internalName(expandPattern.FindStringSubmatch(reference)[1])
+			if _, ok := values[refName]; !ok || err != nil {
+				return reference
+			}
+			if err = expand(refName); err != nil {
+				return reference
+			}
+			return values[refName]
+		})
+		path = path[:len(path)-1]
+		expanded[name] = true
+		return err
+	}
+
+	names := []string{}
+	for name := range values {
+		names = append(names, name)
+	}
+	// Sort is needed to report the same cycle on every run
+	sort.Strings(names)
+	for _, name := range names {
+		if err := expand(name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// expandEnv replaces the ${NAME} references in the default value of the Variable with the environment
+// variables, if the Variable has ExpandEnv. The references to the Variables are left to expandValues.
+// The caller must hold the lock.
+func (appConf *AppConfig) expandEnv(confVar *Variable, value string) string {
+	if !confVar.ExpandEnv {
+		return value
+	}
+	return expandPattern.ReplaceAllStringFunc(value, func(reference string) string {
+		name := expandPattern.FindStringSubmatch(reference)[1]
+		if _, ok := appConf.vars[appConf.internalName(name)]; ok {
+			return reference
+		}
+		if val, ok := os.LookupEnv(name); ok {
+			return val
+		}
+		return reference
+	})
+}
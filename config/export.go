@@ -0,0 +1,31 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/pkg/errors"
+)
+
+// AsMap returns the current value of every Variable by name, e.g. to forward the effective configuration
+// to a diagnostics endpoint. The values of the Sensitive Variables are not masked, see AsJSON.
+func (appConf *AppConfig) AsMap() map[string]string {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	return appConf.values()
+}
+
+// AsJSON returns the current value of every Variable as a JSON object by name, e.g. for support bundles.
+// If maskSensitive is true the values of the Sensitive Variables are masked.
+func (appConf *AppConfig) AsJSON(maskSensitive bool) ([]byte, error) {
+	appConf.mu.RLock()
+	values := appConf.values()
+	if maskSensitive {
+		for name, confVar := range appConf.vars {
+			values[name] = confVar.display(confVar.Value)
+		}
+	}
+	appConf.mu.RUnlock()
+
+	content, err := json.Marshal(values)
+	return content, errors.Wrap(err, "Failed to encode configuration as JSON")
+}
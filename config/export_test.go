@@ -0,0 +1,30 @@
+package config
+
+import (
+	"os"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestExport() {
+	cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	conf := NewConfig(map[string]*Variable{
+		"APP_PORT":     {DefaultValue: "8080"},
+		"APP_PASSWORD": {DefaultValue: "s3cr3t", Sensitive: true},
+		"APP_TOKEN":    {Sensitive: true},
+	})
+	cts.NoError(conf.Setup())
+
+	values := conf.AsMap()
+	cts.Equal(map[string]string{"APP_PORT": "8080", "APP_PASSWORD": "s3cr3t", "APP_TOKEN": ""}, values)
+	values["APP_PORT"] = "9090"
+	cts.Equal("8080", conf.Get("APP_PORT"), "Modifying the map should not modify the AppConfig")
+
+	content, err := conf.AsJSON(false)
+	cts.NoError(err)
+	cts.JSONEq(`{"APP_PORT": "8080", "APP_PASSWORD": "s3cr3t", "APP_TOKEN": ""}`, string(content))
+
+	content, err = conf.AsJSON(true)
+	cts.NoError(err)
+	cts.JSONEq(`{"APP_PORT": "8080", "APP_PASSWORD": "*****", "APP_TOKEN": ""}`, string(content), "Sensitive values should be masked")
+}
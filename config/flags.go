@@ -0,0 +1,68 @@
+package config
+
+import (
+	"flag"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FlagName returns the command-line flag name of the named Variable, e.g. APP_PORT is app-port.
+func FlagName(name string) string {
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// variableName returns the Variable name of a command-line flag, the inverse of FlagName.
+func variableName(flagName string) string {
+	return strings.ReplaceAll(strings.ToUpper(flagName), "-", "_")
+}
+
+// RegisterFlags registers a string flag on fs for every Variable (e.g. --app-port for APP_PORT, named after
+// the external name of the Variable, see WithPrefix),
+// with the Variable's description and default value as its usage.
+// Pass the parsed FlagSet to WithFlags, so the flags set on the command-line take precedence over
+// every other location. To use them with spf13/pflag add fs to the pflag.FlagSet with AddGoFlagSet.
+func (appConf *AppConfig) RegisterFlags(fs *flag.FlagSet) {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+
+	names := []string{}
+	for name := range appConf.vars {
+		names = append(names, name)
+	}
+	// Sort is needed because maps always return values in random order
+	sort.Strings(names)
+	for _, name := range names {
+		confVar := appConf.vars[name]
+		name = appConf.externalName(name)
+		usage := fmt.Sprintf("%s (%s)", confVar.Description, name)
+		if defaultValue := confVar.display(confVar.DefaultValue); defaultValue != "" {
+			usage = fmt.Sprintf("%s (%s, default %q)", confVar.Description, name, defaultValue)
+		}
+		// The flag has no default value, so the unset flags don't override the other locations
+		fs.String(FlagName(name), "", usage)
+	}
+}
+
+// WithFlags applies the flags of the parsed fs which were set on the command-line, after every other
+// location, so they take the highest precedence. Register the flags with RegisterFlags before parsing fs.
+func WithFlags(fs *flag.FlagSet) SetupOption {
+	return func(o *setupOptions) {
+		o.flags = fs
+	}
+}
+
+// loadFlags sets values and their origins from the flags of fs which were set on the command-line.
+func loadFlags(values, origins map[string]string, fs *flag.FlagSet) {
+	if fs == nil {
+		return
+	}
+	// Visit only iterates over the flags that have been set
+	fs.Visit(func(f *flag.Flag) {
+		name := variableName(f.Name)
+		if _, ok := values[name]; ok {
+			values[name] = f.Value.String()
+			origins[name] = "flag --" + f.Name
+		}
+	})
+}
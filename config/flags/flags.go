@@ -0,0 +1,169 @@
+// Package flags provides feature flags on top of the AppConfig. The flags are declared like the Variables,
+// stored as APP_FLAG_<NAME> Variables (so they are loaded, validated and documented the same way), and can
+// be toggled at runtime. A flag is either on or off (true, false, 1, 0, ...) or enabled for a percentage of
+// the keys (e.g. 25%), for gradual rollouts:
+//
+//	features, err := flags.New(conf, map[string]*flags.Flag{
+//	    "NEW_CHECKOUT": {
+//	        Description:   "The new checkout flow",
+//	        Default:       "10%",
+//	        DefaultsByEnv: map[string]string{constants.ENV_DEV: "on"},
+//	    },
+//	})
+//	...
+//	if features.IsEnabled("NEW_CHECKOUT", user.ID) {
+package flags
+
+import (
+	"hash/fnv"
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+const (
+	// VariablePrefix is the prefix of the names of the flag Variables.
+	VariablePrefix = "APP_FLAG_"
+
+	// Group is the Group of the flag Variables in the documentation.
+	Group = "Feature flags"
+)
+
+// ErrInvalidFlag is the validation error of the invalid flag values.
+var ErrInvalidFlag = validation.NewError("validation_feature_flag", "must be on, off or a percentage between 0% and 100%")
+
+// Flag is the declaration of a feature flag.
+type Flag struct {
+	// Description is the brief description of the flag.
+	Description string
+
+	// Default is the default value of the flag, off if empty.
+	Default string
+
+	// DefaultsByEnv are the default values by environment (APP_ENV), they take precedence over Default.
+	DefaultsByEnv map[string]string
+}
+
+// Flags are the feature flags registered on an AppConfig.
+type Flags struct {
+	conf *config.AppConfig
+}
+
+// New registers the flags as Variables on the AppConfig, it must be called before the Setup.
+func New(conf *config.AppConfig, flags map[string]*Flag) (*Flags, error) {
+	for name, flag := range flags {
+		defaultValue := flag.Default
+		if defaultValue == "" {
+			defaultValue = "off"
+		}
+		err := conf.AddVariable(VariableName(name), &config.Variable{
+			DefaultValue:  defaultValue,
+			DefaultsByEnv: flag.DefaultsByEnv,
+			Description:   flag.Description,
+			Group:         Group,
+			Rules: map[string]validation.Rule{
+				"Valid flag": validation.By(validateFlag),
+			},
+		})
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to register flag %s", name)
+		}
+	}
+	return &Flags{conf: conf}, nil
+}
+
+// VariableName returns the name of the Variable of the named flag.
+func VariableName(name string) string {
+	return VariablePrefix + name
+}
+
+// IsEnabled returns whether the named flag is enabled for key (e.g. a user or tenant ID). The percentage
+// flags are enabled for the keys whose hash falls below the percentage, so a key keeps its state, and
+// raising the percentage only enables more keys. Unknown flags are disabled.
+func (f *Flags) IsEnabled(name, key string) bool {
+	percentage := f.Percentage(name)
+	switch {
+	case percentage <= 0:
+		return false
+	case percentage >= 100:
+		return true
+	}
+	// The name is hashed too, so the rollouts of the flags enable different keys
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(name + ":" + key))
+	return float64(hash.Sum32()%10000) < percentage*100
+}
+
+// Percentage returns the percentage of the keys the named flag is enabled for, 0 or 100 for the on/off
+// flags, and 0 for the unknown flags.
+func (f *Flags) Percentage(name string) float64 {
+	percentage, err := parseFlag(f.conf.Get(VariableName(name)))
+	if err != nil {
+		return 0
+	}
+	return percentage
+}
+
+// Enable turns the named flag on at runtime.
+func (f *Flags) Enable(name string) error {
+	return f.Set(name, "on")
+}
+
+// Disable turns the named flag off at runtime.
+func (f *Flags) Disable(name string) error {
+	return f.Set(name, "off")
+}
+
+// SetPercentage enables the named flag for the percentage of the keys at runtime.
+func (f *Flags) SetPercentage(name string, percentage float64) error {
+	return f.Set(name, strconv.FormatFloat(percentage, 'f', -1, 64)+"%")
+}
+
+// Set sets the value of the named flag at runtime (on, off or a percentage), like AppConfig.Set.
+func (f *Flags) Set(name, value string) error {
+	if _, ok := f.conf.Lookup(VariableName(name)); !ok {
+		return errors.Errorf("Unknown feature flag %s", name)
+	}
+	return f.conf.Set(VariableName(name), value)
+}
+
+// validateFlag is the validation rule of the flag Variables.
+func validateFlag(value interface{}) error {
+	str, err := validation.EnsureString(value)
+	if err != nil {
+		return err
+	}
+	if _, err := parseFlag(str); err != nil {
+		return ErrInvalidFlag
+	}
+	return nil
+}
+
+// parseFlag returns the percentage of a flag value: on is 100, off is 0.
+func parseFlag(value string) (float64, error) {
+	value = strings.TrimSpace(value)
+	switch strings.ToLower(value) {
+	case "on", "yes", "enabled":
+		return 100, nil
+	case "off", "no", "disabled", "":
+		return 0, nil
+	}
+	if strings.HasSuffix(value, "%") {
+		percentage, err := strconv.ParseFloat(strings.TrimSuffix(value, "%"), 64)
+		if err != nil || percentage < 0 || percentage > 100 {
+			return 0, errors.Errorf("Invalid percentage %q", value)
+		}
+		return percentage, nil
+	}
+	enabled, err := strconv.ParseBool(value)
+	if err != nil {
+		return 0, err
+	}
+	if enabled {
+		return 100, nil
+	}
+	return 0, nil
+}
@@ -0,0 +1,78 @@
+package flags
+
+import (
+	"fmt"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// FlagsSuite extends testify's Suite.
+type FlagsSuite struct {
+	suite.Suite
+	conf     *config.AppConfig
+	features *Flags
+}
+
+func (fs *FlagsSuite) SetupTest() {
+	fs.NoError(os.Unsetenv(constants.APP_ENV))
+	fs.conf = config.NewConfig(map[string]*config.Variable{
+		constants.APP_ENV: {DefaultValue: constants.ENV_TEST},
+	})
+	var err error
+	fs.features, err = New(fs.conf, map[string]*Flag{
+		"NEW_CHECKOUT": {Description: "The new checkout flow", Default: "25%"},
+		"DARK_MODE":    {DefaultsByEnv: map[string]string{constants.ENV_TEST: "on"}},
+		"BETA":         {},
+	})
+	fs.Require().NoError(err)
+	fs.Require().NoError(fs.conf.Setup())
+}
+
+func (fs *FlagsSuite) TestDefaults() {
+	fs.Equal(25.0, fs.features.Percentage("NEW_CHECKOUT"))
+	fs.True(fs.features.IsEnabled("DARK_MODE", "user-1"), "The default of the environment should be applied")
+	fs.False(fs.features.IsEnabled("BETA", "user-1"), "Flags should be off by default")
+	fs.False(fs.features.IsEnabled("UNKNOWN", "user-1"), "Unknown flags should be disabled")
+	fs.Contains(fs.conf.DumpTable(), "APP_FLAG_NEW_CHECKOUT")
+
+	_, err := New(fs.conf, map[string]*Flag{"BETA": {}})
+	fs.EqualError(err, "Failed to register flag BETA: Configuration variable APP_FLAG_BETA is already registered")
+}
+
+func (fs *FlagsSuite) TestPercentage() {
+	enabled := map[string]bool{}
+	for i := 0; i < 10000; i++ {
+		key := fmt.Sprintf("user-%d", i)
+		if fs.features.IsEnabled("NEW_CHECKOUT", key) {
+			enabled[key] = true
+		}
+		fs.Equal(enabled[key], fs.features.IsEnabled("NEW_CHECKOUT", key), "The result should be consistent")
+	}
+	fs.InDelta(2500, len(enabled), 200, "About 25% of the keys should be enabled")
+
+	fs.NoError(fs.features.SetPercentage("NEW_CHECKOUT", 50))
+	for key := range enabled {
+		fs.True(fs.features.IsEnabled("NEW_CHECKOUT", key), "Raising the percentage should keep the enabled keys")
+	}
+}
+
+func (fs *FlagsSuite) TestToggle() {
+	fs.NoError(fs.features.Enable("BETA"))
+	fs.True(fs.features.IsEnabled("BETA", "user-1"))
+	fs.NoError(fs.features.Disable("BETA"))
+	fs.False(fs.features.IsEnabled("BETA", "user-1"))
+	fs.NoError(fs.features.Set("BETA", "true"))
+	fs.Equal(100.0, fs.features.Percentage("BETA"))
+
+	fs.EqualError(fs.features.Set("BETA", "150%"), "Invalid value for APP_FLAG_BETA = 150%: Valid flag: must be on, off or a percentage between 0% and 100%.")
+	fs.EqualError(fs.features.Set("UNKNOWN", "on"), "Unknown feature flag UNKNOWN")
+	fs.Equal(100.0, fs.features.Percentage("BETA"), "Invalid values should be rejected")
+}
+
+func TestFlags(t *testing.T) {
+	suite.Run(t, new(FlagsSuite))
+}
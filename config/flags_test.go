@@ -0,0 +1,44 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestFlags() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer func(fileName string) {
+		cts.NoErrorf(os.Remove(fileName), "Temp envfile (%s) should have been removed", fileName)
+	}(envFile)
+	cts.writeEnvfile(envFile, map[string]string{
+		constants.APP_PORT:      "9090",
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN,
+	})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	conf.RegisterFlags(fs)
+
+	portFlag := fs.Lookup("app-port")
+	cts.Require().NotNil(portFlag, "APP_PORT should be registered as --app-port")
+	cts.Equal(`TCP/IP Port where the application listens (APP_PORT, default "8080")`, portFlag.Usage)
+	cts.Empty(portFlag.DefValue, "Flags should not have defaults")
+
+	cts.NoError(fs.Parse([]string{"--app-port", "7070"}))
+	cts.NoError(conf.SetupWithOptions(WithEnvfiles(envFile), WithFlags(fs)))
+	cts.Equal("7070", conf.Port(), "Flag should take precedence over the envfile")
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel(), "Unset flags should not override the envfile")
+
+	cts.NoError(conf.Reload())
+	cts.Equal("7070", conf.Port(), "Flag should be kept by Reload")
+
+	fs = flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	conf.RegisterFlags(fs)
+	cts.NoError(fs.Parse([]string{"--app-port=not-a-port"}))
+	cts.Error(conf.SetupWithOptions(WithFlags(fs)), "Flag values should be validated")
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// handlerRow is the effective value of a single Variable rendered by the Handler.
+type handlerRow struct {
+	Name        string `json:"name"`
+	Value       string `json:"value"`
+	Source      string `json:"source"`
+	Description string `json:"description"`
+	Group       string `json:"group,omitempty"`
+	Sensitive   bool   `json:"sensitive"`
+}
+
+// handlerTemplate renders the handlerRows as an HTML page.
+var handlerTemplate = template.Must(template.New("config").Parse(`<!DOCTYPE html>
+<html>
+<head><meta charset="utf-8"><title>Configuration</title></head>
+<body>
+<h1>Configuration</h1>
+<table>
+<tr><th>Variable Name</th><th>Value</th><th>Source</th><th>Description</th></tr>
+{{range .}}<tr><td>{{.Name}}</td><td><code>{{.Value}}</code></td><td>{{.Source}}</td><td>{{.Description}}</td></tr>
+{{end}}</table>
+</body>
+</html>
+`))
+
+// Handler returns an http.Handler which renders the effective configuration: the current value and the
+// origin (see Source) of every Variable, the values of the Sensitive Variables are masked.
+// The response is JSON if the format query parameter is json or the request accepts application/json,
+// otherwise HTML. It is meant for an internal admin port, e.g. mux.Handle("/config", conf.Handler()).
+func (appConf *AppConfig) Handler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		rows := appConf.handlerRows()
+		if r.URL.Query().Get("format") == "json" || strings.Contains(r.Header.Get("Accept"), "application/json") {
+			w.Header().Set("Content-Type", "application/json")
+			// The response is already started, an encoding error cannot be reported to the client
+			_ = json.NewEncoder(w).Encode(rows)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_ = handlerTemplate.Execute(w, rows)
+	})
+}
+
+// handlerRows collects the effective values of the Variables in alphabetic order.
+func (appConf *AppConfig) handlerRows() []handlerRow {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+
+	rows := make([]handlerRow, 0, len(appConf.vars))
+	for name, confVar := range appConf.vars {
+		rows = append(rows, handlerRow{
+			Name:        appConf.externalName(name),
+			Value:       confVar.display(confVar.Value),
+			Source:      confVar.origin,
+			Description: confVar.Description,
+			Group:       confVar.Group,
+			Sensitive:   confVar.Sensitive,
+		})
+	}
+	// Sort is needed because maps always return values in random order
+	sort.Slice(rows, func(i, j int) bool {
+		return rows[i].Name < rows[j].Name
+	})
+	return rows
+}
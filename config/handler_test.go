@@ -0,0 +1,57 @@
+package config
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestHandler() {
+	cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	conf := NewConfig(map[string]*Variable{
+		"APP_PORT":     {DefaultValue: "8080", Description: "TCP/IP Port"},
+		"APP_PASSWORD": {DefaultValue: "<s3cr3t>", Sensitive: true},
+		"APP_GREETING": {DefaultValue: "<b>hello</b>"},
+	})
+	cts.NoError(conf.Setup())
+	cts.NoError(conf.Set("APP_PORT", "9090"))
+	handler := conf.Handler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/config?format=json", nil))
+	cts.Equal(http.StatusOK, recorder.Code)
+	cts.Equal("application/json", recorder.Header().Get("Content-Type"))
+	rows := []map[string]interface{}{}
+	cts.NoError(json.Unmarshal(recorder.Body.Bytes(), &rows), "Response should be valid JSON")
+	cts.Len(rows, 3)
+	cts.Equal("APP_PASSWORD", rows[1]["name"])
+	cts.Equal(MaskedValue, rows[1]["value"], "Sensitive values should be masked")
+	cts.Equal(map[string]interface{}{
+		"name":        "APP_PORT",
+		"value":       "9090",
+		"source":      OriginRuntime,
+		"description": "TCP/IP Port",
+		"sensitive":   false,
+	}, rows[2])
+
+	request := httptest.NewRequest(http.MethodGet, "/config", nil)
+	request.Header.Set("Accept", "application/json")
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	cts.Equal("application/json", recorder.Header().Get("Content-Type"), "Accept header should select JSON")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/config", nil))
+	cts.Equal("text/html; charset=utf-8", recorder.Header().Get("Content-Type"))
+	body := recorder.Body.String()
+	cts.Contains(body, "<tr><td>APP_PORT</td><td><code>9090</code></td><td>runtime</td><td>TCP/IP Port</td></tr>")
+	cts.Contains(body, "&lt;b&gt;hello&lt;/b&gt;", "Values should be escaped")
+	cts.NotContains(body, "s3cr3t", "Sensitive values should be masked")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/config", nil))
+	cts.Equal(http.StatusMethodNotAllowed, recorder.Code)
+}
@@ -0,0 +1,186 @@
+// Package httpsource provides a config.WatchableSource which loads an envfile, JSON or YAML document from
+// an HTTP(S) endpoint.
+// Use the New or NewFromEnv constructors to create the Source and pass it to config.WithSources
+// Use config.AppConfig.WatchSources to poll the endpoint and reload the configuration when the document changes
+package httpsource
+
+import (
+	"context"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/config/internal/poll"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// DefaultPollInterval is the interval of the polls if no interval is set.
+const DefaultPollInterval = time.Minute
+
+// DefaultTimeout is the timeout of the requests of the default client.
+const DefaultTimeout = 30 * time.Second
+
+// Source loads the variables from a document served over HTTP(S). The document is only downloaded again
+// if its ETag has changed (If-None-Match). If the endpoint fails after a successful Load, the last document
+// is used, so a temporary outage does not fail the Reloads; the failures are passed to the ErrorHandler.
+// The AppConfig validates the values before applying them, and keeps the last valid values otherwise.
+type Source struct {
+	// URL is the address of the document.
+	URL string
+
+	// Format is the format of the document, detected from the Content-Type or the URL's extension if empty.
+	Format config.Format
+
+	// Header is added to the requests, e.g. an Authorization header.
+	Header http.Header
+
+	// PollInterval is the interval of the polls of Watch, DefaultPollInterval if zero.
+	PollInterval time.Duration
+
+	// ErrorHandler is called with the failures of Load hidden by the fallback to the last document, if set.
+	ErrorHandler func(err error)
+
+	client *http.Client
+
+	// mu guards the cached etag and values
+	mu     sync.Mutex
+	etag   string
+	values map[string]string
+}
+
+// New creates a new Source which reads the document with the supplied HTTP client, a client with
+// DefaultTimeout if nil.
+func New(client *http.Client, url string) *Source {
+	if client == nil {
+		client = &http.Client{Timeout: DefaultTimeout}
+	}
+	return &Source{URL: url, client: client}
+}
+
+// NewFromEnv creates a new Source with the environment variables APP_CONFIG_HTTP_URL and
+// APP_CONFIG_HTTP_POLL_INTERVAL. The environment is read directly, as the Source is needed before the
+// AppConfig is set up.
+func NewFromEnv() (*Source, error) {
+	endpoint := os.Getenv(constants.APP_CONFIG_HTTP_URL)
+	if endpoint == "" {
+		return nil, errors.Errorf("%s must be set", constants.APP_CONFIG_HTTP_URL)
+	}
+	source := New(nil, endpoint)
+	if interval, err := time.ParseDuration(os.Getenv(constants.APP_CONFIG_HTTP_POLL_INTERVAL)); err == nil {
+		source.PollInterval = interval
+	}
+	return source, nil
+}
+
+// Name implements the config.Source interface.
+func (s *Source) Name() string {
+	return s.URL
+}
+
+// Load implements the config.Source interface.
+func (s *Source) Load(ctx context.Context) (map[string]string, error) {
+	_, err := s.fetch(ctx)
+	s.mu.Lock()
+	values := s.values
+	s.mu.Unlock()
+	if err != nil {
+		if values == nil {
+			return nil, err
+		}
+		s.handleError(err)
+	}
+	return values, nil
+}
+
+// Watch implements the config.WatchableSource interface, it polls the document and calls changed if its
+// ETag differs from the one of the last Load. The failed polls are passed to failed, and the next poll waits
+// with a backoff.
+func (s *Source) Watch(ctx context.Context, changed func(), failed func(err error)) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	poll.Poll(ctx, interval, func() error {
+		// The fetched document is cached, so the Load of the Reload is served by a 304 response
+		modified, err := s.fetch(ctx)
+		if modified {
+			changed()
+		}
+		return err
+	}, failed)
+	return ctx.Err()
+}
+
+// fetch downloads the document if it is modified, and caches it. It returns whether it was modified.
+func (s *Source) fetch(ctx context.Context) (bool, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, s.URL, nil)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to create request of %s", s.Name())
+	}
+	for name, values := range s.Header {
+		request.Header[name] = values
+	}
+	s.mu.Lock()
+	etag := s.etag
+	s.mu.Unlock()
+	if etag != "" {
+		request.Header.Set("If-None-Match", etag)
+	}
+
+	response, err := s.client.Do(request)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to get %s", s.Name())
+	}
+	defer response.Body.Close()
+	if response.StatusCode == http.StatusNotModified {
+		return false, nil
+	}
+	if response.StatusCode != http.StatusOK {
+		return false, errors.Errorf("Failed to get %s: %s", s.Name(), response.Status)
+	}
+
+	content, err := ioutil.ReadAll(response.Body)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to read %s", s.Name())
+	}
+	values, err := config.ParseDocument(s.format(response.Header.Get("Content-Type")), content)
+	if err != nil {
+		return false, errors.Wrapf(err, "Failed to parse %s", s.Name())
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.etag = response.Header.Get("ETag")
+	s.values = values
+	return true, nil
+}
+
+// format returns the Format of the document by the Format field, the Content-Type or the URL.
+func (s *Source) format(contentType string) config.Format {
+	if s.Format != "" {
+		return s.Format
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	switch mediaType {
+	case "application/json":
+		return config.FormatJSON
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return config.FormatYAML
+	}
+	if parsed, err := url.Parse(s.URL); err == nil {
+		return config.FormatFromPath(parsed.Path)
+	}
+	return config.FormatEnv
+}
+
+// handleError passes err to the ErrorHandler, if set.
+func (s *Source) handleError(err error) {
+	if s.ErrorHandler != nil {
+		s.ErrorHandler(err)
+	}
+}
@@ -0,0 +1,146 @@
+package httpsource
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// HTTPSourceSuite extends testify's Suite.
+type HTTPSourceSuite struct {
+	suite.Suite
+}
+
+// fakeServer serves a single document from memory
+type fakeServer struct {
+	mu          sync.Mutex
+	content     string
+	contentType string
+	etag        string
+	status      int
+	gets        int
+	downloads   int
+}
+
+func (f *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+	if r.Header.Get("If-None-Match") == f.etag {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	f.downloads++
+	w.Header().Set("ETag", f.etag)
+	w.Header().Set("Content-Type", f.contentType)
+	_, _ = w.Write([]byte(f.content))
+}
+
+func (f *fakeServer) set(content, etag string, status int) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.content, f.etag, f.status, f.contentType = content, etag, status, ""
+}
+
+func (hs *HTTPSourceSuite) TestLoad() {
+	server := &fakeServer{content: `{"app": {"port": 9090}}`, contentType: "application/json", etag: `"v1"`}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	errs := []error{}
+	source := New(nil, httpServer.URL+"/config")
+	source.ErrorHandler = func(err error) { errs = append(errs, err) }
+	hs.Equal(DefaultTimeout, source.client.Timeout, "The default client should time out")
+	values, err := source.Load(context.Background())
+	hs.NoError(err, "Document should have been loaded")
+	hs.Equal(map[string]string{"APP_PORT": "9090"}, values)
+
+	values, err = source.Load(context.Background())
+	hs.NoError(err, "Unmodified document should be served from the cache")
+	hs.Equal("9090", values["APP_PORT"])
+	hs.Equal(1, server.downloads)
+
+	server.set("", `"v2"`, http.StatusInternalServerError)
+	values, err = source.Load(context.Background())
+	hs.NoError(err, "The last document should be used on failure")
+	hs.Equal("9090", values["APP_PORT"])
+	hs.Len(errs, 1, "The failure should be passed to the ErrorHandler")
+
+	_, err = New(nil, httpServer.URL+"/config.env").Load(context.Background())
+	hs.EqualError(err, "Failed to get "+httpServer.URL+"/config.env: 500 Internal Server Error")
+
+	server.set("APP_PORT=7070\n", `"v3"`, 0)
+	values, err = New(nil, httpServer.URL+"/config.env").Load(context.Background())
+	hs.NoError(err, "Envfile should be detected by the URL")
+	hs.Equal("7070", values["APP_PORT"])
+}
+
+func (hs *HTTPSourceSuite) TestWatch() {
+	server := &fakeServer{content: "APP_PORT=9090\n", etag: `"v1"`}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	conf := config.NewConfig(map[string]*config.Variable{
+		constants.APP_PORT: {
+			DefaultValue: "8080",
+			Rules: map[string]validation.Rule{
+				"Valid port": is.Port,
+			},
+		},
+	})
+	hs.NoError(os.Unsetenv(constants.APP_PORT))
+	source := New(nil, httpServer.URL)
+	source.PollInterval = 10 * time.Millisecond
+	hs.NoError(conf.SetupWithOptions(config.WithSources(source)))
+	hs.Equal("9090", conf.Port())
+
+	changes := conf.Subscribe(constants.APP_PORT)
+	reloadErrors := make(chan error, 10)
+	conf.OnReloadError(func(err error) { reloadErrors <- err })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.WatchSources(ctx)
+
+	server.set("APP_PORT=7070\n", `"v2"`, 0)
+	select {
+	case change := <-changes:
+		hs.Equal(config.Change{Name: constants.APP_PORT, OldValue: "9090", NewValue: "7070"}, change)
+	case <-time.After(5 * time.Second):
+		hs.Fail("The change should have been delivered")
+	}
+
+	server.set("APP_PORT=not-a-port\n", `"v3"`, 0)
+	select {
+	case <-reloadErrors:
+	case <-time.After(5 * time.Second):
+		hs.Fail("The invalid document should fail the Reload")
+	}
+	hs.Equal("7070", conf.Port(), "The last valid value should be kept")
+	server.mu.Lock()
+	hs.Equal(3, server.downloads, "Every version should be downloaded once")
+	server.mu.Unlock()
+}
+
+func (hs *HTTPSourceSuite) TestNewFromEnv() {
+	hs.NoError(os.Unsetenv(constants.APP_CONFIG_HTTP_URL))
+	_, err := NewFromEnv()
+	hs.EqualError(err, "APP_CONFIG_HTTP_URL must be set")
+}
+
+func TestHTTPSource(t *testing.T) {
+	suite.Run(t, new(HTTPSourceSuite))
+}
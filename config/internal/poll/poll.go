@@ -0,0 +1,73 @@
+// Package poll provides the polling with backoff shared by the watchable Sources of the config packages, e.g. the
+// S3 and the HTTP sources.
+package poll
+
+import (
+	"context"
+	"time"
+)
+
+// MaxBackoff is the longest wait after the failed attempts, unless the poll interval is longer.
+const MaxBackoff = 5 * time.Minute
+
+// Backoff is the wait between the attempts of a watch, it starts at Min and doubles after every failure, up to
+// MaxBackoff or Min if it is longer. The zero value waits MaxBackoff.
+type Backoff struct {
+	// Min is the wait after a success, and the base of the waits after the failures.
+	Min time.Duration
+
+	wait time.Duration
+}
+
+// Failure returns the wait after one more failed attempt.
+func (b *Backoff) Failure() time.Duration {
+	max := MaxBackoff
+	if b.Min > max {
+		max = b.Min
+	}
+	if b.wait == 0 {
+		b.wait = b.Min
+	} else {
+		b.wait *= 2
+	}
+	if b.wait <= 0 || b.wait > max {
+		b.wait = max
+	}
+	return b.wait
+}
+
+// Success resets the backoff and returns Min.
+func (b *Backoff) Success() time.Duration {
+	b.wait = 0
+	return b.Min
+}
+
+// Sleep waits for d, it returns false if ctx is done first.
+func Sleep(ctx context.Context, d time.Duration) bool {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+// Poll calls poll every interval until ctx is done. The errors of poll are passed to failed, and the next poll
+// waits with a Backoff, so an unavailable backend is not hammered.
+func Poll(ctx context.Context, interval time.Duration, poll func() error, failed func(err error)) {
+	backoff := Backoff{Min: interval}
+	wait := interval
+	for Sleep(ctx, wait) {
+		if err := poll(); err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			failed(err)
+			wait = backoff.Failure()
+			continue
+		}
+		wait = backoff.Success()
+	}
+}
@@ -0,0 +1,61 @@
+package config
+
+import (
+	"os"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// Layer is an envfile of a layered Setup, e.g. the base envfile, the envfile of the environment and the local overrides:
+//
+//	conf.SetupLayered([]config.Layer{
+//	    {Path: ".env", Priority: 0},
+//	    {Path: ".env.production", Priority: 10, Optional: true},
+//	    {Path: ".env.local", Priority: 20, Optional: true},
+//	})
+type Layer struct {
+	// Path is the path of the envfile.
+	Path string
+
+	// Priority orders the Layers, the Layers with higher priority take precedence.
+	// The Layers with the same priority take precedence in the order they are supplied.
+	Priority int
+
+	// Optional Layers are skipped if the envfile does not exist, a missing required Layer fails the Setup.
+	Optional bool
+}
+
+// WithLayers loads the envfiles of the Layers in the order of their priority, after the envfiles of WithEnvfiles.
+// The existence of the Optional Layers is checked on every Setup and Reload, so a Layer created later is picked up.
+func WithLayers(layers ...Layer) SetupOption {
+	return func(o *setupOptions) {
+		o.layers = append(o.layers, layers...)
+	}
+}
+
+// SetupLayered sets up the Application's Configuration like SetupWithOptions, with the envfiles of the Layers,
+// see WithLayers.
+func (appConf *AppConfig) SetupLayered(layers []Layer, opts ...SetupOption) error {
+	return appConf.SetupWithOptions(append(opts, WithLayers(layers...))...)
+}
+
+// layerFiles returns the existing envfiles of the Layers, from the lowest to the highest priority.
+func (o *setupOptions) layerFiles() ([]string, error) {
+	layers := append([]Layer{}, o.layers...)
+	sort.SliceStable(layers, func(i, j int) bool {
+		return layers[i].Priority < layers[j].Priority
+	})
+
+	files := []string{}
+	for _, layer := range layers {
+		if _, err := os.Stat(layer.Path); err != nil {
+			if layer.Optional && os.IsNotExist(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "Failed to load layer %s", layer.Path)
+		}
+		files = append(files, layer.Path)
+	}
+	return files, nil
+}
@@ -0,0 +1,49 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSetupLayered() {
+	unsetEnv := func() {
+		for _, name := range constants.BasicEnvs {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+	dir := cts.T().TempDir()
+	base := filepath.Join(dir, ".env")
+	production := filepath.Join(dir, ".env.production")
+	local := filepath.Join(dir, ".env.local")
+	cts.writeEnvfile(base, map[string]string{
+		constants.APP_PORT:      "9090",
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN,
+	})
+	cts.writeEnvfile(production, map[string]string{
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_ERROR,
+		constants.APP_PORT:      "7070",
+	})
+	layers := []Layer{
+		{Path: local, Priority: 20, Optional: true},
+		{Path: production, Priority: 10},
+		{Path: base},
+	}
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupLayered(layers), "Missing optional layer should be skipped")
+	cts.Equal("7070", conf.Port(), "Higher priority layer should take precedence")
+	cts.Equal(constants.LOG_LEVEL_ERROR, conf.LogLevel())
+	cts.Equal("envfile "+production, conf.Source(constants.APP_PORT))
+
+	cts.writeEnvfile(local, map[string]string{constants.APP_PORT: "6060"})
+	cts.NoError(conf.Reload())
+	cts.Equal("6060", conf.Port(), "Optional layer created later should be picked up by Reload")
+	cts.Equal(constants.LOG_LEVEL_ERROR, conf.LogLevel())
+
+	cts.NoError(os.Remove(production))
+	cts.Contains(conf.SetupLayered(layers).Error(), "Failed to load layer "+production, "Missing required layer should fail the Setup")
+}
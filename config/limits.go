@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var (
+	// ErrNumber is the error of the limits of the Values which are not numbers.
+	ErrNumber = validation.NewError("validation_config_number", "must be a number")
+)
+
+// Limit returns a pointer to value, for the Min and Max of a Variable.
+func Limit(value float64) *float64 {
+	return &value
+}
+
+// limitRules returns the validation.Rules of the Min, Max, MinLen and MaxLen limits, named after the limits.
+func (confVar *Variable) limitRules() map[string]validation.Rule {
+	rules := map[string]validation.Rule{}
+	if confVar.Min != nil {
+		rules["Min "+formatLimit(*confVar.Min)] = numberRule(func(number float64) error {
+			if number < *confVar.Min {
+				return validation.NewError("validation_config_min", "must be no less than "+formatLimit(*confVar.Min))
+			}
+			return nil
+		})
+	}
+	if confVar.Max != nil {
+		rules["Max "+formatLimit(*confVar.Max)] = numberRule(func(number float64) error {
+			if number > *confVar.Max {
+				return validation.NewError("validation_config_max", "must be no greater than "+formatLimit(*confVar.Max))
+			}
+			return nil
+		})
+	}
+	if confVar.MinLen > 0 {
+		rules[fmt.Sprintf("Min length %d", confVar.MinLen)] = validation.RuneLength(confVar.MinLen, 0)
+	}
+	if confVar.MaxLen > 0 {
+		rules[fmt.Sprintf("Max length %d", confVar.MaxLen)] = validation.RuneLength(0, confVar.MaxLen)
+	}
+	return rules
+}
+
+// numberRule creates a rule which parses the non-empty values as numbers and checks them with check. NaN is not a
+// number, it would pass every limit.
+func numberRule(check func(number float64) error) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		str, err := validation.EnsureString(value)
+		if err != nil {
+			return err
+		}
+		if str == "" {
+			return nil
+		}
+		number, err := strconv.ParseFloat(str, 64)
+		if err != nil || math.IsNaN(number) {
+			return ErrNumber
+		}
+		return check(number)
+	})
+}
+
+// formatLimit formats a limit without the needless decimals, e.g. 1 and 0.5.
+func formatLimit(limit float64) string {
+	return strconv.FormatFloat(limit, 'f', -1, 64)
+}
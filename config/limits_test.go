@@ -0,0 +1,83 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+)
+
+func (cts *ConfigTestSuite) TestLimits() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_WORKERS": {
+			DefaultValue: "4",
+			Description:  "Number of workers",
+			Min:          Limit(1),
+			Max:          Limit(64),
+		},
+		"APP_RATIO": {Max: Limit(0.5)},
+		"APP_PASSWORD": {
+			MinLen:    8,
+			MaxLen:    16,
+			Sensitive: true,
+		},
+	})
+	conf.Override(conf.defaultValues())
+	cts.NoError(conf.Validate(), "Empty values should not be checked")
+
+	testCases := map[string]struct {
+		name  string
+		value string
+		err   string
+	}{
+		"below min": {
+			name:  "APP_WORKERS",
+			value: "0",
+			err:   "Invalid value for APP_WORKERS = 0: Min 1: must be no less than 1.",
+		},
+		"above max": {
+			name:  "APP_WORKERS",
+			value: "65",
+			err:   "Invalid value for APP_WORKERS = 65: Max 64: must be no greater than 64.",
+		},
+		"not a number": {
+			name:  "APP_WORKERS",
+			value: "four",
+			err:   "Invalid value for APP_WORKERS = four: Max 64: must be a number; Min 1: must be a number.",
+		},
+		"NaN": {
+			name:  "APP_WORKERS",
+			value: "NaN",
+			err:   "Invalid value for APP_WORKERS = NaN: Max 64: must be a number; Min 1: must be a number.",
+		},
+		"fraction above max": {
+			name:  "APP_RATIO",
+			value: "0.75",
+			err:   "Invalid value for APP_RATIO = 0.75: Max 0.5: must be no greater than 0.5.",
+		},
+		"too short": {
+			name:  "APP_PASSWORD",
+			value: "s3cr3t",
+			err:   "Invalid value for APP_PASSWORD = *****: Min length 8: the length must be no less than 8.",
+		},
+		"too long": {
+			name:  "APP_PASSWORD",
+			value: "correct horse battery staple",
+			err:   "Invalid value for APP_PASSWORD = *****: Max length 16: the length must be no more than 16.",
+		},
+	}
+	for name, tc := range testCases {
+		cts.EqualError(conf.Set(tc.name, tc.value), tc.err, name)
+	}
+	cts.NoError(conf.Set("APP_WORKERS", "64"), "Limits should be inclusive")
+	cts.NoError(conf.Set("APP_PASSWORD", "pässwörd"), "Length should be counted in characters")
+
+	sampleFile := filepath.Join(cts.T().TempDir(), ".env.sample")
+	cts.NoError(conf.CreateSampleFile(sampleFile))
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err)
+	cts.Contains(string(content), "# Description: Number of workers # Constraints: Max 64, Min 1\nAPP_WORKERS=4\n", "Limits should be documented")
+	cts.Contains(string(content), "# Constraints: Max length 16, Min length 8\n")
+
+	clone := conf.Clone()
+	*clone.vars["APP_WORKERS"].Min = 2
+	cts.NoError(conf.Set("APP_WORKERS", "1"), "Clone should not share the limits")
+}
@@ -0,0 +1,107 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// LintReport is the result of Lint, the names are the external names of the Variables, sorted.
+type LintReport struct {
+	// File is the linted file.
+	File string `json:"file"`
+
+	// Err is the error of reading or parsing the file, the other fields are empty if it is set.
+	Err error `json:"-"`
+
+	// Unknown are the keys of the file which are not registered Variables, e.g. TZ read by the runtime. They are
+	// warnings, they do not fail the lint.
+	Unknown []string `json:"unknown"`
+
+	// Missing are the required Variables (with a validation.Required rule) which are neither set by the file,
+	// nor have a default value.
+	Missing []string `json:"missing"`
+
+	// Deprecated are the Deprecated Variables set by the file, with their notes.
+	Deprecated map[string]string `json:"deprecated"`
+
+	// Invalid are the rule violations of the values, except the Required rules of the Missing Variables.
+	Invalid ValidationReport `json:"invalid"`
+}
+
+// OK returns true if the file is valid, the Unknown keys and the Deprecated Variables do not fail the lint.
+func (report LintReport) OK() bool {
+	return report.Err == nil && len(report.Missing) == 0 && len(report.Invalid) == 0
+}
+
+// Lint checks the configuration file (envfile, JSON or YAML by its extension) against the Variables like
+// ValidateFile, but it reports every problem of the file: the unknown keys, the missing required Variables,
+// the rule violations and the Deprecated Variables. It does not modify the AppConfig or the environment,
+// so it can be used from CI.
+func (appConf *AppConfig) Lint(envfile string) LintReport {
+	report := LintReport{
+		File:       envfile,
+		Unknown:    []string{},
+		Missing:    []string{},
+		Deprecated: map[string]string{},
+		Invalid:    ValidationReport{},
+	}
+	values, loaded, err := appConf.fileValues(envfile)
+	if err != nil {
+		report.Err = err
+		return report
+	}
+
+	appConf.mu.RLock()
+	for name := range loaded {
+		if appConf.schemaVersion > 0 && name == appConf.externalName(constants.APP_CONFIG_SCHEMA_VERSION) {
+			continue
+		}
+		confVar, ok := appConf.vars[appConf.internalName(name)]
+		if !ok {
+			if _, ok := appConf.vars[appConf.internalName(strings.TrimSuffix(name, FileSuffix))]; !ok {
+				report.Unknown = append(report.Unknown, name)
+			}
+			continue
+		}
+		if confVar.Deprecated != "" {
+			report.Deprecated[name] = confVar.Deprecated
+		}
+	}
+
+	// missing are the names of the Required rules of the Missing Variables
+	missing := map[string][]string{}
+	for confKey, confVar := range appConf.vars {
+		if rules := confVar.requiredRules(values[constants.APP_ENV]); values[confKey] == "" && len(rules) > 0 {
+			missing[appConf.externalName(confKey)] = rules
+			report.Missing = append(report.Missing, appConf.externalName(confKey))
+		}
+	}
+	appConf.mu.RUnlock()
+
+	for _, issue := range appConf.validationReport(values) {
+		if !contains(missing[issue.Variable], issue.Rule) {
+			report.Invalid = append(report.Invalid, issue)
+		}
+	}
+
+	// Sort is needed because maps always return values in random order
+	sort.Strings(report.Unknown)
+	sort.Strings(report.Missing)
+	return report
+}
+
+// requiredRules returns the names of the validation.Required rules of the Variable in the environment.
+func (confVar *Variable) requiredRules(environment string) []string {
+	names := []string{}
+	for _, rules := range []map[string]validation.Rule{confVar.Rules, confVar.RulesByEnv[environment]} {
+		for name, rule := range rules {
+			if _, ok := rule.(validation.RequiredRule); ok {
+				names = append(names, name)
+			}
+		}
+	}
+	return names
+}
@@ -0,0 +1,73 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestLint() {
+	conf := NewConfig(map[string]*Variable{
+		constants.APP_ENV: {DefaultValue: constants.ENV_DEV},
+		constants.APP_PORT: {
+			DefaultValue: "8080",
+			Rules:        map[string]validation.Rule{"Valid port": is.Port},
+		},
+		"APP_DB_HOST": {
+			Rules: map[string]validation.Rule{"Required": validation.Required, "Valid host": is.Host},
+		},
+		"APP_DB_USER": {
+			DefaultValue: "app",
+			Rules:        map[string]validation.Rule{"Required": validation.Required},
+		},
+		"APP_API_KEY": {
+			RulesByEnv: map[string]map[string]validation.Rule{
+				constants.ENV_PRODUCTION: {"Required in production": validation.Required},
+			},
+		},
+		"APP_LOGLEVEL": {Deprecated: "Use APP_LOG_LEVEL instead"},
+	})
+
+	dir := cts.T().TempDir()
+	envfile := filepath.Join(dir, ".env")
+	content := "APP_ENV=production\nAPP_PORT=http\nAPP_PROT=80\nAPP_LOGLEVEL=debug\nAPP_DB_HOST_FILE=/run/secrets/host\n"
+	cts.NoError(ioutil.WriteFile(envfile, []byte(content), 0600))
+	cts.NoError(os.Setenv("APP_API_KEY", "from-environment"), "Environment variable should have been set")
+	defer func() {
+		cts.NoError(os.Unsetenv("APP_API_KEY"), "Environment variable should have been unset")
+	}()
+
+	report := conf.Lint(envfile)
+	cts.False(report.OK())
+	cts.NoError(report.Err)
+	cts.Equal(envfile, report.File)
+	cts.Equal([]string{"APP_PROT"}, report.Unknown, "The FileSuffix variants should be known")
+	cts.Equal([]string{"APP_API_KEY", "APP_DB_HOST"}, report.Missing, "The environment should be ignored")
+	cts.Equal(map[string]string{"APP_LOGLEVEL": "Use APP_LOG_LEVEL instead"}, report.Deprecated)
+	cts.Equal(ValidationReport{
+		{Variable: "APP_PORT", Value: "http", Rule: "Valid port", Message: "must be a valid port number"},
+	}, report.Invalid, "The Required rules of the missing Variables should not be repeated")
+	cts.Empty(conf.Get(constants.APP_PORT), "Lint should not modify the AppConfig")
+
+	cts.NoError(ioutil.WriteFile(envfile, []byte("APP_DB_HOST=db.internal\nAPP_LOGLEVEL=debug\nTZ=UTC\n"), 0600))
+	report = conf.Lint(envfile)
+	cts.True(report.OK(), "Deprecated Variables and unknown keys should not fail the lint")
+	cts.Equal([]string{"TZ"}, report.Unknown, "Unknown keys should be reported as warnings")
+
+	calls := 0
+	cts.NoError(conf.AddVariable("APP_INSTANCE", &Variable{DefaultFunc: func() (string, error) {
+		calls++
+		return "instance-1", nil
+	}}))
+	conf.Lint(envfile)
+	conf.Lint(envfile)
+	cts.Equal(2, calls, "Lint should not cache the computed defaults")
+
+	report = conf.Lint(filepath.Join(dir, "missing.env"))
+	cts.False(report.OK())
+	cts.Error(report.Err)
+}
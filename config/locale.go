@@ -0,0 +1,83 @@
+package config
+
+import (
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+	"golang.org/x/text/language"
+)
+
+// LocaleGroup is the Group of the LocaleVariables.
+const LocaleGroup = "Locale"
+
+var (
+	// ErrTimezone is the error of the timezones which cannot be loaded by time.LoadLocation.
+	ErrTimezone = validation.NewError("validation_config_timezone", "must be a valid IANA timezone (e.g. UTC, Europe/Budapest)")
+
+	// ErrLocale is the error of the locales which are not well-formed BCP 47 language tags.
+	ErrLocale = validation.NewError("validation_config_locale", "must be a valid BCP 47 language tag (e.g. en-US, hu)")
+)
+
+// LocaleVariables returns the standard Variables of the timezone and the locale of the application (APP_TIMEZONE
+// and APP_LOCALE), to be added to the defaults of NewConfig or registered with AddVariable. They are read by
+// Location and Locale. The timezones are loaded from the system's timezone database, import time/tzdata
+// in the main package if the containers lack it.
+func LocaleVariables() map[string]*Variable {
+	return map[string]*Variable{
+		constants.APP_TIMEZONE: {
+			DefaultValue: "UTC",
+			Description:  "IANA timezone of the application, e.g. Europe/Budapest",
+			Group:        LocaleGroup,
+			Rules: map[string]validation.Rule{
+				"Valid timezone": validation.By(func(value interface{}) error {
+					name, err := validation.EnsureString(value)
+					if err != nil {
+						return err
+					}
+					if _, err := time.LoadLocation(name); err != nil {
+						return ErrTimezone
+					}
+					return nil
+				}),
+			},
+		},
+		constants.APP_LOCALE: {
+			DefaultValue: "en-US",
+			Description:  "BCP 47 language tag of the application, e.g. en-US",
+			Group:        LocaleGroup,
+			Rules: map[string]validation.Rule{
+				"Valid locale": validation.By(func(value interface{}) error {
+					tag, err := validation.EnsureString(value)
+					if err != nil || tag == "" {
+						return err
+					}
+					if _, err := language.Parse(tag); err != nil {
+						return ErrLocale
+					}
+					return nil
+				}),
+			},
+		},
+	}
+}
+
+// Location returns the timezone of APP_TIMEZONE, or time.UTC if it is not set or cannot be loaded.
+func (appConf *AppConfig) Location() *time.Location {
+	// The value is validated by the Setup, the invalid one falls back to UTC
+	location, err := time.LoadLocation(appConf.Get(constants.APP_TIMEZONE))
+	if err != nil {
+		return time.UTC
+	}
+	return location
+}
+
+// Locale returns the language tag of APP_LOCALE, or language.Und if it is not set or invalid.
+func (appConf *AppConfig) Locale() language.Tag {
+	// The value is validated by the Setup, the invalid one falls back to the undefined language
+	tag, err := language.Parse(appConf.Get(constants.APP_LOCALE))
+	if err != nil {
+		return language.Und
+	}
+	return tag
+}
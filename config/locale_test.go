@@ -0,0 +1,42 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+	"golang.org/x/text/language"
+)
+
+func (cts *ConfigTestSuite) TestLocale() {
+	unsetEnv := func() {
+		for name := range LocaleVariables() {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+
+	conf := NewConfig(LocaleVariables())
+	cts.NoError(conf.Setup())
+	cts.Equal(time.UTC, conf.Location())
+	cts.Equal(language.AmericanEnglish, conf.Locale())
+
+	cts.setEnvVars(map[string]string{
+		constants.APP_TIMEZONE: "Europe/Budapest",
+		constants.APP_LOCALE:   "hu-HU",
+	})
+	cts.NoError(conf.Setup())
+	cts.Equal("Europe/Budapest", conf.Location().String())
+	cts.Equal(language.MustParse("hu-HU"), conf.Locale())
+
+	cts.setEnvVars(map[string]string{
+		constants.APP_TIMEZONE: "Mars/Olympus_Mons",
+		constants.APP_LOCALE:   "not a locale",
+	})
+	cts.EqualError(errors.Cause(conf.Setup()), "APP_LOCALE = not a locale: (Valid locale: must be a valid BCP 47 language tag (e.g. en-US, hu).); "+
+		"APP_TIMEZONE = Mars/Olympus_Mons: (Valid timezone: must be a valid IANA timezone (e.g. UTC, Europe/Budapest).).")
+	cts.Equal(time.UTC, conf.Location(), "Invalid timezone should fall back to UTC")
+	cts.Equal(language.Und, conf.Locale())
+}
@@ -0,0 +1,54 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// markdownDocHeader is the header of the table of CreateMarkdownDoc.
+var markdownDocHeader = []string{"Variable Name", "Group", "Description", "Constraints", "Default Value", "Sensitive"}
+
+// CreateMarkdownDoc writes the documentation of the whole variable catalogue into w, as a markdown table
+// ordered by group and name, which can be committed next to the service instead of hand-maintained docs.
+// The default values of the Sensitive variables are masked.
+func (appConf *AppConfig) CreateMarkdownDoc(w io.Writer) error {
+	rows := appConf.dumpRows()
+	// The rows are sorted by name already, the stable sort keeps that order within the groups
+	sortByGroup(rows)
+
+	builder := &strings.Builder{}
+	builder.WriteString("# Configuration\n\n")
+	builder.WriteString("<!-- Automatically created by the application from the config object -->\n\n")
+	builder.WriteString("| " + strings.Join(markdownDocHeader, " | ") + " |\n")
+	builder.WriteString(strings.Repeat("| --- ", len(markdownDocHeader)) + "|\n")
+	for _, row := range rows {
+		defaultValue := ""
+		if row.DefaultValue != "" {
+			defaultValue = "`" + row.DefaultValue + "`"
+		}
+		sensitive := ""
+		if row.Sensitive {
+			sensitive = "yes"
+		}
+		fields := []string{
+			"`" + row.Name + "`",
+			row.Group,
+			row.Description,
+			strings.Join(row.Constraints, ", "),
+			defaultValue,
+			sensitive,
+		}
+		for i, field := range fields {
+			fields[i] = markdownEscaper.Replace(field)
+		}
+		builder.WriteString(fmt.Sprintf("| %s |\n", strings.Join(fields, " | ")))
+	}
+
+	if _, err := io.WriteString(w, builder.String()); err != nil {
+		return errors.Wrap(err, "Failed to write markdown documentation")
+	}
+	return nil
+}
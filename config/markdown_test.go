@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+func (cts *ConfigTestSuite) TestCreateMarkdownDoc() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_PORT": {
+			DefaultValue: "8080",
+			Description:  "TCP/IP Port where the application listens",
+			Group:        "Server",
+		},
+		"APP_DB_PASSWORD": {
+			DefaultValue: "postgres",
+			Description:  "The password of the database user",
+			Rules: map[string]validation.Rule{
+				"Required": validation.Required,
+			},
+			Sensitive: true,
+			Group:     "Database",
+		},
+		"APP_DB_HOST": {
+			Description: "The host of the database",
+			Group:       "Database",
+		},
+		"APP_NAME": {
+			Description: "Name | alias of the application",
+		},
+	})
+
+	builder := &strings.Builder{}
+	cts.NoError(conf.CreateMarkdownDoc(builder))
+	lines := strings.Split(strings.TrimSpace(builder.String()), "\n")
+	cts.Equal("# Configuration", lines[0])
+	cts.Equal([]string{
+		"| Variable Name | Group | Description | Constraints | Default Value | Sensitive |",
+		"| --- | --- | --- | --- | --- | --- |",
+		"| `APP_NAME` |  | Name \\| alias of the application |  |  |  |",
+		"| `APP_DB_HOST` | Database | The host of the database |  |  |  |",
+		"| `APP_DB_PASSWORD` | Database | The password of the database user | Required | `*****` | yes |",
+		"| `APP_PORT` | Server | TCP/IP Port where the application listens |  | `8080` |  |",
+	}, lines[4:], "Variables should be ordered by group and name")
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/config/mysqldsn"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// MySQLVariables returns the standard Variables of a MySQL connection (APP_DB_HOST, APP_DB_PORT, APP_DB_USER,
+// APP_DB_PASSWORD, APP_DB_NAME, APP_DB_TLS and APP_DB_CHARSET), to be added to the defaults of NewConfig
+// or registered with AddVariable. The DSN is built by MySQLDSN.
+func MySQLVariables() map[string]*Variable {
+	return map[string]*Variable{
+		constants.APP_DB_HOST: {
+			DefaultValue: "localhost",
+			Description:  "Host name or IP address of the MySQL server",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Required":   validation.Required,
+				"Valid host": is.Host,
+			},
+		},
+		constants.APP_DB_PORT: {
+			DefaultValue: "3306",
+			Description:  "TCP port of the MySQL server",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Required":   validation.Required,
+				"Valid port": is.Port,
+			},
+		},
+		constants.APP_DB_USER: {
+			Description: "Name of the database user",
+			Group:       DatabaseGroup,
+		},
+		constants.APP_DB_PASSWORD: {
+			Description: "Password of the database user",
+			Group:       DatabaseGroup,
+			NoExpand:    true,
+			Sensitive:   true,
+		},
+		constants.APP_DB_NAME: {
+			Description: "Name of the database",
+			Group:       DatabaseGroup,
+		},
+		constants.APP_DB_TLS: {
+			DefaultValue: mysqldsn.TLSPreferred,
+			Description:  "TLS mode of the connection (false, true, skip-verify, preferred)",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Valid TLS mode": validation.In(mysqldsn.ValidTLSModes...),
+			},
+		},
+		constants.APP_DB_CHARSET: {
+			DefaultValue: "utf8mb4",
+			Description:  "Character set of the connection",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Required": validation.Required,
+			},
+		},
+	}
+}
+
+// MySQLDSN returns the MySQL data source name built from the MySQLVariables, with ParseTime enabled.
+// Use its FormatDSN method to open the connection.
+func (appConf *AppConfig) MySQLDSN() mysqldsn.DSN {
+	return mysqldsn.DSN{
+		Host:      appConf.Get(constants.APP_DB_HOST),
+		Port:      appConf.Get(constants.APP_DB_PORT),
+		User:      appConf.Get(constants.APP_DB_USER),
+		Password:  appConf.Get(constants.APP_DB_PASSWORD),
+		Name:      appConf.Get(constants.APP_DB_NAME),
+		TLS:       appConf.Get(constants.APP_DB_TLS),
+		Charset:   appConf.Get(constants.APP_DB_CHARSET),
+		ParseTime: true,
+	}
+}
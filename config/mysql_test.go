@@ -0,0 +1,33 @@
+package config
+
+import (
+	"os"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestMySQLDSN() {
+	for name := range MySQLVariables() {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	cts.setEnvVars(map[string]string{
+		constants.APP_DB_HOST:     "db.internal",
+		constants.APP_DB_USER:     "app",
+		constants.APP_DB_PASSWORD: "s3cr3t",
+		constants.APP_DB_NAME:     "orders",
+		constants.APP_DB_TLS:      "true",
+	})
+	defer func() {
+		for name := range MySQLVariables() {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}()
+
+	conf := NewConfig(MySQLVariables())
+	cts.NoError(conf.Setup())
+	cts.Equal("app:s3cr3t@tcp(db.internal:3306)/orders?charset=utf8mb4&parseTime=true&tls=true", conf.MySQLDSN().FormatDSN())
+
+	cts.setEnvVars(map[string]string{constants.APP_DB_TLS: "always"})
+	cts.EqualError(errors.Cause(conf.Setup()), "APP_DB_TLS = always: (Valid TLS mode: must be a valid value.).")
+}
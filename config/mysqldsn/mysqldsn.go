@@ -0,0 +1,103 @@
+// Package mysqldsn builds MySQL data source names from their parts, in the format of go-sql-driver/mysql:
+//
+//	dsn := mysqldsn.DSN{Host: "db", Port: "3306", User: "app", Password: "s3cr3t", Name: "app", TLS: mysqldsn.TLSTrue}
+//	sql.Open("mysql", dsn.FormatDSN())
+//
+// The AppConfig builds the DSN from the APP_DB_* Variables, see config.AppConfig.MySQLDSN.
+package mysqldsn
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// The TLS modes of go-sql-driver/mysql, custom modes can be registered with mysql.RegisterTLSConfig.
+const (
+	// TLSFalse disables TLS.
+	TLSFalse = "false"
+
+	// TLSTrue requires TLS and verifies the server certificate.
+	TLSTrue = "true"
+
+	// TLSSkipVerify requires TLS without verifying the server certificate.
+	TLSSkipVerify = "skip-verify"
+
+	// TLSPreferred uses TLS if the server supports it, without verifying the server certificate.
+	TLSPreferred = "preferred"
+)
+
+// ValidTLSModes are the valid TLS modes. Used in validation.
+var ValidTLSModes = []interface{}{TLSFalse, TLSTrue, TLSSkipVerify, TLSPreferred}
+
+// DSN holds the parts of a MySQL data source name, the empty parts are left out.
+type DSN struct {
+	// Host is the host name or the IP address of the server.
+	Host string
+
+	// Port is the TCP port of the server.
+	Port string
+
+	// User is the name of the database user.
+	User string
+
+	// Password is the password of the database user.
+	Password string
+
+	// Name is the name of the database.
+	Name string
+
+	// TLS is one of the TLS modes, or the name of a custom TLS config.
+	TLS string
+
+	// Charset is the character set of the connection, e.g. utf8mb4.
+	Charset string
+
+	// ParseTime scans DATE and DATETIME values into time.Time.
+	ParseTime bool
+}
+
+// FormatDSN returns the DSN as accepted by go-sql-driver/mysql, e.g.
+// app:s3cr3t@tcp(db:3306)/app?charset=utf8mb4&parseTime=true&tls=true. The user, the password and the
+// parameters are escaped.
+func (dsn DSN) FormatDSN() string {
+	builder := &strings.Builder{}
+	if dsn.User != "" {
+		userinfo := url.User(dsn.User)
+		if dsn.Password != "" {
+			userinfo = url.UserPassword(dsn.User, dsn.Password)
+		}
+		builder.WriteString(userinfo.String() + "@")
+	}
+	if dsn.Host != "" {
+		address := dsn.Host
+		if dsn.Port != "" {
+			address = net.JoinHostPort(dsn.Host, dsn.Port)
+		}
+		builder.WriteString("tcp(" + address + ")")
+	}
+	builder.WriteString("/" + dsn.Name)
+
+	params := url.Values{}
+	if dsn.Charset != "" {
+		params.Set("charset", dsn.Charset)
+	}
+	if dsn.ParseTime {
+		params.Set("parseTime", "true")
+	}
+	if dsn.TLS != "" {
+		params.Set("tls", dsn.TLS)
+	}
+	if len(params) > 0 {
+		builder.WriteString("?" + params.Encode())
+	}
+	return builder.String()
+}
+
+// String returns the FormatDSN with the password masked, so the DSN can be logged.
+func (dsn DSN) String() string {
+	if dsn.Password != "" {
+		dsn.Password = "xxxxx"
+	}
+	return dsn.FormatDSN()
+}
@@ -0,0 +1,50 @@
+package mysqldsn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+// MySQLDSNSuite extends testify's Suite.
+type MySQLDSNSuite struct {
+	suite.Suite
+}
+
+func (ms *MySQLDSNSuite) TestFormatDSN() {
+	testCases := map[string]struct {
+		dsn      DSN
+		expected string
+	}{
+		"full": {
+			dsn:      DSN{Host: "db", Port: "3306", User: "app", Password: "s3cr3t", Name: "app", TLS: TLSTrue, Charset: "utf8mb4", ParseTime: true},
+			expected: "app:s3cr3t@tcp(db:3306)/app?charset=utf8mb4&parseTime=true&tls=true",
+		},
+		"IPv6 host": {
+			dsn:      DSN{Host: "::1", Port: "3306", User: "app", Name: "app"},
+			expected: "app@tcp([::1]:3306)/app",
+		},
+		"custom TLS config": {
+			dsn:      DSN{Host: "db", User: "app", Password: "p@ss:word", TLS: "my tls"},
+			expected: "app:p%40ss%3Aword@tcp(db)/?tls=my+tls",
+		},
+		"empty": {
+			expected: "/",
+		},
+	}
+
+	for name, tc := range testCases {
+		ms.Equal(tc.expected, tc.dsn.FormatDSN(), name)
+	}
+}
+
+func (ms *MySQLDSNSuite) TestString() {
+	dsn := DSN{Host: "db", Port: "3306", User: "app", Password: "s3cr3t", Name: "app"}
+	ms.Equal("app:xxxxx@tcp(db:3306)/app", dsn.String(), "The password should be masked")
+	ms.Equal("s3cr3t", dsn.Password, "The DSN should not be modified")
+}
+
+// TestMySQLDSN runs the whole test suite
+func TestMySQLDSN(t *testing.T) {
+	suite.Run(t, new(MySQLDSNSuite))
+}
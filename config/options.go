@@ -0,0 +1,155 @@
+package config
+
+import (
+	"flag"
+	"strings"
+)
+
+// setupOptions holds the settings of a single Setup run.
+type setupOptions struct {
+	// envfiles are the dotenv files which overload the environment variables.
+	envfiles []string
+
+	// layers are the envfiles which are applied after the envfiles, in the order of their priority.
+	layers []Layer
+
+	// searchPaths are the directories where SetupAuto looks for the envfiles.
+	searchPaths []string
+
+	// yamlFiles are the YAML files which are applied after the envfiles.
+	yamlFiles []string
+
+	// sources are the Sources which are applied after the files.
+	sources []Source
+
+	// flags are the command-line flags which are applied last.
+	flags *flag.FlagSet
+
+	// strictEnv fails the Setup if unknown environment variables are set.
+	strictEnv bool
+
+	// unknownEnvHandler is called with the unknown environment variables instead of failing the Setup.
+	unknownEnvHandler func(names []string)
+
+	// envPrecedence makes the environment variables take precedence over the envfiles.
+	envPrecedence bool
+}
+
+// SetupOption configures how SetupWithOptions loads the Application's Configuration.
+type SetupOption func(*setupOptions)
+
+// newSetupOptions applies the supplied SetupOptions on an empty setupOptions.
+func newSetupOptions(opts ...SetupOption) *setupOptions {
+	options := &setupOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	return options
+}
+
+// WithEnvfiles loads the supplied envfile(s). Variables in the envfile(s) take precedence over
+// environment variables.
+func WithEnvfiles(envfiles ...string) SetupOption {
+	return func(o *setupOptions) {
+		o.envfiles = append(o.envfiles, envfiles...)
+	}
+}
+
+// WithYAMLFiles loads the supplied YAML file(s). Variables in the YAML file(s) take precedence over
+// both the envfiles and the environment variables, later files take precedence over earlier ones.
+func WithYAMLFiles(files ...string) SetupOption {
+	return func(o *setupOptions) {
+		o.yamlFiles = append(o.yamlFiles, files...)
+	}
+}
+
+// files returns every file loaded by the Setup, including the missing Optional Layers.
+func (o *setupOptions) files() []string {
+	files := append([]string{}, o.envfiles...)
+	for _, layer := range o.layers {
+		files = append(files, layer.Path)
+	}
+	return append(files, o.yamlFiles...)
+}
+
+// DefaultPrefix is the prefix of the Variable names in the application, see constants.
+const DefaultPrefix = "APP_"
+
+// ConfigOption configures an AppConfig created by NewConfig.
+type ConfigOption func(*AppConfig)
+
+// WithPrefix replaces the DefaultPrefix of the Variable names outside of the application: in the environment,
+// the files, the Sources, the flags, the sample file and the dumps. E.g. with WithPrefix("MYSVC_") APP_PORT is
+// read from MYSVC_PORT, so several services sharing one host don't collide. The application keeps using the
+// original names, e.g. Get(constants.APP_PORT). Names without the DefaultPrefix are not changed.
+func WithPrefix(prefix string) ConfigOption {
+	return func(appConf *AppConfig) {
+		appConf.prefix = prefix
+	}
+}
+
+// WithNormalizedNames makes Lookup and Get (and the helpers built on them), Set, ValidateVar, Source, Display and
+// LogFields resolve the names case-insensitively, with "." and "-" as "_" separators, e.g. Lookup("app.port") and
+// Lookup("app-port") return APP_PORT. It eases the migration from libraries using lowercase dotted keys (e.g.
+// Viper). The exact names are looked up first, so the Variables with lowercase names can still be retrieved.
+func WithNormalizedNames() ConfigOption {
+	return func(appConf *AppConfig) {
+		appConf.normalizeNames = true
+	}
+}
+
+// nameReplacer replaces the separators of the normalized names.
+var nameReplacer = strings.NewReplacer(".", "_", "-", "_")
+
+// normalizeName returns the upper-case name with "_" separators, see WithNormalizedNames.
+func normalizeName(name string) string {
+	return strings.ToUpper(nameReplacer.Replace(name))
+}
+
+// variableName returns the registered name of the named Variable, the normalized name if the exact name is not
+// registered and the names are normalized, and whether the Variable is registered. The caller must hold the lock.
+func (appConf *AppConfig) variableName(name string) (string, bool) {
+	if _, ok := appConf.vars[name]; ok {
+		return name, true
+	}
+	if appConf.normalizeNames {
+		if _, ok := appConf.vars[normalizeName(name)]; ok {
+			return normalizeName(name), true
+		}
+	}
+	return name, false
+}
+
+// externalName returns the name of the Variable outside of the application.
+func (appConf *AppConfig) externalName(name string) string {
+	if appConf.prefix == "" || !strings.HasPrefix(name, DefaultPrefix) {
+		return name
+	}
+	return appConf.prefix + strings.TrimPrefix(name, DefaultPrefix)
+}
+
+// internalName returns the name of the Variable in the application, the inverse of externalName.
+func (appConf *AppConfig) internalName(name string) string {
+	if appConf.prefix == "" || !strings.HasPrefix(name, appConf.prefix) {
+		return name
+	}
+	return DefaultPrefix + strings.TrimPrefix(name, appConf.prefix)
+}
+
+// externalValues returns a copy of values keyed by the external names.
+func (appConf *AppConfig) externalValues(values map[string]string) map[string]string {
+	external := make(map[string]string, len(values))
+	for name, value := range values {
+		external[appConf.externalName(name)] = value
+	}
+	return external
+}
+
+// internalValues returns a copy of values keyed by the internal names, the inverse of externalValues.
+func (appConf *AppConfig) internalValues(values map[string]string) map[string]string {
+	internal := make(map[string]string, len(values))
+	for name, value := range values {
+		internal[appConf.internalName(name)] = value
+	}
+	return internal
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestPrefix() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer func(fileName string) {
+		cts.NoErrorf(os.Remove(fileName), "Temp envfile (%s) should have been removed", fileName)
+	}(envFile)
+	cts.writeEnvfile(envFile, map[string]string{
+		"MYSVC_LOG_LEVEL": constants.LOG_LEVEL_WARN,
+	})
+	cts.setEnvVars(map[string]string{
+		"MYSVC_PORT": "9090",
+		"APP_DEBUG":  "0",
+	})
+	defer func() {
+		for _, name := range []string{"MYSVC_PORT", "MYSVC_LOG_LEVEL", "APP_DEBUG"} {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}()
+
+	conf := NewConfig(cts.getDefaultConfigs(), WithPrefix("MYSVC_"))
+	cts.NoError(conf.SetupWithOptions(WithEnvfiles(envFile)))
+	cts.Equal("9090", conf.Port(), "Prefixed environment variable should be read")
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel(), "Prefixed envfile variable should be read")
+	cts.Equal("true", conf.Get(constants.APP_DEBUG), "APP_ prefixed environment variable should be ignored")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	conf.RegisterFlags(fs)
+	cts.NotNil(fs.Lookup("mysvc-port"), "MYSVC_PORT should be registered as --mysvc-port")
+	cts.Nil(fs.Lookup("app-port"), "APP_PORT should not be registered")
+
+	sampleFile := filepath.Join(cts.T().TempDir(), ".env.sample")
+	cts.NoError(conf.CreateSampleFile(sampleFile), "The sample file should have been created")
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err, "The sample file should be readable")
+	cts.Contains(string(content), "MYSVC_LOG_LEVEL=debug")
+	cts.NotContains(string(content), "APP_")
+
+	cts.setEnvVars(map[string]string{"MYSVC_PORT": "not-a-port"})
+	cts.Contains(conf.Setup().Error(), "MYSVC_PORT = not-a-port", "Errors should use the prefixed name")
+}
+
+func (cts *ConfigTestSuite) TestNormalizedNames() {
+	conf := NewConfig(map[string]*Variable{
+		constants.APP_PORT: {Value: "8080"},
+		"app.legacy":       {Value: "exact"},
+		"APP_LEGACY":       {Value: "normalized"},
+	}, WithNormalizedNames())
+	for _, name := range []string{"APP_PORT", "app_port", "app.port", "App-Port"} {
+		value, ok := conf.Lookup(name)
+		cts.True(ok, name)
+		cts.Equal("8080", value, name)
+	}
+	cts.Equal("exact", conf.Get("app.legacy"), "Exact names should be looked up first")
+	cts.Equal("8080", conf.Clone().Get("app.port"), "Clone should keep the normalization")
+	_, ok := conf.Lookup("app.unknown")
+	cts.False(ok)
+	cts.NoError(conf.Set("app.port", "9090"))
+	cts.Equal("9090", conf.Get(constants.APP_PORT), "Set should resolve the normalized names")
+	cts.NoError(conf.ValidateVar("app-port"))
+	cts.Equal(OriginRuntime, conf.Source("app.port"))
+	cts.Equal("9090", conf.Display("app.port"))
+	cts.Equal("9090", conf.LogFields("app.port")[constants.APP_PORT])
+
+	conf = NewConfig(map[string]*Variable{constants.APP_PORT: {Value: "8080"}})
+	_, ok = conf.Lookup("app.port")
+	cts.False(ok, "Names should not be normalized by default")
+}
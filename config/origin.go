@@ -0,0 +1,34 @@
+package config
+
+// The origins of the values returned by Source. The other origins name the location of the value:
+//   - "default (<APP_ENV>)": the DefaultsByEnv of the environment
+//   - "envfile <filename>": an envfile
+//   - "file <NAME>_FILE": the file named by the FileSuffix variable
+//   - "yaml <filename>": a YAML file
+//   - "flag --<name>": a command-line flag
+//   - the Name of the Source, e.g. "s3://bucket/key"
+const (
+	// OriginDefault is the DefaultValue of the Variable.
+	OriginDefault = "default"
+
+	// OriginEnvironment is an environment variable, which is not set by the envfiles.
+	OriginEnvironment = "environment"
+
+	// OriginRuntime is a value set by Set or Override.
+	OriginRuntime = "runtime"
+
+	// OriginDerived is a value computed by the Derive function of the Variable.
+	OriginDerived = "derived"
+)
+
+// Source returns where the value of the named Variable was resolved from by the last Setup or Reload
+// (e.g. "default", "environment" or "envfile .env", see OriginDefault), to debug where a value came from.
+// It returns an empty string if the Variable is unknown or it is not set up yet.
+func (appConf *AppConfig) Source(name string) string {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	if name, ok := appConf.variableName(name); ok {
+		return appConf.vars[name].origin
+	}
+	return ""
+}
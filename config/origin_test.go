@@ -0,0 +1,50 @@
+package config
+
+import (
+	"flag"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSource() {
+	envFile := cts.setupEnvTest(append(constants.BasicEnvs, constants.APP_DB_SECRET_NAME)...)
+	defer func(fileName string) {
+		cts.NoErrorf(os.Remove(fileName), "Temp envfile (%s) should have been removed", fileName)
+	}(envFile)
+	cts.writeEnvfile(envFile, map[string]string{
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN,
+	})
+	cts.setEnvVars(map[string]string{constants.APP_DEBUG: "0"})
+	yamlFile := filepath.Join(cts.T().TempDir(), "config.yaml")
+	cts.NoError(ioutil.WriteFile(yamlFile, []byte("app:\n  db_secret_name: secret\n"), 0600))
+	defer func() {
+		for _, name := range []string{constants.APP_DEBUG, constants.APP_LOG_LEVEL} {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}()
+
+	defaults := cts.getDefaultConfigs()
+	defaults[constants.APP_LOG_DEV].DefaultsByEnv = map[string]string{constants.ENV_TEST: "1"}
+	conf := NewConfig(defaults)
+	cts.Empty(conf.Source(constants.APP_PORT), "The source should be empty before the Setup")
+
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	conf.RegisterFlags(fs)
+	cts.NoError(fs.Parse([]string{"--app-port", "7070"}))
+	cts.NoError(conf.SetupWithOptions(WithEnvfiles(envFile), WithYAMLFiles(yamlFile), WithFlags(fs)))
+
+	cts.Equal(OriginDefault, conf.Source(constants.APP_ENV))
+	cts.Equal("default (test)", conf.Source(constants.APP_LOG_DEV))
+	cts.Equal(OriginEnvironment, conf.Source(constants.APP_DEBUG))
+	cts.Equal("envfile "+envFile, conf.Source(constants.APP_LOG_LEVEL))
+	cts.Equal("yaml "+yamlFile, conf.Source(constants.APP_DB_SECRET_NAME))
+	cts.Equal("flag --app-port", conf.Source(constants.APP_PORT))
+	cts.Empty(conf.Source("APP_UNKNOWN"), "The source of unknown Variables should be empty")
+	cts.Contains(conf.DumpTable(), "flag --app-port", "The table should contain the source")
+
+	cts.NoError(conf.Set(constants.APP_PORT, "6060"))
+	cts.Equal(OriginRuntime, conf.Source(constants.APP_PORT))
+}
@@ -0,0 +1,103 @@
+// Package pgdsn builds PostgreSQL data source names from their parts, in the formats of lib/pq and gorm:
+//
+//	dsn := pgdsn.DSN{Host: "db", Port: "5432", User: "app", Password: "s3cr3t", Name: "app", SSLMode: constants.SSL_MODE_REQUIRE}
+//	sql.Open("postgres", dsn.LibPQ())
+//	gorm.Open(postgres.Open(dsn.Gorm()), &gorm.Config{})
+//
+// The AppConfig builds the DSN from the APP_DB_* Variables, see config.AppConfig.PostgresDSN.
+package pgdsn
+
+import (
+	"net"
+	"net/url"
+	"strings"
+)
+
+// DSN holds the parts of a PostgreSQL data source name, the empty parts are left out.
+type DSN struct {
+	// Host is the host name or the IP address of the server.
+	Host string
+
+	// Port is the TCP port of the server.
+	Port string
+
+	// User is the name of the database user.
+	User string
+
+	// Password is the password of the database user.
+	Password string
+
+	// Name is the name of the database.
+	Name string
+
+	// SSLMode is one of the constants.SSL_MODE_* values.
+	SSLMode string
+}
+
+// LibPQ returns the DSN as a URL, e.g. postgres://app:s3cr3t@db:5432/app?sslmode=require, as accepted by lib/pq
+// and pgx. The parts are escaped. The directory of a Unix socket (an absolute path as Host) is passed by the host
+// parameter, e.g. postgres:///app?host=%2Fvar%2Frun%2Fpostgresql.
+func (dsn DSN) LibPQ() string {
+	u := url.URL{Scheme: "postgres", Host: dsn.Host}
+	params := url.Values{}
+	if strings.HasPrefix(dsn.Host, "/") {
+		u.Host = ""
+		params.Set("host", dsn.Host)
+		if dsn.Port != "" {
+			params.Set("port", dsn.Port)
+		}
+	} else if dsn.Port != "" {
+		u.Host = net.JoinHostPort(dsn.Host, dsn.Port)
+	}
+	if dsn.User != "" {
+		if dsn.Password != "" {
+			u.User = url.UserPassword(dsn.User, dsn.Password)
+		} else {
+			u.User = url.User(dsn.User)
+		}
+	}
+	if dsn.Name != "" {
+		u.Path = "/" + dsn.Name
+	}
+	if dsn.SSLMode != "" {
+		params.Set("sslmode", dsn.SSLMode)
+	}
+	u.RawQuery = params.Encode()
+	return u.String()
+}
+
+// Gorm returns the DSN in the keyword/value format, e.g. host=db port=5432 user=app password=s3cr3t dbname=app sslmode=require,
+// as accepted by gorm's postgres driver. The values with spaces, quotes or backslashes are quoted.
+func (dsn DSN) Gorm() string {
+	pairs := []string{}
+	for _, pair := range [][2]string{
+		{"host", dsn.Host},
+		{"port", dsn.Port},
+		{"user", dsn.User},
+		{"password", dsn.Password},
+		{"dbname", dsn.Name},
+		{"sslmode", dsn.SSLMode},
+	} {
+		if pair[1] != "" {
+			pairs = append(pairs, pair[0]+"="+quote(pair[1]))
+		}
+	}
+	return strings.Join(pairs, " ")
+}
+
+// String returns the LibPQ DSN with the password masked, so the DSN can be logged.
+func (dsn DSN) String() string {
+	if dsn.Password != "" {
+		dsn.Password = "xxxxx"
+	}
+	return dsn.LibPQ()
+}
+
+// quote quotes value for the keyword/value format if needed.
+func quote(value string) string {
+	if !strings.ContainsAny(value, " '\\") {
+		return value
+	}
+	value = strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value)
+	return "'" + value + "'"
+}
@@ -0,0 +1,58 @@
+package pgdsn
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// PgDSNSuite extends testify's Suite.
+type PgDSNSuite struct {
+	suite.Suite
+}
+
+func (ps *PgDSNSuite) TestDSN() {
+	testCases := map[string]struct {
+		dsn   DSN
+		libpq string
+		gorm  string
+	}{
+		"full": {
+			dsn:   DSN{Host: "db", Port: "5432", User: "app", Password: "s3cr3t", Name: "app", SSLMode: constants.SSL_MODE_REQUIRE},
+			libpq: "postgres://app:s3cr3t@db:5432/app?sslmode=require",
+			gorm:  "host=db port=5432 user=app password=s3cr3t dbname=app sslmode=require",
+		},
+		"escaped": {
+			dsn:   DSN{Host: "::1", Port: "5432", User: "app", Password: `p@ss w'rd\`, Name: "app"},
+			libpq: "postgres://app:p%40ss%20w%27rd%5C@[::1]:5432/app",
+			gorm:  `host=::1 port=5432 user=app password='p@ss w\'rd\\' dbname=app`,
+		},
+		"Unix socket": {
+			dsn:   DSN{Host: "/var/run/postgresql", Port: "5432", User: "app", Name: "app", SSLMode: constants.SSL_MODE_DISABLE},
+			libpq: "postgres://app@/app?host=%2Fvar%2Frun%2Fpostgresql&port=5432&sslmode=disable",
+			gorm:  "host=/var/run/postgresql port=5432 user=app dbname=app sslmode=disable",
+		},
+		"host only": {
+			dsn:   DSN{Host: "localhost"},
+			libpq: "postgres://localhost",
+			gorm:  "host=localhost",
+		},
+	}
+
+	for name, tc := range testCases {
+		ps.Equal(tc.libpq, tc.dsn.LibPQ(), name)
+		ps.Equal(tc.gorm, tc.dsn.Gorm(), name)
+	}
+}
+
+func (ps *PgDSNSuite) TestString() {
+	dsn := DSN{Host: "db", User: "app", Password: "s3cr3t", Name: "app"}
+	ps.Equal("postgres://app:xxxxx@db/app", dsn.String(), "The password should be masked")
+	ps.Equal("s3cr3t", dsn.Password, "The DSN should not be modified")
+}
+
+// TestPgDSN runs the whole test suite
+func TestPgDSN(t *testing.T) {
+	suite.Run(t, new(PgDSNSuite))
+}
@@ -0,0 +1,84 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/config/pgdsn"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// DatabaseGroup is the Group of the PostgresVariables and the MySQLVariables.
+const DatabaseGroup = "Database"
+
+// PostgresVariables returns the standard Variables of a PostgreSQL connection (APP_DB_HOST, APP_DB_PORT,
+// APP_DB_USER, APP_DB_PASSWORD, APP_DB_NAME and APP_DB_SSL_MODE), to be added to the defaults of NewConfig
+// or registered with AddVariable. The DSN is built by PostgresDSN.
+func PostgresVariables() map[string]*Variable {
+	return map[string]*Variable{
+		constants.APP_DB_HOST: {
+			DefaultValue: "localhost",
+			Description:  "Host name, IP address or Unix socket directory of the PostgreSQL server",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Required":   validation.Required,
+				"Valid host": hostOrSocket,
+			},
+		},
+		constants.APP_DB_PORT: {
+			DefaultValue: "5432",
+			Description:  "TCP port of the PostgreSQL server",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Required":   validation.Required,
+				"Valid port": is.Port,
+			},
+		},
+		constants.APP_DB_USER: {
+			Description: "Name of the database user",
+			Group:       DatabaseGroup,
+		},
+		constants.APP_DB_PASSWORD: {
+			Description: "Password of the database user",
+			Group:       DatabaseGroup,
+			NoExpand:    true,
+			Sensitive:   true,
+		},
+		constants.APP_DB_NAME: {
+			Description: "Name of the database",
+			Group:       DatabaseGroup,
+		},
+		constants.APP_DB_SSL_MODE: {
+			DefaultValue: constants.SSL_MODE_PREFER,
+			Description:  "SSL mode of the connection (disable, allow, prefer, require, verify-ca, verify-full)",
+			Group:        DatabaseGroup,
+			Rules: map[string]validation.Rule{
+				"Valid SSL mode": validation.In(constants.ValidSSLModes...),
+			},
+		},
+	}
+}
+
+// hostOrSocket validates if a string is a host name, an IP address or the absolute path of a Unix socket
+// (directory).
+var hostOrSocket = validation.By(func(value interface{}) error {
+	host, err := validation.EnsureString(value)
+	if err != nil || filepath.IsAbs(host) {
+		return err
+	}
+	return is.Host.Validate(host)
+})
+
+// PostgresDSN returns the PostgreSQL data source name built from the PostgresVariables,
+// use its LibPQ or Gorm method to format it for the driver.
+func (appConf *AppConfig) PostgresDSN() pgdsn.DSN {
+	return pgdsn.DSN{
+		Host:     appConf.Get(constants.APP_DB_HOST),
+		Port:     appConf.Get(constants.APP_DB_PORT),
+		User:     appConf.Get(constants.APP_DB_USER),
+		Password: appConf.Get(constants.APP_DB_PASSWORD),
+		Name:     appConf.Get(constants.APP_DB_NAME),
+		SSLMode:  appConf.Get(constants.APP_DB_SSL_MODE),
+	}
+}
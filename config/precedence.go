@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WithEnvPrecedence makes the environment variables take precedence over the envfiles, e.g. when the container
+// runtime injects the environment and the envfiles only hold the local defaults. The envfile entries only set the
+// variables missing from the environment, later envfiles still take precedence over earlier ones.
+// Without this option the envfiles overload the environment variables.
+func WithEnvPrecedence() SetupOption {
+	return func(o *setupOptions) {
+		o.envPrecedence = true
+	}
+}
+
+// underloadEnv sets the variables of the envfiles which are missing from the environment, and drops the others
+// from env, so the environment takes precedence. The variables set by an earlier Setup are updated (or unset if
+// the envfiles do not set them anymore), so a Reload follows the changes of the envfiles.
+func (appConf *AppConfig) underloadEnv(env envLookup) error {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	for name, set := range appConf.envfileEnv {
+		if _, ok := env.envfiles[name]; !ok && os.Getenv(name) == set {
+			if err := os.Unsetenv(name); err != nil {
+				return errors.Wrapf(err, "Failed to unset %s", name)
+			}
+			delete(appConf.envfileEnv, name)
+		}
+	}
+	if appConf.envfileEnv == nil {
+		appConf.envfileEnv = map[string]string{}
+	}
+	for name, value := range env.envfiles {
+		// A variable set by someone else (not by an envfile) takes precedence
+		if current, ok := os.LookupEnv(name); ok {
+			if set, fromEnvfile := appConf.envfileEnv[name]; !fromEnvfile || set != current {
+				delete(env.envfiles, name)
+				delete(env.origins, name)
+				continue
+			}
+		}
+		if err := os.Setenv(name, value); err != nil {
+			return errors.Wrapf(err, "Failed to set %s from %s", name, env.origins[name])
+		}
+		appConf.envfileEnv[name] = value
+	}
+	return nil
+}
+
+// envLookup looks up the environment variables overlaid with the variables set from the envfiles, which
+// record the envfile of every variable.
+type envLookup struct {
+	// envfiles are the variables set from the envfiles, and origins the envfile of every variable
+	envfiles map[string]string
+	origins  map[string]string
+}
+
+// lookup returns the value of the environment variable and its origin, an empty string if it is not set.
+func (env envLookup) lookup(name string) (string, string) {
+	if value, ok := env.envfiles[name]; ok {
+		return value, env.origins[name]
+	}
+	if current, ok := os.LookupEnv(name); ok {
+		return current, OriginEnvironment
+	}
+	return "", ""
+}
+
+// names returns the names of the environment variables and the variables of the envfiles.
+func (env envLookup) names() []string {
+	names := []string{}
+	for _, variable := range os.Environ() {
+		name := strings.SplitN(variable, "=", 2)[0]
+		if _, ok := env.envfiles[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	for name := range env.envfiles {
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,81 @@
+package config
+
+import (
+	"os"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestEnvPrecedence() {
+	testCases := map[string]struct {
+		opts     []SetupOption
+		port     string
+		logLevel string
+	}{
+		"envfiles overload the environment": {
+			port:     "9090",
+			logLevel: constants.LOG_LEVEL_WARN,
+		},
+		"environment takes precedence": {
+			opts:     []SetupOption{WithEnvPrecedence()},
+			port:     "7070",
+			logLevel: constants.LOG_LEVEL_WARN,
+		},
+	}
+
+	for name, tc := range testCases {
+		envFile := cts.setupEnvTest(constants.BasicEnvs...)
+		cts.writeEnvfile(envFile, map[string]string{
+			constants.APP_PORT:      "9090",
+			constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN,
+		})
+		cts.setEnvVars(map[string]string{constants.APP_PORT: "7070"})
+
+		conf := NewConfig(cts.getDefaultConfigs())
+		cts.NoError(conf.SetupWithOptions(append(tc.opts, WithEnvfiles(envFile))...), name)
+		cts.Equal(tc.port, conf.Port(), name)
+		cts.Equal(tc.logLevel, conf.LogLevel(), "%s: the missing variables should be read from the envfile", name)
+		cts.Equal("envfile "+envFile, conf.Source(constants.APP_LOG_LEVEL), name)
+		cts.Equal(tc.port, os.Getenv(constants.APP_PORT), "%s: the environment should follow the precedence", name)
+		cts.Equal(constants.LOG_LEVEL_WARN, os.Getenv(constants.APP_LOG_LEVEL), "%s: the envfiles should set the environment", name)
+		cts.NoError(os.Unsetenv(constants.APP_LOG_LEVEL), "Environment variable should have been unset")
+
+		cts.NoErrorf(os.Remove(envFile), "Temp envfile (%s) should have been removed", envFile)
+	}
+
+	cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	cts.NoError(os.Unsetenv(constants.APP_LOG_LEVEL), "Environment variable should have been unset")
+}
+
+func (cts *ConfigTestSuite) TestEnvPrecedenceReload() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer func(fileName string) {
+		cts.NoErrorf(os.Remove(fileName), "Temp envfile (%s) should have been removed", fileName)
+		cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+		cts.NoError(os.Unsetenv(constants.APP_LOG_LEVEL), "Environment variable should have been unset")
+	}(envFile)
+	cts.writeEnvfile(envFile, map[string]string{constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN})
+	cts.setEnvVars(map[string]string{constants.APP_PORT: "7070"})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupWithOptions(WithEnvPrecedence(), WithEnvfiles(envFile)))
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel())
+	cts.Equal(OriginEnvironment, conf.Source(constants.APP_PORT))
+
+	cts.NoError(os.Truncate(envFile, 0))
+	cts.writeEnvfile(envFile, map[string]string{
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_ERROR,
+		constants.APP_PORT:      "9090",
+	})
+	cts.NoError(conf.Reload())
+	cts.Equal(constants.LOG_LEVEL_ERROR, conf.LogLevel(), "Variables set from the envfile should follow the envfile")
+	cts.Equal("7070", conf.Port(), "The environment should still take precedence")
+	cts.Equal("envfile "+envFile, conf.Source(constants.APP_LOG_LEVEL))
+	cts.Equal(constants.LOG_LEVEL_ERROR, os.Getenv(constants.APP_LOG_LEVEL), "The environment should follow the envfile")
+
+	cts.NoError(os.Truncate(envFile, 0))
+	cts.NoError(conf.Reload())
+	_, ok := os.LookupEnv(constants.APP_LOG_LEVEL)
+	cts.False(ok, "Variables removed from the envfile should be unset")
+	cts.Equal(OriginDefault, conf.Source(constants.APP_LOG_LEVEL))
+}
@@ -0,0 +1,80 @@
+package config
+
+import (
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// ProxyGroup is the Group of the ProxyVariables.
+const ProxyGroup = "Proxy"
+
+// ProxyVariables returns the standard Variables of the outbound HTTP proxy (APP_HTTP_PROXY, APP_HTTPS_PROXY
+// and APP_NO_PROXY), to be added to the defaults of NewConfig or registered with AddVariable.
+// They default to the conventional HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables (or their
+// lowercase variants), so the services behave like the other tools behind a corporate proxy.
+// The proxy of the requests is selected by ProxyFunc.
+func ProxyVariables() map[string]*Variable {
+	return map[string]*Variable{
+		constants.APP_HTTP_PROXY: {
+			DefaultFunc: standardEnv("HTTP_PROXY"),
+			Description: "Proxy URL of the outbound HTTP requests, defaults to HTTP_PROXY",
+			Group:       ProxyGroup,
+			Sensitive:   true,
+			Rules: map[string]validation.Rule{
+				"Valid URL": is.URL,
+			},
+		},
+		constants.APP_HTTPS_PROXY: {
+			DefaultFunc: standardEnv("HTTPS_PROXY"),
+			Description: "Proxy URL of the outbound HTTPS requests, defaults to HTTPS_PROXY",
+			Group:       ProxyGroup,
+			Sensitive:   true,
+			Rules: map[string]validation.Rule{
+				"Valid URL": is.URL,
+			},
+		},
+		constants.APP_NO_PROXY: {
+			DefaultFunc: standardEnv("NO_PROXY"),
+			Description: "Comma-separated hosts, domains and CIDRs reached without the proxy, defaults to NO_PROXY",
+			Group:       ProxyGroup,
+		},
+	}
+}
+
+// standardEnv returns a DefaultFunc reading the conventional environment variable, or its lowercase variant.
+func standardEnv(name string) func() (string, error) {
+	return func() (string, error) {
+		if value := os.Getenv(name); value != "" {
+			return value, nil
+		}
+		return os.Getenv(strings.ToLower(name)), nil
+	}
+}
+
+// ProxyFunc returns the proxy selector of the outbound requests built from the ProxyVariables, to be used as
+// the Proxy of an http.Transport. Like http.ProxyFromEnvironment, the requests to localhost are not proxied.
+// The proxy URLs may contain credentials, which are masked in the dumps.
+func (appConf *AppConfig) ProxyFunc() func(*http.Request) (*url.URL, error) {
+	proxyFunc := (&httpproxy.Config{
+		HTTPProxy:  appConf.Get(constants.APP_HTTP_PROXY),
+		HTTPSProxy: appConf.Get(constants.APP_HTTPS_PROXY),
+		NoProxy:    appConf.Get(constants.APP_NO_PROXY),
+	}).ProxyFunc()
+	return func(req *http.Request) (*url.URL, error) {
+		return proxyFunc(req.URL)
+	}
+}
+
+// HTTPTransport returns a copy of http.DefaultTransport, which selects the proxy by ProxyFunc.
+func (appConf *AppConfig) HTTPTransport() *http.Transport {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = appConf.ProxyFunc()
+	return transport
+}
@@ -0,0 +1,29 @@
+package config
+
+// ConfigReader is the read-only view of the configuration, satisfied by *AppConfig and StaticGetter.
+// Depend on it instead of *AppConfig in the packages which only read the configuration, so they can be
+// tested with a StaticGetter or a mock.
+type ConfigReader interface {
+	// Get returns the named value, or an empty string.
+	Get(name string) string
+
+	// Lookup returns the named value, and a boolean indicating if it was found.
+	Lookup(name string) (string, bool)
+
+	// Env returns the environment of the application (APP_ENV).
+	Env() string
+
+	// IsDebug returns true if debug mode (APP_DEBUG) is enabled.
+	IsDebug() bool
+
+	// IsProduction returns true if the environment is production.
+	IsProduction() bool
+
+	// Hostname returns the hostname of the machine where the application is running.
+	Hostname() string
+}
+
+var (
+	_ ConfigReader = (*AppConfig)(nil)
+	_ ConfigReader = StaticGetter(nil)
+)
@@ -0,0 +1,47 @@
+package config
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// RedactKeepLast returns a Redact function which masks all but the last n characters of the values,
+// e.g. "*****c0ffee" with n = 6. The values not longer than 2*n characters are fully masked, so the short
+// secrets are not revealed.
+func RedactKeepLast(n int) func(value string) string {
+	return func(value string) string {
+		runes := []rune(value)
+		if len(runes) <= 2*n {
+			return MaskedValue
+		}
+		return MaskedValue + string(runes[len(runes)-n:])
+	}
+}
+
+// Display returns the value of the named Variable as it can be shown to humans: redacted by the Redact
+// function of the Variable, or masked if the Variable is Sensitive. It returns an empty string for the
+// unknown Variables.
+func (appConf *AppConfig) Display(name string) string {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	if name, ok := appConf.variableName(name); ok {
+		confVar := appConf.vars[name]
+		return confVar.display(confVar.Value)
+	}
+	return ""
+}
+
+// LogFields returns the displayed values (see Display) of the named Variables, keyed by their names,
+// to enrich the log entries, e.g. logger.WithFields(conf.LogFields(constants.APP_DB_HOST)).
+// The unknown Variables are skipped.
+func (appConf *AppConfig) LogFields(names ...string) logrus.Fields {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	fields := logrus.Fields{}
+	for _, name := range names {
+		if name, ok := appConf.variableName(name); ok {
+			confVar := appConf.vars[name]
+			fields[appConf.externalName(name)] = confVar.display(confVar.Value)
+		}
+	}
+	return fields
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/sirupsen/logrus"
+)
+
+func (cts *ConfigTestSuite) TestRedact() {
+	redact := RedactKeepLast(4)
+	cts.Equal("*****cdef", redact("0123456789abcdef"))
+	cts.Equal(MaskedValue, redact("12345678"), "Short values should be fully masked")
+	cts.Equal("*****éééé", redact("ééééééééé"), "Characters should be kept, not bytes")
+
+	for _, name := range []string{"APP_API_KEY", "APP_PASSWORD", "APP_REGION"} {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	conf := NewConfig(map[string]*Variable{
+		"APP_API_KEY": {
+			DefaultValue: "sk-default-000000",
+			Sensitive:    true,
+			Redact:       redact,
+			Rules:        map[string]validation.Rule{"Length": validation.Length(16, 32)},
+		},
+		"APP_PASSWORD": {DefaultValue: "s3cr3t", Sensitive: true},
+		"APP_REGION":   {DefaultValue: "eu-west-1"},
+	})
+	cts.NoError(conf.Setup())
+	cts.Equal("sk-default-000000", conf.Get("APP_API_KEY"), "Get should return the original value")
+	cts.Equal("*****0000", conf.Display("APP_API_KEY"))
+	cts.Equal(MaskedValue, conf.Display("APP_PASSWORD"))
+	cts.Empty(conf.Display("APP_UNKNOWN"))
+	cts.Equal(logrus.Fields{"APP_API_KEY": "*****0000", "APP_REGION": "eu-west-1"}, conf.LogFields("APP_API_KEY", "APP_REGION", "APP_UNKNOWN"))
+	cts.Contains(conf.DumpTable(), "*****0000", "The dump should show the redacted default")
+	cts.NotContains(conf.DumpTable(), "sk-default")
+	content, err := conf.Dump(DumpFormatJSON)
+	cts.NoError(err)
+	cts.Contains(content, `"default_value": "*****",`, "The JSON dump should not be redacted")
+
+	redacted := NewConfig(map[string]*Variable{"APP_REGION": {DefaultValue: "eu-west-1", Redact: redact}})
+	sampleFile := filepath.Join(cts.T().TempDir(), ".env.sample")
+	cts.NoError(redacted.CreateSampleFile(sampleFile))
+	sample, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err)
+	cts.Contains(string(sample), "APP_REGION=eu-west-1\n", "The sample file should have the real default")
+
+	cts.EqualError(conf.Set("APP_API_KEY", "sk-short"), "Invalid value for APP_API_KEY = *****: Length: the length must be between 16 and 32.")
+	cts.NoError(conf.Set("APP_API_KEY", "sk-live-1234567890abcd"))
+	recorder := httptest.NewRecorder()
+	conf.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/config?format=json", nil))
+	rows := []map[string]interface{}{}
+	cts.NoError(json.Unmarshal(recorder.Body.Bytes(), &rows), "Response should be valid JSON")
+	cts.Equal("*****abcd", rows[0]["value"], "The handler should show the redacted value")
+}
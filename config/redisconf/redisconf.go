@@ -0,0 +1,91 @@
+// Package redisconf declares the standard Variables of a Redis connection and builds the options of a go-redis
+// client from them, so the config package does not depend on go-redis:
+//
+//	conf := config.NewConfig(redisconf.Variables())
+//	client := redis.NewClient(redisconf.Options(conf))
+package redisconf
+
+import (
+	"crypto/tls"
+	"net"
+	"strconv"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/redis/go-redis/v9"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// Group is the Group of the Variables.
+const Group = "Redis"
+
+// Variables returns the standard Variables of a Redis connection (APP_REDIS_ADDR, APP_REDIS_PASSWORD,
+// APP_REDIS_DB, APP_REDIS_TLS and APP_REDIS_POOL_SIZE), to be added to the defaults of config.NewConfig
+// or registered with AddVariable. The options of the client are built by Options.
+func Variables() map[string]*config.Variable {
+	return map[string]*config.Variable{
+		constants.APP_REDIS_ADDR: {
+			DefaultValue: "localhost:6379",
+			Description:  "Address (host:port) of the Redis server",
+			Group:        Group,
+			Rules: map[string]validation.Rule{
+				"Required":            validation.Required,
+				"Valid host and port": is.DialString,
+			},
+		},
+		constants.APP_REDIS_PASSWORD: {
+			Description: "Password of the Redis server",
+			Group:       Group,
+			NoExpand:    true,
+			Sensitive:   true,
+		},
+		constants.APP_REDIS_DB: {
+			DefaultValue: "0",
+			Description:  "Index of the Redis database",
+			Group:        Group,
+			Rules: map[string]validation.Rule{
+				"Non-negative integer": is.Digit,
+			},
+		},
+		constants.APP_REDIS_TLS: {
+			DefaultValue: "0",
+			Description:  "Connect to the Redis server with TLS",
+			Group:        Group,
+			Rules: map[string]validation.Rule{
+				"Truthy value": validation.In(constants.TruthyValues...),
+			},
+		},
+		constants.APP_REDIS_POOL_SIZE: {
+			DefaultValue: "0",
+			Description:  "Maximum number of connections, 0 means 10 per CPU",
+			Group:        Group,
+			Rules: map[string]validation.Rule{
+				"Non-negative integer": is.Digit,
+			},
+		},
+	}
+}
+
+// Options returns the options of a go-redis client built from the Variables of conf.
+// With APP_REDIS_TLS enabled the server certificate is verified against the host of APP_REDIS_ADDR.
+func Options(conf *config.AppConfig) *redis.Options {
+	addr := conf.Get(constants.APP_REDIS_ADDR)
+	// The values are validated by the Setup, the invalid ones fall back to the defaults of go-redis
+	db, _ := strconv.Atoi(conf.Get(constants.APP_REDIS_DB))
+	poolSize, _ := strconv.Atoi(conf.Get(constants.APP_REDIS_POOL_SIZE))
+	options := &redis.Options{
+		Addr:     addr,
+		Password: conf.Get(constants.APP_REDIS_PASSWORD),
+		DB:       db,
+		PoolSize: poolSize,
+	}
+	if useTLS, _ := strconv.ParseBool(conf.Get(constants.APP_REDIS_TLS)); useTLS {
+		host, _, _ := net.SplitHostPort(addr)
+		options.TLSConfig = &tls.Config{
+			MinVersion: tls.VersionTLS12,
+			ServerName: host,
+		}
+	}
+	return options
+}
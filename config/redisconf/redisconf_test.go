@@ -0,0 +1,59 @@
+package redisconf
+
+import (
+	"os"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// RedisConfSuite extends testify's Suite.
+type RedisConfSuite struct {
+	suite.Suite
+}
+
+// TearDownTest unsets the Variables set by the tests.
+func (rs *RedisConfSuite) TearDownTest() {
+	for name := range Variables() {
+		rs.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+}
+
+func (rs *RedisConfSuite) TestOptions() {
+	rs.TearDownTest()
+	conf := config.NewConfig(Variables())
+	rs.NoError(conf.Setup())
+	options := Options(conf)
+	rs.Equal("localhost:6379", options.Addr)
+	rs.Equal(0, options.DB)
+	rs.Nil(options.TLSConfig, "TLS should be disabled by default")
+
+	for name, value := range map[string]string{
+		constants.APP_REDIS_ADDR:      "cache.internal:6380",
+		constants.APP_REDIS_PASSWORD:  "s3cr3t",
+		constants.APP_REDIS_DB:        "2",
+		constants.APP_REDIS_TLS:       "true",
+		constants.APP_REDIS_POOL_SIZE: "50",
+	} {
+		rs.NoError(os.Setenv(name, value))
+	}
+	rs.NoError(conf.Setup())
+	options = Options(conf)
+	rs.Equal("cache.internal:6380", options.Addr)
+	rs.Equal("s3cr3t", options.Password)
+	rs.Equal(2, options.DB)
+	rs.Equal(50, options.PoolSize)
+	rs.Require().NotNil(options.TLSConfig, "TLS should be enabled")
+	rs.Equal("cache.internal", options.TLSConfig.ServerName)
+
+	rs.NoError(os.Setenv(constants.APP_REDIS_DB, "-1"))
+	rs.EqualError(errors.Cause(conf.Setup()), "APP_REDIS_DB = -1: (Non-negative integer: must contain digits only.).")
+}
+
+// TestRedisConf runs the whole test suite
+func TestRedisConf(t *testing.T) {
+	suite.Run(t, new(RedisConfSuite))
+}
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// ValidationIssue is a single failed validation rule of a ValidationReport.
+type ValidationIssue struct {
+	// Variable is the name of the invalid Variable, empty for the Constraints and validators.
+	Variable string `json:"variable"`
+
+	// Value is the invalid value, masked if the Variable is Sensitive.
+	Value string `json:"value"`
+
+	// Rule is the name of the failed rule, Constraint or validator.
+	Rule string `json:"rule"`
+
+	// Message describes the failure.
+	Message string `json:"message"`
+}
+
+// ValidationReport is the list of the validation failures, sorted by Variable and Rule.
+// It can be encoded to JSON, e.g. for CI checks or a health endpoint.
+type ValidationReport []ValidationIssue
+
+// ValidationReport validates the current values like Validate, and returns the failures in a structured form
+// instead of an error. The report is empty if the configuration is valid.
+func (appConf *AppConfig) ValidationReport() ValidationReport {
+	appConf.mu.RLock()
+	values := appConf.values()
+	appConf.mu.RUnlock()
+	return appConf.validationReport(values)
+}
+
+// validationReport validates values and returns the failures, see ValidationReport.
+// The caller must not hold the lock, see validateConstraints.
+func (appConf *AppConfig) validationReport(values map[string]string) ValidationReport {
+	_, report := appConf.validate(values)
+	return report
+}
+
+// validate runs the validation rules of the Variables and the Constraints once on values, and returns the
+// failures both as the unified errors of validateValues and as the report of validationReport.
+// The caller must not hold the lock, see validateConstraints.
+func (appConf *AppConfig) validate(values map[string]string) (validation.Errors, ValidationReport) {
+	appConf.mu.RLock()
+	allErrors, report := appConf.validateVars(values)
+	appConf.mu.RUnlock()
+
+	constraintErrors := map[string]error{}
+	appConf.validateConstraints(values, constraintErrors)
+	for rule, err := range constraintErrors {
+		allErrors[rule] = err
+		report = append(report, ValidationIssue{Rule: rule, Message: err.Error()})
+	}
+
+	// Sort is needed because maps always return values in random order
+	sort.Slice(report, func(i, j int) bool {
+		if report[i].Variable != report[j].Variable {
+			return report[i].Variable < report[j].Variable
+		}
+		return report[i].Rule < report[j].Rule
+	})
+	return allErrors, report
+}
+
+// validateVars runs the validation rules of the Variables on values, see validate.
+// The caller must hold the lock.
+func (appConf *AppConfig) validateVars(values map[string]string) (validation.Errors, ValidationReport) {
+	allErrors := validation.Errors{}
+	report := ValidationReport{}
+	for name, confVar := range appConf.vars {
+		value := values[name]
+		validationErrors := confVar.validate(value, values)
+		if len(validationErrors) == 0 {
+			continue
+		}
+		allErrors[fmt.Sprintf("%s = %s", appConf.externalName(name), confVar.display(value))] = validationErrors.Filter()
+		for rule, err := range validationErrors {
+			report = append(report, ValidationIssue{
+				Variable: appConf.externalName(name),
+				Value:    confVar.display(value),
+				Rule:     rule,
+				Message:  err.Error(),
+			})
+		}
+	}
+	return allErrors, report
+}
@@ -0,0 +1,51 @@
+package config
+
+import (
+	"encoding/json"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+func (cts *ConfigTestSuite) TestValidationReport() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_PORT": {
+			Rules: map[string]validation.Rule{
+				"Required":   validation.Required,
+				"Valid port": is.Port,
+			},
+		},
+		"APP_PASSWORD": {
+			Rules:     map[string]validation.Rule{"Min length": validation.Length(8, 0)},
+			Sensitive: true,
+		},
+		"APP_TLS_CERT": {},
+	})
+	conf.AddConstraints(Constraint{
+		Name: "TLS pair",
+		Validate: func(values map[string]string) error {
+			if values["APP_TLS_CERT"] == "" {
+				return errors.New("cert must be set")
+			}
+			return nil
+		},
+	})
+	conf.Override(map[string]string{"APP_PORT": "http", "APP_PASSWORD": "s3cr3t"})
+
+	report := conf.ValidationReport()
+	cts.Equal(ValidationReport{
+		{Rule: "TLS pair", Message: "cert must be set"},
+		{Variable: "APP_PASSWORD", Value: MaskedValue, Rule: "Min length", Message: "the length must be no less than 8"},
+		{Variable: "APP_PORT", Value: "http", Rule: "Valid port", Message: "must be a valid port number"},
+	}, report)
+
+	content, err := json.Marshal(report)
+	cts.NoError(err)
+	cts.Contains(string(content), `{"variable":"APP_PORT","value":"http","rule":"Valid port","message":"must be a valid port number"}`)
+
+	conf.Override(map[string]string{"APP_PORT": "8080", "APP_PASSWORD": "correct horse", "APP_TLS_CERT": "cert.pem"})
+	content, err = json.Marshal(conf.ValidationReport())
+	cts.NoError(err)
+	cts.Equal("[]", string(content), "Valid configuration should have an empty report")
+}
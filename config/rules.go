@@ -0,0 +1,59 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// valuesKey is the context key of the values of the Variables during the validation.
+type valuesKey struct{}
+
+// withValues returns a copy of ctx carrying the values of the Variables.
+func withValues(ctx context.Context, values map[string]string) context.Context {
+	return context.WithValue(ctx, valuesKey{}, values)
+}
+
+// ValuesFromContext returns the values of every Variable by name, available to the context-aware rules
+// (see validation.WithContext) during the validation. It returns nil outside the validation of an AppConfig.
+func ValuesFromContext(ctx context.Context) map[string]string {
+	values, _ := ctx.Value(valuesKey{}).(map[string]string)
+	return values
+}
+
+// RequiredIf returns a rule which requires the value only if the named Variable has any of the listed values.
+//
+//	Rules: map[string]validation.Rule{
+//	    "Required with TLS": config.RequiredIf("APP_TLS_ENABLED", "true", "1"),
+//	}
+func RequiredIf(name string, values ...string) validation.Rule {
+	message := fmt.Sprintf("cannot be blank when %s is %s", name, strings.Join(values, " or "))
+	return validation.WithContext(func(ctx context.Context, value interface{}) error {
+		other, ok := ValuesFromContext(ctx)[name]
+		if !ok || !contains(values, other) {
+			return nil
+		}
+		if err := validation.Required.Validate(value); err != nil {
+			return validation.NewError("validation_required_if", message)
+		}
+		return nil
+	})
+}
+
+// RequiredWhenEnv returns a rule which requires the value only in the listed environments (APP_ENV).
+func RequiredWhenEnv(envs ...string) validation.Rule {
+	return RequiredIf(constants.APP_ENV, envs...)
+}
+
+// contains returns true if value is in values.
+func contains(values []string, value string) bool {
+	for _, val := range values {
+		if val == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,130 @@
+// Package rules provides reusable ozzo-validation rules for the configuration Variables.
+// Like the rules of ozzo-validation, they accept empty values, combine them with validation.Required
+// if the Variable is mandatory.
+//
+//	Rules: map[string]validation.Rule{
+//	    "Required":       validation.Required,
+//	    "Valid duration": rules.Duration,
+//	}
+package rules
+
+import (
+	"io/ioutil"
+	"net"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/config"
+)
+
+var (
+	// ErrDuration is the error returned by Duration.
+	ErrDuration = validation.NewError("validation_config_duration", "must be a valid duration (e.g. 300ms, 30s, 1h30m)")
+
+	// ErrURL is the error returned by URL.
+	ErrURL = validation.NewError("validation_config_url", "must be an absolute URL with a scheme and a host")
+
+	// ErrCIDR is the error returned by CIDR.
+	ErrCIDR = validation.NewError("validation_config_cidr", "must be a valid CIDR notation IP address and prefix length (e.g. 10.0.0.0/8)")
+
+	// ErrHostPort is the error returned by HostPort.
+	ErrHostPort = validation.NewError("validation_config_host_port", "must be a host:port pair with a valid port number")
+
+	// ErrFileExists is the error returned by FileExists.
+	ErrFileExists = validation.NewError("validation_config_file_exists", "must be the path of an existing file")
+
+	// ErrByteSize is the error returned by ByteSize.
+	ErrByteSize = validation.NewError("validation_config_byte_size", "must be a valid byte size (e.g. 512, 64KB, 512MB, 2GiB)")
+
+	// ErrFalsy is the error returned by Falsy.
+	ErrFalsy = validation.NewError("validation_config_falsy", "must be a false boolean (e.g. false, 0)")
+
+	// ErrWritableDir is the error returned by WritableDir.
+	ErrWritableDir = validation.NewError("validation_config_writable_dir", "must be the path of an existing, writable directory")
+)
+
+var (
+	// Duration validates if a string can be parsed by time.ParseDuration.
+	Duration = stringRule(func(value string) bool {
+		_, err := time.ParseDuration(value)
+		return err == nil
+	}, ErrDuration)
+
+	// ByteSize validates if a string can be parsed by config.ParseByteSize.
+	ByteSize = stringRule(func(value string) bool {
+		_, err := config.ParseByteSize(value)
+		return err == nil
+	}, ErrByteSize)
+
+	// URL validates if a string is an absolute URL, with a scheme and a host (e.g. https://example.com/path).
+	URL = stringRule(func(value string) bool {
+		parsed, err := url.Parse(value)
+		return err == nil && parsed.Scheme != "" && parsed.Host != ""
+	}, ErrURL)
+
+	// CIDR validates if a string is a CIDR notation IP address and prefix length (e.g. 192.168.0.0/16).
+	CIDR = stringRule(func(value string) bool {
+		_, _, err := net.ParseCIDR(value)
+		return err == nil
+	}, ErrCIDR)
+
+	// HostPort validates if a string is a host:port pair (e.g. localhost:5432, [::1]:80),
+	// the host may be empty (e.g. :8080), the port must be a number between 1 and 65535.
+	HostPort = stringRule(func(value string) bool {
+		_, port, err := net.SplitHostPort(value)
+		if err != nil {
+			return false
+		}
+		number, err := strconv.Atoi(port)
+		return err == nil && number > 0 && number <= 65535
+	}, ErrHostPort)
+
+	// Falsy validates if a string is a false boolean, e.g. to forbid a debug mode in production. An empty value
+	// is accepted, so the optional Variables can be left unset.
+	Falsy = stringRule(func(value string) bool {
+		parsed, err := strconv.ParseBool(value)
+		return err == nil && !parsed
+	}, ErrFalsy)
+
+	// FileExists validates if a string is the path of an existing regular file.
+	FileExists = stringRule(func(value string) bool {
+		info, err := os.Stat(value)
+		return err == nil && info.Mode().IsRegular()
+	}, ErrFileExists)
+
+	// WritableDir validates if a string is the path of an existing directory where files can be created.
+	WritableDir = stringRule(func(value string) bool {
+		info, err := os.Stat(value)
+		if err != nil || !info.IsDir() {
+			return false
+		}
+		// Creating a file is the only portable way to check the permissions
+		file, err := ioutil.TempFile(value, ".writable-*")
+		if err != nil {
+			return false
+		}
+		file.Close()
+		return os.Remove(file.Name()) == nil
+	}, ErrWritableDir)
+)
+
+// stringRule creates a rule which checks the non-empty string values with valid.
+func stringRule(valid func(value string) bool, err validation.Error) validation.Rule {
+	return validation.By(func(value interface{}) error {
+		// The empty values (e.g. "" and nil) are skipped, the same as by the rules of ozzo-validation
+		if validation.IsEmpty(value) {
+			return nil
+		}
+		str, ensureErr := validation.EnsureString(value)
+		if ensureErr != nil {
+			return ensureErr
+		}
+		if valid(str) {
+			return nil
+		}
+		return err
+	})
+}
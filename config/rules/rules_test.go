@@ -0,0 +1,113 @@
+package rules
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// RulesSuite extends testify's Suite.
+type RulesSuite struct {
+	suite.Suite
+}
+
+func (rs *RulesSuite) TestRules() {
+	dir := rs.T().TempDir()
+	file := filepath.Join(dir, "cert.pem")
+	rs.NoError(ioutil.WriteFile(file, []byte("cert"), 0600))
+	readOnly := filepath.Join(dir, "readonly")
+	rs.NoError(os.Mkdir(readOnly, 0500))
+
+	testCases := map[string]struct {
+		rule    validation.Rule
+		valid   []string
+		invalid []string
+		err     string
+	}{
+		"Duration": {
+			rule:    Duration,
+			valid:   []string{"", "300ms", "30s", "1h30m"},
+			invalid: []string{"30", "1 hour", "s"},
+			err:     "must be a valid duration (e.g. 300ms, 30s, 1h30m)",
+		},
+		"ByteSize": {
+			rule:    ByteSize,
+			valid:   []string{"", "512", "64KB", "512 mb", "2GiB"},
+			invalid: []string{"MB", "-1MB", "2 gigabytes", "1.5GB"},
+			err:     "must be a valid byte size (e.g. 512, 64KB, 512MB, 2GiB)",
+		},
+		"URL": {
+			rule:    URL,
+			valid:   []string{"", "https://example.com", "postgres://user:pass@db:5432/app?sslmode=disable"},
+			invalid: []string{"example.com", "/path", "https://", "::"},
+			err:     "must be an absolute URL with a scheme and a host",
+		},
+		"CIDR": {
+			rule:    CIDR,
+			valid:   []string{"", "10.0.0.0/8", "2001:db8::/32"},
+			invalid: []string{"10.0.0.1", "10.0.0.0/33", "net"},
+			err:     "must be a valid CIDR notation IP address and prefix length (e.g. 10.0.0.0/8)",
+		},
+		"HostPort": {
+			rule:    HostPort,
+			valid:   []string{"", "localhost:5432", ":8080", "[::1]:80"},
+			invalid: []string{"localhost", "localhost:0", "localhost:http", "host:70000"},
+			err:     "must be a host:port pair with a valid port number",
+		},
+		"Falsy": {
+			rule:    Falsy,
+			valid:   []string{"", "false", "0", "F"},
+			invalid: []string{"true", "1", "off"},
+			err:     "must be a false boolean (e.g. false, 0)",
+		},
+		"FileExists": {
+			rule:    FileExists,
+			valid:   []string{"", file},
+			invalid: []string{filepath.Join(dir, "missing.pem"), dir},
+			err:     "must be the path of an existing file",
+		},
+		"WritableDir": {
+			rule:    WritableDir,
+			valid:   []string{"", dir},
+			invalid: []string{file, filepath.Join(dir, "missing")},
+			err:     "must be the path of an existing, writable directory",
+		},
+	}
+
+	for name, tc := range testCases {
+		for _, value := range tc.valid {
+			rs.NoErrorf(tc.rule.Validate(value), "%s should accept %q", name, value)
+		}
+		for _, value := range tc.invalid {
+			rs.EqualErrorf(tc.rule.Validate(value), tc.err, "%s should reject %q", name, value)
+		}
+	}
+
+	if os.Geteuid() != 0 {
+		rs.Error(WritableDir.Validate(readOnly), "Read-only directory should be rejected")
+	}
+	rs.Error(Duration.Validate(42), "Non-string values should be rejected")
+	rs.NoError(Falsy.Validate(nil), "Nil values should be skipped")
+
+	conf := config.NewConfig(map[string]*config.Variable{
+		constants.APP_ENV: {DefaultValue: constants.ENV_PRODUCTION},
+		constants.APP_DEBUG: {
+			RulesByEnv: map[string]map[string]validation.Rule{
+				constants.ENV_PRODUCTION: {"Disabled in production": Falsy},
+			},
+		},
+	})
+	conf.Override(map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION})
+	rs.NoError(conf.Validate(), "Optional Variables should not be checked when empty")
+}
+
+// TestRules runs the whole test suite
+func TestRules(t *testing.T) {
+	suite.Run(t, new(RulesSuite))
+}
@@ -0,0 +1,44 @@
+package config
+
+import (
+	"context"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestRequiredIf() {
+	conf := NewConfig(map[string]*Variable{
+		constants.APP_ENV: {DefaultValue: constants.ENV_DEV},
+		constants.APP_DB_SECRET_NAME: {
+			Rules: map[string]validation.Rule{
+				"Required in production": RequiredWhenEnv(constants.ENV_PRODUCTION, constants.ENV_STAGING),
+			},
+		},
+		"APP_TLS_ENABLED": {},
+		"APP_TLS_CERT": {
+			Rules: map[string]validation.Rule{
+				"Required with TLS": RequiredIf("APP_TLS_ENABLED", "true"),
+			},
+		},
+	})
+	conf.Override(map[string]string{constants.APP_ENV: constants.ENV_DEV})
+	cts.NoError(conf.Validate(), "Secret name should not be required in dev")
+
+	conf.Override(map[string]string{constants.APP_ENV: constants.ENV_PRODUCTION, "APP_TLS_ENABLED": "true"})
+	errs := conf.ValidationErrors()
+	cts.Len(errs, 2)
+	cts.EqualError(errs["APP_DB_SECRET_NAME = "], "Required in production: cannot be blank when APP_ENV is production or stage.")
+	cts.EqualError(errs["APP_TLS_CERT = "], "Required with TLS: cannot be blank when APP_TLS_ENABLED is true.")
+
+	cts.NoError(conf.Set(constants.APP_DB_SECRET_NAME, "prod/db"))
+	cts.Error(conf.Set(constants.APP_DB_SECRET_NAME, ""), "Set should validate against the other values")
+	cts.NoError(conf.Set("APP_TLS_CERT", "cert.pem"))
+	cts.NoError(conf.Validate())
+}
+
+func (cts *ConfigTestSuite) TestValuesFromContext() {
+	cts.Nil(ValuesFromContext(context.Background()))
+	rule := RequiredIf("APP_TLS_ENABLED", "true")
+	cts.NoError(rule.Validate(""), "Rule should be skipped outside of the AppConfig validation")
+}
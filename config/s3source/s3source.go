@@ -0,0 +1,186 @@
+// Package s3source provides a config.Source which loads an envfile, JSON or YAML document from AWS S3.
+// Use the New or NewFromEnv constructors to create the Source and pass it to config.WithSources
+// Use config.AppConfig.WatchSources to reload the configuration when the object's ETag changes
+package s3source
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsHttp "github.com/aws/smithy-go/transport/http"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/config/internal/poll"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// DefaultPollInterval is the interval of the ETag checks if no interval is set.
+const DefaultPollInterval = time.Minute
+
+// s3API is the part of the S3 client used by the Source.
+type s3API interface {
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error)
+}
+
+// Source loads the variables from a single S3 object.
+type Source struct {
+	// Bucket is the name of the S3 bucket.
+	Bucket string
+
+	// Key is the key of the S3 object.
+	Key string
+
+	// Format is the format of the object, detected from the Key's extension if empty.
+	Format config.Format
+
+	// KMSKeyID is the optional SSE-KMS key ID (or ARN), if set objects not encrypted with this key are rejected.
+	KMSKeyID string
+
+	// PollInterval is the interval of the ETag checks of Watch, DefaultPollInterval if zero.
+	PollInterval time.Duration
+
+	client s3API
+
+	// mu guards the cached etag and values
+	mu     sync.Mutex
+	etag   string
+	values map[string]string
+}
+
+// New creates a new Source which reads the object with the supplied S3 client.
+func New(client s3API, bucket, key string) *Source {
+	return &Source{
+		Bucket: bucket,
+		Key:    key,
+		client: client,
+	}
+}
+
+// NewFromEnv creates a new Source with the default AWS configuration and the environment variables
+// APP_CONFIG_S3_BUCKET, APP_CONFIG_S3_KEY, APP_CONFIG_S3_KMS_KEY_ID and APP_CONFIG_S3_POLL_INTERVAL.
+// The environment is read directly, as the Source is needed before the AppConfig is set up.
+func NewFromEnv(ctx context.Context) (*Source, error) {
+	bucket := os.Getenv(constants.APP_CONFIG_S3_BUCKET)
+	key := os.Getenv(constants.APP_CONFIG_S3_KEY)
+	if bucket == "" || key == "" {
+		return nil, errors.Errorf("Both %s and %s must be set", constants.APP_CONFIG_S3_BUCKET, constants.APP_CONFIG_S3_KEY)
+	}
+	awsConf, err := awsConfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load AWS configuration")
+	}
+	source := New(s3.NewFromConfig(awsConf), bucket, key)
+	source.KMSKeyID = os.Getenv(constants.APP_CONFIG_S3_KMS_KEY_ID)
+	if interval, err := time.ParseDuration(os.Getenv(constants.APP_CONFIG_S3_POLL_INTERVAL)); err == nil {
+		source.PollInterval = interval
+	}
+	return source, nil
+}
+
+// Name implements the config.Source interface.
+func (s *Source) Name() string {
+	return fmt.Sprintf("s3://%s/%s", s.Bucket, s.Key)
+}
+
+// Load implements the config.Source interface. The object is only downloaded again if its ETag has changed.
+func (s *Source) Load(ctx context.Context) (map[string]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	input := &s3.GetObjectInput{
+		Bucket: aws.String(s.Bucket),
+		Key:    aws.String(s.Key),
+	}
+	if s.etag != "" {
+		input.IfNoneMatch = aws.String(s.etag)
+	}
+
+	output, err := s.client.GetObject(ctx, input)
+	if isNotModified(err) {
+		return s.values, nil
+	}
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get %s", s.Name())
+	}
+	defer output.Body.Close()
+
+	if err := s.checkEncryption(output.ServerSideEncryption, aws.ToString(output.SSEKMSKeyId)); err != nil {
+		return nil, err
+	}
+
+	content, err := ioutil.ReadAll(output.Body)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read %s", s.Name())
+	}
+	format := s.Format
+	if format == "" {
+		format = config.FormatFromPath(s.Key)
+	}
+	values, err := config.ParseDocument(format, content)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse %s", s.Name())
+	}
+
+	s.etag = aws.ToString(output.ETag)
+	s.values = values
+	return values, nil
+}
+
+// Watch implements the config.WatchableSource interface, it polls the ETag of the object
+// and calls changed if it differs from the ETag of the last Load. The failed checks are passed to failed, and
+// the next check waits with a backoff.
+func (s *Source) Watch(ctx context.Context, changed func(), failed func(err error)) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	poll.Poll(ctx, interval, func() error {
+		output, err := s.client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket: aws.String(s.Bucket),
+			Key:    aws.String(s.Key),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to check %s", s.Name())
+		}
+		s.mu.Lock()
+		modified := aws.ToString(output.ETag) != s.etag
+		s.mu.Unlock()
+		if modified {
+			changed()
+		}
+		return nil
+	}, failed)
+	return ctx.Err()
+}
+
+// checkEncryption rejects the object if a KMS key is required, but the object is not encrypted with it.
+// S3 may report the key by ID or by ARN, so the suffix of the reported key is compared too.
+func (s *Source) checkEncryption(sse types.ServerSideEncryption, keyID string) error {
+	if s.KMSKeyID == "" {
+		return nil
+	}
+	if sse != types.ServerSideEncryptionAwsKms && sse != types.ServerSideEncryptionAwsKmsDsse {
+		return errors.Errorf("%s is not encrypted with SSE-KMS", s.Name())
+	}
+	if keyID != s.KMSKeyID && !strings.HasSuffix(keyID, "key/"+s.KMSKeyID) {
+		return errors.Errorf("%s is encrypted with an unexpected KMS key", s.Name())
+	}
+	return nil
+}
+
+// isNotModified reports whether the error is a 304 Not Modified response.
+func isNotModified(err error) bool {
+	var respErr *awsHttp.ResponseError
+	return errors.As(err, &respErr) && respErr.HTTPStatusCode() == http.StatusNotModified
+}
@@ -0,0 +1,189 @@
+package s3source
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	awsHttp "github.com/aws/smithy-go/transport/http"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// S3SourceSuite extends testify's Suite.
+type S3SourceSuite struct {
+	suite.Suite
+}
+
+// fakeS3 serves a single object from memory
+type fakeS3 struct {
+	mu      sync.Mutex
+	content string
+	etag    string
+	sse     types.ServerSideEncryption
+	kmsKey  string
+	gets    int
+	headErr error
+}
+
+func (f *fakeS3) GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.gets++
+	if aws.ToString(params.IfNoneMatch) == f.etag {
+		return nil, &awsHttp.ResponseError{
+			Response: &awsHttp.Response{Response: &http.Response{StatusCode: http.StatusNotModified}},
+			Err:      errors.New("not modified"),
+		}
+	}
+	return &s3.GetObjectOutput{
+		Body:                 ioutil.NopCloser(strings.NewReader(f.content)),
+		ETag:                 aws.String(f.etag),
+		ServerSideEncryption: f.sse,
+		SSEKMSKeyId:          aws.String(f.kmsKey),
+	}, nil
+}
+
+func (f *fakeS3) HeadObject(ctx context.Context, params *s3.HeadObjectInput, optFns ...func(*s3.Options)) (*s3.HeadObjectOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.headErr != nil {
+		return nil, f.headErr
+	}
+	return &s3.HeadObjectOutput{ETag: aws.String(f.etag)}, nil
+}
+
+func (ss *S3SourceSuite) TestLoad() {
+	client := &fakeS3{content: "APP_PORT=9090\nAPP_ENV=stage\n", etag: `"v1"`}
+	source := New(client, "bucket", "service/.env")
+	ss.Equal("s3://bucket/service/.env", source.Name())
+
+	values, err := source.Load(context.Background())
+	ss.NoError(err, "Object should have been loaded")
+	ss.Equal(map[string]string{"APP_PORT": "9090", "APP_ENV": "stage"}, values)
+
+	values, err = source.Load(context.Background())
+	ss.NoError(err, "Unmodified object should be served from the cache")
+	ss.Equal("9090", values["APP_PORT"])
+	ss.Equal(2, client.gets)
+
+	client.content = "app:\n  port: 7070\n"
+	client.etag = `"v2"`
+	source = New(client, "bucket", "service/config.yaml")
+	values, err = source.Load(context.Background())
+	ss.NoError(err, "YAML object should have been loaded")
+	ss.Equal("7070", values["APP_PORT"])
+}
+
+func (ss *S3SourceSuite) TestKMS() {
+	client := &fakeS3{content: `{"APP_PORT": 9090}`, etag: `"v1"`}
+	source := New(client, "bucket", "config.json")
+	source.KMSKeyID = "1234abcd"
+
+	_, err := source.Load(context.Background())
+	ss.EqualError(err, "s3://bucket/config.json is not encrypted with SSE-KMS")
+
+	client.sse = types.ServerSideEncryptionAwsKms
+	client.kmsKey = "arn:aws:kms:eu-west-1:123456789012:key/other"
+	_, err = source.Load(context.Background())
+	ss.EqualError(err, "s3://bucket/config.json is encrypted with an unexpected KMS key")
+
+	client.kmsKey = "arn:aws:kms:eu-west-1:123456789012:key/1234abcd"
+	values, err := source.Load(context.Background())
+	ss.NoError(err, "Object encrypted with the expected key should have been loaded")
+	ss.Equal("9090", values["APP_PORT"])
+}
+
+func (ss *S3SourceSuite) TestSetupAndWatch() {
+	ss.NoError(os.Unsetenv(constants.APP_PORT))
+	client := &fakeS3{content: "APP_PORT=9090\n", etag: `"v1"`}
+	source := New(client, "bucket", ".env")
+	source.PollInterval = 10 * time.Millisecond
+
+	conf := config.NewConfig(map[string]*config.Variable{
+		constants.APP_PORT: {DefaultValue: "8080"},
+	})
+	ss.NoError(conf.SetupWithOptions(config.WithSources(source)), "Config should have been set up")
+	ss.Equal("9090", conf.Port())
+
+	changes := make(chan []string, 1)
+	conf.OnChange(func(changed []string) { changes <- changed })
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.WatchSources(ctx)
+
+	client.mu.Lock()
+	client.content = "APP_PORT=7070\n"
+	client.etag = `"v2"`
+	client.mu.Unlock()
+	select {
+	case changed := <-changes:
+		ss.Equal([]string{constants.APP_PORT}, changed)
+		ss.Equal("7070", conf.Port())
+	case <-time.After(5 * time.Second):
+		ss.Fail("Changed ETag should have triggered a reload")
+	}
+}
+
+func (ss *S3SourceSuite) TestWatchFailure() {
+	client := &fakeS3{content: "APP_PORT=9090\n", etag: `"v1"`, headErr: errors.New("throttled")}
+	source := New(client, "bucket", ".env")
+	source.PollInterval = 10 * time.Millisecond
+	_, err := source.Load(context.Background())
+	ss.NoError(err)
+
+	failures := make(chan error, 1)
+	changes := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = source.Watch(ctx, func() {
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}, func(err error) {
+			select {
+			case failures <- err:
+			default:
+			}
+		})
+	}()
+
+	select {
+	case err := <-failures:
+		ss.EqualError(err, "Failed to check s3://bucket/.env: throttled")
+	case <-time.After(5 * time.Second):
+		ss.Fail("The failed check should have been reported")
+	}
+	client.mu.Lock()
+	client.headErr = nil
+	client.etag = `"v2"`
+	client.mu.Unlock()
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		ss.Fail("Watch should keep polling after a failed check")
+	}
+}
+
+func (ss *S3SourceSuite) TestNewFromEnv() {
+	ss.NoError(os.Unsetenv(constants.APP_CONFIG_S3_BUCKET))
+	_, err := NewFromEnv(context.Background())
+	ss.Error(err, "Missing bucket should fail")
+}
+
+// TestS3Source runs the whole test suite
+func TestS3Source(t *testing.T) {
+	suite.Run(t, new(S3SourceSuite))
+}
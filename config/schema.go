@@ -0,0 +1,131 @@
+package config
+
+import (
+	"sort"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// Migration migrates the values of an envfile from the previous schema version to Version,
+// e.g. renames or transforms the Variables changed by the new version.
+type Migration struct {
+	// Version is the schema version created by the Migration, from Version-1.
+	Version int
+
+	// Description describes the changes of the version in the warnings.
+	Description string
+
+	// Migrate changes the values of the envfile in place, keyed by the Variable names (without the prefix of
+	// WithPrefix). See RenameVariable and TransformVariable.
+	Migrate func(values map[string]string) error
+}
+
+// RenameVariable returns a Migrate function which renames the oldName Variable to newName.
+// The value of newName is kept if the envfile sets both.
+func RenameVariable(oldName, newName string) func(values map[string]string) error {
+	return func(values map[string]string) error {
+		if value, ok := values[oldName]; ok {
+			if _, ok := values[newName]; !ok {
+				values[newName] = value
+			}
+			delete(values, oldName)
+		}
+		return nil
+	}
+}
+
+// TransformVariable returns a Migrate function which replaces the value of the named Variable with the result
+// of transform, e.g. a duration in seconds to a Go duration. The envfiles without the Variable are not changed.
+func TransformVariable(name string, transform func(value string) (string, error)) func(values map[string]string) error {
+	return func(values map[string]string) error {
+		value, ok := values[name]
+		if !ok {
+			return nil
+		}
+		transformed, err := transform(value)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to transform %s", name)
+		}
+		values[name] = transformed
+		return nil
+	}
+}
+
+// WithSchemaVersion sets the version of the config schema and the Migrations from the earlier versions.
+// The envfiles declare their schema version by APP_CONFIG_SCHEMA_VERSION (1 if not set), the envfiles of older
+// versions are migrated by the Migrations of the later versions in order, with a warning (see
+// WithMigrationWarning), so long-lived services accept the envfiles written for an older schema. The YAML files
+// and the files of Lint, Diff and ValidateFile are migrated the same way.
+// The envfiles of a newer schema version fail the Setup. APP_CONFIG_SCHEMA_VERSION is written into the
+// sample file, but it is not set in the environment.
+func WithSchemaVersion(version int, migrations ...Migration) ConfigOption {
+	return func(appConf *AppConfig) {
+		appConf.schemaVersion = version
+		appConf.migrations = append(append([]Migration{}, appConf.migrations...), migrations...)
+		sort.SliceStable(appConf.migrations, func(i, j int) bool {
+			return appConf.migrations[i].Version < appConf.migrations[j].Version
+		})
+	}
+}
+
+// WithMigrationWarning calls handler with the warnings of the migrated envfiles instead of logging them
+// with the standard logrus logger.
+func WithMigrationWarning(handler func(warning string)) ConfigOption {
+	return func(appConf *AppConfig) {
+		appConf.migrationWarning = handler
+	}
+}
+
+// SchemaVersion returns the version of the config schema set by WithSchemaVersion, 0 if not versioned.
+func (appConf *AppConfig) SchemaVersion() int {
+	return appConf.schemaVersion
+}
+
+// migrateEnvfile removes the schema version from the values of the envfile (or of a YAML or JSON configuration
+// file), and migrates the values if the file has an older schema version. The values are keyed by the external
+// names.
+func (appConf *AppConfig) migrateEnvfile(envfile string, values map[string]string) (map[string]string, error) {
+	if appConf.schemaVersion == 0 {
+		return values, nil
+	}
+	name := appConf.externalName(constants.APP_CONFIG_SCHEMA_VERSION)
+	version := 1
+	if value, ok := values[name]; ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, errors.Errorf("Invalid %s in envfile %s: %s", name, envfile, value)
+		}
+		version = parsed
+		delete(values, name)
+	}
+	if version > appConf.schemaVersion {
+		return nil, errors.Errorf("Envfile %s has schema version %d, newer than %d", envfile, version, appConf.schemaVersion)
+	}
+	if version == appConf.schemaVersion {
+		return values, nil
+	}
+
+	internal := appConf.internalValues(values)
+	for _, migration := range appConf.migrations {
+		if migration.Version <= version || migration.Version > appConf.schemaVersion {
+			continue
+		}
+		if err := migration.Migrate(internal); err != nil {
+			return nil, errors.Wrapf(err, "Failed to migrate envfile %s to schema version %d", envfile, migration.Version)
+		}
+		warning := "Envfile " + envfile + " is migrated from schema version " + strconv.Itoa(version) + " to " + strconv.Itoa(migration.Version)
+		if migration.Description != "" {
+			warning += ": " + migration.Description
+		}
+		if appConf.migrationWarning != nil {
+			appConf.migrationWarning(warning)
+		} else {
+			logrus.Warn(warning)
+		}
+		version = migration.Version
+	}
+	return appConf.externalValues(internal), nil
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSchemaMigrations() {
+	names := []string{"APP_LOGLEVEL", constants.APP_LOG_LEVEL, "APP_TIMEOUT", "APP_TIMEOUT_SECONDS", constants.APP_CONFIG_SCHEMA_VERSION}
+	unsetEnv := func() {
+		for _, name := range names {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+
+	warnings := []string{}
+	newConf := func() *AppConfig {
+		return NewConfig(map[string]*Variable{
+			constants.APP_LOG_LEVEL: {DefaultValue: constants.LOG_LEVEL_INFO},
+			"APP_TIMEOUT":           {DefaultValue: "30s"},
+		}, WithSchemaVersion(3,
+			Migration{Version: 3, Description: "APP_TIMEOUT is a duration", Migrate: func(values map[string]string) error {
+				if err := RenameVariable("APP_TIMEOUT_SECONDS", "APP_TIMEOUT")(values); err != nil {
+					return err
+				}
+				return TransformVariable("APP_TIMEOUT", func(value string) (string, error) {
+					seconds, err := strconv.Atoi(value)
+					return (time.Duration(seconds) * time.Second).String(), err
+				})(values)
+			}},
+			Migration{Version: 2, Description: "APP_LOGLEVEL is renamed", Migrate: RenameVariable("APP_LOGLEVEL", constants.APP_LOG_LEVEL)},
+		), WithMigrationWarning(func(warning string) { warnings = append(warnings, warning) }))
+	}
+
+	dir := cts.T().TempDir()
+	envfile := filepath.Join(dir, ".env")
+	cts.NoError(ioutil.WriteFile(envfile, []byte("APP_LOGLEVEL=warn\nAPP_TIMEOUT_SECONDS=90\n"), 0600))
+	conf := newConf()
+	cts.Equal(3, conf.SchemaVersion())
+	cts.NoError(conf.Setup(envfile))
+	cts.Equal(constants.LOG_LEVEL_WARN, conf.LogLevel(), "Renamed Variable should be migrated")
+	cts.Equal("1m30s", conf.Get("APP_TIMEOUT"), "Transformed Variable should be migrated")
+	cts.Equal([]string{
+		"Envfile " + envfile + " is migrated from schema version 1 to 2: APP_LOGLEVEL is renamed",
+		"Envfile " + envfile + " is migrated from schema version 2 to 3: APP_TIMEOUT is a duration",
+	}, warnings)
+
+	unsetEnv()
+	warnings = nil
+	cts.NoError(ioutil.WriteFile(envfile, []byte("APP_CONFIG_SCHEMA_VERSION=2\nAPP_TIMEOUT_SECONDS=5\n"), 0600))
+	conf = newConf()
+	cts.NoError(conf.SetupWithOptions(WithEnvfiles(envfile), WithEnvPrecedence()))
+	_, ok := os.LookupEnv(constants.APP_CONFIG_SCHEMA_VERSION)
+	cts.False(ok, "The schema version should not be set in the environment")
+	cts.Equal("5s", conf.Get("APP_TIMEOUT"))
+	cts.Len(warnings, 1, "Migrations of older versions should be skipped")
+
+	cts.NoError(ioutil.WriteFile(envfile, []byte("APP_CONFIG_SCHEMA_VERSION=4\n"), 0600))
+	cts.EqualError(newConf().Setup(envfile), "Failed to set Application Configuration: Envfile "+envfile+" has schema version 4, newer than 3")
+
+	cts.NoError(ioutil.WriteFile(envfile, []byte("APP_LOGLEVEL=warn\nAPP_TIMEOUT_SECONDS=90\n"), 0600))
+	currentFile := filepath.Join(dir, ".env.current")
+	cts.NoError(ioutil.WriteFile(currentFile, []byte("APP_CONFIG_SCHEMA_VERSION=3\nAPP_LOG_LEVEL=warn\nAPP_TIMEOUT=1m30s\n"), 0600))
+	conf = newConf()
+	cts.True(conf.Lint(envfile).OK(), "Lint should migrate the file")
+	cts.NoError(conf.ValidateFile(envfile))
+	diff, err := conf.Diff(envfile, currentFile)
+	cts.NoError(err)
+	cts.Empty(diff, "Diff should compare the migrated files")
+	yamlFile := filepath.Join(dir, "config.yaml")
+	cts.NoError(ioutil.WriteFile(yamlFile, []byte("app:\n  timeout_seconds: 60\n"), 0600))
+	cts.NoError(conf.SetupWithOptions(WithYAMLFiles(yamlFile)))
+	cts.Equal("1m0s", conf.Get("APP_TIMEOUT"), "The YAML files should be migrated")
+
+	sampleFile := filepath.Join(dir, ".env.sample")
+	cts.NoError(newConf().CreateSampleFile(sampleFile))
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err)
+	cts.Contains(string(content), "\nAPP_CONFIG_SCHEMA_VERSION=3\n", "The sample file should declare the schema version")
+}
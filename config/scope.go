@@ -0,0 +1,55 @@
+package config
+
+import (
+	"sort"
+	"strings"
+)
+
+// ScopedConfig is a read-only view of the Variables of an AppConfig sharing a name prefix, e.g. the
+// Scope("APP_DB_") view reads APP_DB_HOST by Get("HOST"). Components can receive a ScopedConfig instead of
+// the whole AppConfig. The view is live: it returns the values after the reloads of the AppConfig.
+type ScopedConfig struct {
+	conf   *AppConfig
+	prefix string
+}
+
+// Scope returns the view of the Variables whose names start with prefix.
+func (appConf *AppConfig) Scope(prefix string) *ScopedConfig {
+	return &ScopedConfig{conf: appConf, prefix: prefix}
+}
+
+// Scope returns the view of the Variables whose names start with the prefix of the scope and prefix.
+func (scope *ScopedConfig) Scope(prefix string) *ScopedConfig {
+	return scope.conf.Scope(scope.prefix + prefix)
+}
+
+// Prefix returns the name prefix of the scope.
+func (scope *ScopedConfig) Prefix() string {
+	return scope.prefix
+}
+
+// Lookup returns the value of the named Variable of the scope (or an empty string), and a boolean
+// indicating if it was found or not.
+func (scope *ScopedConfig) Lookup(name string) (string, bool) {
+	return scope.conf.Lookup(scope.prefix + name)
+}
+
+// Get returns the value of the named Variable of the scope. If it is not set, an empty string is returned.
+func (scope *ScopedConfig) Get(name string) string {
+	val, _ := scope.Lookup(name)
+	return val
+}
+
+// Names returns the sorted names of the Variables of the scope, without the prefix.
+func (scope *ScopedConfig) Names() []string {
+	scope.conf.mu.RLock()
+	defer scope.conf.mu.RUnlock()
+	names := []string{}
+	for name := range scope.conf.vars {
+		if strings.HasPrefix(name, scope.prefix) {
+			names = append(names, strings.TrimPrefix(name, scope.prefix))
+		}
+	}
+	sort.Strings(names)
+	return names
+}
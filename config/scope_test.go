@@ -0,0 +1,27 @@
+package config
+
+func (cts *ConfigTestSuite) TestScope() {
+	conf := NewConfig(map[string]*Variable{
+		"APP_DB_HOST":         {DefaultValue: "localhost"},
+		"APP_DB_PORT":         {DefaultValue: "5432"},
+		"APP_DB_REPLICA_HOST": {DefaultValue: "replica"},
+		"APP_PORT":            {DefaultValue: "8080"},
+	})
+	cts.NoError(conf.Setup())
+
+	db := conf.Scope("APP_DB_")
+	cts.Equal("APP_DB_", db.Prefix())
+	cts.Equal("localhost", db.Get("HOST"), "Get should read APP_DB_HOST")
+	_, ok := db.Lookup("PORT")
+	cts.True(ok, "APP_DB_PORT should be found")
+	_, ok = db.Lookup("APP_PORT")
+	cts.False(ok, "Variables outside of the scope should not be found")
+	cts.Equal([]string{"HOST", "PORT", "REPLICA_HOST"}, db.Names())
+
+	replica := db.Scope("REPLICA_")
+	cts.Equal("replica", replica.Get("HOST"), "Nested scope should read APP_DB_REPLICA_HOST")
+	cts.Equal([]string{"HOST"}, replica.Names())
+
+	cts.NoError(conf.Set("APP_DB_HOST", "db.internal"))
+	cts.Equal("db.internal", db.Get("HOST"), "Scope should return the current values")
+}
@@ -0,0 +1,167 @@
+// Package secretsource provides a config.Source which loads the variables from a JSON secret in AWS SecretsManager.
+// Use the New or NewFromEnv constructors to create the Source and pass it to config.WithSources
+// Use config.AppConfig.WatchSources to reload the configuration when the secret is rotated, the OnChange callbacks
+// and the subscriptions are notified about the new values (e.g. to reconnect with the rotated DB password)
+package secretsource
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/config/internal/poll"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// DefaultPollInterval is the interval of the rotation checks if no interval is set.
+const DefaultPollInterval = 5 * time.Minute
+
+// currentStage is the staging label of the current version of a secret.
+const currentStage = "AWSCURRENT"
+
+// RDSMapping maps the keys of the secrets managed by RDS to the standard database Variables,
+// see config.PostgresVariables and config.MySQLVariables.
+var RDSMapping = map[string]string{
+	"host":     constants.APP_DB_HOST,
+	"port":     constants.APP_DB_PORT,
+	"username": constants.APP_DB_USER,
+	"password": constants.APP_DB_PASSWORD,
+	"dbname":   constants.APP_DB_NAME,
+}
+
+// secretsManagerAPI is the part of the SecretsManager client used by the Source.
+type secretsManagerAPI interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+	DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error)
+}
+
+// Source loads the variables from the current version of a single secret.
+type Source struct {
+	// SecretID is the name or the ARN of the secret.
+	SecretID string
+
+	// Mapping maps the keys of the secret to the Variable names, the other keys are ignored.
+	// If nil, the upper-cased keys of the secret are the Variable names (nested keys are joined by "_").
+	Mapping map[string]string
+
+	// PollInterval is the interval of the rotation checks of Watch, DefaultPollInterval if zero.
+	PollInterval time.Duration
+
+	client secretsManagerAPI
+
+	// mu guards the version of the last Load
+	mu        sync.Mutex
+	versionID string
+}
+
+// New creates a new Source which reads the secret with the supplied SecretsManager client.
+func New(client secretsManagerAPI, secretID string) *Source {
+	return &Source{
+		SecretID: secretID,
+		client:   client,
+	}
+}
+
+// NewFromEnv creates a new Source of the database secret with the default AWS configuration and the environment
+// variables APP_DB_SECRET_NAME and APP_CONFIG_SECRET_POLL_INTERVAL, the keys are mapped by the RDSMapping.
+// The environment is read directly, as the Source is needed before the AppConfig is set up.
+func NewFromEnv(ctx context.Context) (*Source, error) {
+	secretID := os.Getenv(constants.APP_DB_SECRET_NAME)
+	if secretID == "" {
+		return nil, errors.Errorf("%s must be set", constants.APP_DB_SECRET_NAME)
+	}
+	awsConf, err := awsConfig.LoadDefaultConfig(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to load AWS configuration")
+	}
+	source := New(secretsmanager.NewFromConfig(awsConf), secretID)
+	source.Mapping = RDSMapping
+	if interval, err := time.ParseDuration(os.Getenv(constants.APP_CONFIG_SECRET_POLL_INTERVAL)); err == nil {
+		source.PollInterval = interval
+	}
+	return source, nil
+}
+
+// Name implements the config.Source interface.
+func (s *Source) Name() string {
+	return fmt.Sprintf("secretsmanager:%s", s.SecretID)
+}
+
+// Load implements the config.Source interface, it reads the current version of the secret.
+func (s *Source) Load(ctx context.Context) (map[string]string, error) {
+	output, err := s.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId:     aws.String(s.SecretID),
+		VersionStage: aws.String(currentStage),
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to get %s", s.Name())
+	}
+	if output.SecretString == nil {
+		return nil, errors.Errorf("%s is not a string secret", s.Name())
+	}
+	loaded, err := config.ParseDocument(config.FormatJSON, []byte(aws.ToString(output.SecretString)))
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to parse %s", s.Name())
+	}
+
+	values := loaded
+	if s.Mapping != nil {
+		values = map[string]string{}
+		for key, name := range s.Mapping {
+			if value, ok := loaded[strings.ToUpper(key)]; ok {
+				values[name] = value
+			}
+		}
+	}
+
+	s.mu.Lock()
+	s.versionID = aws.ToString(output.VersionId)
+	s.mu.Unlock()
+	return values, nil
+}
+
+// Watch implements the config.WatchableSource interface, it polls the current version of the secret
+// and calls changed if it differs from the version of the last Load, e.g. after a rotation. The failed checks
+// are passed to failed, and the next check waits with a backoff.
+func (s *Source) Watch(ctx context.Context, changed func(), failed func(err error)) error {
+	interval := s.PollInterval
+	if interval <= 0 {
+		interval = DefaultPollInterval
+	}
+	poll.Poll(ctx, interval, func() error {
+		output, err := s.client.DescribeSecret(ctx, &secretsmanager.DescribeSecretInput{
+			SecretId: aws.String(s.SecretID),
+		})
+		if err != nil {
+			return errors.Wrapf(err, "Failed to check %s", s.Name())
+		}
+		s.mu.Lock()
+		rotated := currentVersion(output.VersionIdsToStages) != s.versionID
+		s.mu.Unlock()
+		if rotated {
+			changed()
+		}
+		return nil
+	}, failed)
+	return ctx.Err()
+}
+
+// currentVersion returns the ID of the version labelled AWSCURRENT.
+func currentVersion(versions map[string][]string) string {
+	for versionID, stages := range versions {
+		for _, stage := range stages {
+			if stage == currentStage {
+				return versionID
+			}
+		}
+	}
+	return ""
+}
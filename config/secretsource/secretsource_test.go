@@ -0,0 +1,166 @@
+package secretsource
+
+import (
+	"context"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// SecretSourceSuite extends testify's Suite.
+type SecretSourceSuite struct {
+	suite.Suite
+}
+
+// fakeSecretsManager serves a single secret from memory
+type fakeSecretsManager struct {
+	mu          sync.Mutex
+	content     string
+	versionID   string
+	describeErr error
+}
+
+func (f *fakeSecretsManager) GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return &secretsmanager.GetSecretValueOutput{
+		SecretString: aws.String(f.content),
+		VersionId:    aws.String(f.versionID),
+	}, nil
+}
+
+func (f *fakeSecretsManager) DescribeSecret(ctx context.Context, params *secretsmanager.DescribeSecretInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.DescribeSecretOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.describeErr != nil {
+		return nil, f.describeErr
+	}
+	return &secretsmanager.DescribeSecretOutput{
+		VersionIdsToStages: map[string][]string{
+			"previous":  {"AWSPREVIOUS"},
+			f.versionID: {currentStage},
+		},
+	}, nil
+}
+
+// rotate replaces the current version of the secret
+func (f *fakeSecretsManager) rotate(content, versionID string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.content = content
+	f.versionID = versionID
+}
+
+func (ss *SecretSourceSuite) TestLoad() {
+	client := &fakeSecretsManager{content: `{"APP_API_KEY": "s3cr3t", "APP_LIMITS": {"RPS": 10}}`, versionID: "v1"}
+	source := New(client, "service/api")
+	ss.Equal("secretsmanager:service/api", source.Name())
+
+	values, err := source.Load(context.Background())
+	ss.NoError(err, "Secret should have been loaded")
+	ss.Equal(map[string]string{"APP_API_KEY": "s3cr3t", "APP_LIMITS_RPS": "10"}, values)
+
+	client.rotate(`{"engine": "postgres", "host": "db", "port": 5432, "username": "app", "password": "s3cr3t"}`, "v2")
+	source.Mapping = RDSMapping
+	values, err = source.Load(context.Background())
+	ss.NoError(err, "Secret should have been loaded")
+	ss.Equal(map[string]string{
+		constants.APP_DB_HOST:     "db",
+		constants.APP_DB_PORT:     "5432",
+		constants.APP_DB_USER:     "app",
+		constants.APP_DB_PASSWORD: "s3cr3t",
+	}, values, "The keys should be mapped, the unmapped keys ignored")
+
+	client.rotate("not json", "v3")
+	_, err = source.Load(context.Background())
+	ss.Error(err, "Invalid secret should fail")
+}
+
+func (ss *SecretSourceSuite) TestRotation() {
+	for name := range config.PostgresVariables() {
+		ss.NoError(os.Unsetenv(name))
+	}
+	client := &fakeSecretsManager{content: `{"username": "app", "password": "old"}`, versionID: "v1"}
+	source := New(client, "service/db")
+	source.Mapping = RDSMapping
+	source.PollInterval = 10 * time.Millisecond
+
+	conf := config.NewConfig(config.PostgresVariables())
+	ss.NoError(conf.SetupWithOptions(config.WithSources(source)), "Config should have been set up")
+	ss.Equal("old", conf.Get(constants.APP_DB_PASSWORD))
+
+	changes := conf.Subscribe(constants.APP_DB_PASSWORD)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.WatchSources(ctx)
+
+	client.rotate(`{"username": "app", "password": "new"}`, "v2")
+	select {
+	case change := <-changes:
+		ss.Equal(config.Change{Name: constants.APP_DB_PASSWORD, OldValue: "old", NewValue: "new"}, change)
+		ss.Equal("new", conf.PostgresDSN().Password)
+	case <-time.After(5 * time.Second):
+		ss.Fail("Rotation should have triggered a reload")
+	}
+}
+
+func (ss *SecretSourceSuite) TestWatchFailure() {
+	client := &fakeSecretsManager{content: `{"APP_API_KEY": "old"}`, versionID: "v1", describeErr: errors.New("throttled")}
+	source := New(client, "service/api")
+	source.PollInterval = 10 * time.Millisecond
+	_, err := source.Load(context.Background())
+	ss.NoError(err)
+
+	failures := make(chan error, 1)
+	changes := make(chan struct{}, 1)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go func() {
+		_ = source.Watch(ctx, func() {
+			select {
+			case changes <- struct{}{}:
+			default:
+			}
+		}, func(err error) {
+			select {
+			case failures <- err:
+			default:
+			}
+		})
+	}()
+
+	select {
+	case err := <-failures:
+		ss.EqualError(err, "Failed to check secretsmanager:service/api: throttled")
+	case <-time.After(5 * time.Second):
+		ss.Fail("The failed check should have been reported")
+	}
+	client.mu.Lock()
+	client.describeErr = nil
+	client.mu.Unlock()
+	client.rotate(`{"APP_API_KEY": "new"}`, "v2")
+	select {
+	case <-changes:
+	case <-time.After(5 * time.Second):
+		ss.Fail("Watch should keep polling after a failed check")
+	}
+}
+
+func (ss *SecretSourceSuite) TestNewFromEnv() {
+	ss.NoError(os.Unsetenv(constants.APP_DB_SECRET_NAME))
+	_, err := NewFromEnv(context.Background())
+	ss.EqualError(err, "APP_DB_SECRET_NAME must be set")
+}
+
+// TestSecretSource runs the whole test suite
+func TestSecretSource(t *testing.T) {
+	suite.Run(t, new(SecretSourceSuite))
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"fmt"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+// Set updates the named Variable's value at runtime. The Variable's own rules are validated, and the rules of
+// the other Variables depending on it (e.g. RequiredIf), an invalid value is rejected and the previous value is
+// kept. The Variables which are already invalid and the Constraints do not affect Set.
+// The OnChange callbacks and the subscriptions are invoked if the value has changed.
+func (appConf *AppConfig) Set(name, value string) error {
+	appConf.mu.Lock()
+	name, _ = appConf.variableName(name)
+	values := appConf.values()
+	values[name] = value
+	if err := appConf.validateVar(name, values); err != nil {
+		appConf.mu.Unlock()
+		return err
+	}
+	if err := appConf.validateDependents(name, values); err != nil {
+		appConf.mu.Unlock()
+		return err
+	}
+	confVar := appConf.vars[name]
+	before := confVar.Value
+	confVar.setValue(value, OriginRuntime)
+	callbacks := append([]func([]string){}, appConf.onChange...)
+	var dropped []Change
+	if before != value {
+		dropped = appConf.publish(map[string]string{name: before}, values, []string{name})
+	}
+	appConf.mu.Unlock()
+
+	if before != value {
+		for _, fn := range callbacks {
+			fn([]string{name})
+		}
+		appConf.reportDropped(dropped)
+	}
+	return nil
+}
+
+// Override sets the values of several Variables at once without validation, names which are not
+// registered are added as new Variables without rules. It is meant for tests, use Set at runtime.
+// The OnChange callbacks and the subscriptions are invoked with the changed Variables.
+func (appConf *AppConfig) Override(values map[string]string) {
+	appConf.mu.Lock()
+	before := appConf.values()
+	for name, value := range values {
+		if _, ok := appConf.vars[name]; !ok {
+			appConf.vars[name] = &Variable{}
+		}
+		appConf.vars[name].setValue(value, OriginRuntime)
+	}
+	after := appConf.values()
+	changed := changedNames(before, after)
+	callbacks := append([]func([]string){}, appConf.onChange...)
+	dropped := appConf.publish(before, after, changed)
+	appConf.mu.Unlock()
+
+	if len(changed) > 0 {
+		for _, fn := range callbacks {
+			fn(changed)
+		}
+		appConf.reportDropped(dropped)
+	}
+}
+
+// ValidateVar applies the named Variable's own validation rules on its current value.
+// It returns an error if the Variable is unknown or its value is invalid, without validating the others.
+func (appConf *AppConfig) ValidateVar(name string) error {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	name, _ = appConf.variableName(name)
+	return appConf.validateVar(name, appConf.values())
+}
+
+// validateVar applies the named Variable's validation rules on its value in values.
+// The caller must hold the lock.
+func (appConf *AppConfig) validateVar(name string, values map[string]string) error {
+	confVar, ok := appConf.vars[name]
+	if !ok {
+		return errors.Errorf("Unknown configuration variable %s", name)
+	}
+	value := values[name]
+	if validationErrors := confVar.validate(value, values); len(validationErrors) > 0 {
+		return errors.Wrapf(validationErrors.Filter(), "Invalid value for %s = %s", appConf.externalName(name), confVar.display(value))
+	}
+	return nil
+}
+
+// validateDependents applies the validation rules of the other Variables on values, and returns the errors of
+// the Variables which are valid with the current values but not with values, i.e. which depend on the named
+// Variable. The caller must hold the lock.
+func (appConf *AppConfig) validateDependents(name string, values map[string]string) error {
+	current := appConf.values()
+	allErrors := validation.Errors{}
+	for confKey, confVar := range appConf.vars {
+		if confKey == name {
+			continue
+		}
+		validationErrors := confVar.validate(values[confKey], values)
+		if len(validationErrors) == 0 || len(confVar.validate(current[confKey], current)) > 0 {
+			continue
+		}
+		allErrors[fmt.Sprintf("%s = %s", appConf.externalName(confKey), confVar.display(values[confKey]))] = validationErrors.Filter()
+	}
+	if len(allErrors) == 0 {
+		return nil
+	}
+	return errors.Wrapf(allErrors, "Invalid value for %s = %s", appConf.externalName(name), appConf.vars[name].display(values[name]))
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSet() {
+	cts.setupEnvTest(constants.BasicEnvs...)
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(), "Config should have been set up")
+
+	changes := [][]string{}
+	conf.OnChange(func(changed []string) { changes = append(changes, changed) })
+
+	cts.NoError(conf.Set(constants.APP_PORT, "9090"), "Valid value should be set")
+	cts.Equal("9090", conf.Port())
+	cts.Equal([][]string{{constants.APP_PORT}}, changes)
+
+	err := conf.Set(constants.APP_PORT, "notAportNum")
+	cts.Error(err, "Invalid value should be rejected")
+	cts.Contains(err.Error(), "APP_PORT = notAportNum")
+	cts.Contains(err.Error(), "must be a valid port number")
+	cts.Equal("9090", conf.Port(), "Invalid value should not be applied")
+
+	// Only the variable's own rules are validated
+	conf.Override(map[string]string{constants.APP_ENV: "Nasa"})
+	cts.NoError(conf.Set(constants.APP_PORT, "7070"), "Other invalid variables should not affect Set")
+
+	cts.EqualError(conf.Set("APP_UNKNOWN", "1"), "Unknown configuration variable APP_UNKNOWN")
+
+	conf = NewConfig(map[string]*Variable{
+		constants.APP_ENV:    {DefaultValue: constants.ENV_DEV},
+		"APP_DB_SECRET_NAME": {Rules: map[string]validation.Rule{"Required in production": RequiredWhenEnv(constants.ENV_PRODUCTION)}},
+	})
+	conf.Override(conf.defaultValues())
+	cts.EqualError(conf.Set(constants.APP_ENV, constants.ENV_PRODUCTION), "Invalid value for APP_ENV = production: "+
+		"APP_DB_SECRET_NAME = : (Required in production: cannot be blank when APP_ENV is production.).",
+		"The Variables depending on the value should be validated")
+	cts.Equal(constants.ENV_DEV, conf.Get(constants.APP_ENV), "Invalid value should not be applied")
+}
+
+func (cts *ConfigTestSuite) TestOverride() {
+	cts.setupEnvTest(constants.BasicEnvs...)
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(), "Config should have been set up")
+
+	changes := [][]string{}
+	conf.OnChange(func(changed []string) { changes = append(changes, changed) })
+
+	conf.Override(map[string]string{
+		constants.APP_ENV:  constants.ENV_PRODUCTION,
+		constants.APP_PORT: "8080",
+		"APP_FEATURE_X":    "on",
+	})
+	cts.True(conf.IsProduction())
+	cts.Equal("on", conf.Get("APP_FEATURE_X"), "Unknown names should be registered")
+	cts.Equal([][]string{{constants.APP_ENV, "APP_FEATURE_X"}}, changes, "Only the changed names should be reported")
+	cts.NoError(conf.Validate())
+}
+
+func (cts *ConfigTestSuite) TestValidateVar() {
+	conf := NewConfig(cts.getDefaultConfigs())
+	conf.Override(map[string]string{
+		constants.APP_PORT: "8080",
+		constants.APP_ENV:  "Nasa",
+	})
+
+	cts.NoError(conf.ValidateVar(constants.APP_PORT), "Other invalid variables should not affect ValidateVar")
+	cts.EqualError(conf.ValidateVar(constants.APP_ENV), "Invalid value for APP_ENV = Nasa: Valid environment: must be a valid value.")
+	cts.EqualError(conf.ValidateVar("APP_UNKNOWN"), "Unknown configuration variable APP_UNKNOWN")
+}
@@ -0,0 +1,128 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// VariableError lists the failed validation rules of a Variable (or a Constraint) in a SetupError.
+type VariableError struct {
+	// Variable is the name of the invalid Variable, or the name of the failed Constraint.
+	Variable string
+
+	// Value is the invalid value, masked if the Variable is Sensitive.
+	Value string
+
+	// Description is the Description of the Variable, empty for the Constraints.
+	Description string
+
+	// Missing is true if the Variable is not set.
+	Missing bool
+
+	// Issues are the failed rules of the Variable, sorted by the rule names.
+	Issues []ValidationIssue
+
+	constraint bool
+}
+
+// SetupError is returned by Setup (and SetupWithOptions) on an invalid configuration. It lists every
+// invalid or missing Variable at once, so a misconfigured deployment can be fixed in one round instead of
+// failing on the variables one by one. Its message is a multi-line report, e.g.:
+//
+//	Invalid Application Configuration, 2 variables failed:
+//	  APP_DB_HOST is not set
+//	    Host of the database server
+//	    - Required: cannot be blank
+//	  APP_PORT = http
+//	    Port of the HTTP server
+//	    - Valid port: must be a valid port number
+//
+// errors.Cause returns the validation.Errors of the failures, the same as Validate.
+type SetupError struct {
+	// Variables are the failed Variables sorted by name, followed by the failed Constraints.
+	Variables []VariableError
+
+	errs validation.Errors
+}
+
+// Error returns the multi-line report of the failed Variables.
+func (setupErr *SetupError) Error() string {
+	var builder strings.Builder
+	builder.WriteString("Invalid Application Configuration, " + strconv.Itoa(len(setupErr.Variables)))
+	if len(setupErr.Variables) == 1 {
+		builder.WriteString(" variable failed:")
+	} else {
+		builder.WriteString(" variables failed:")
+	}
+	for _, varErr := range setupErr.Variables {
+		switch {
+		case varErr.constraint:
+			builder.WriteString("\n  Constraint " + varErr.Variable)
+		case varErr.Missing:
+			builder.WriteString("\n  " + varErr.Variable + " is not set")
+		default:
+			builder.WriteString("\n  " + varErr.Variable + " = " + varErr.Value)
+		}
+		if varErr.Description != "" {
+			builder.WriteString("\n    " + varErr.Description)
+		}
+		for _, issue := range varErr.Issues {
+			if varErr.constraint {
+				builder.WriteString("\n    - " + issue.Message)
+			} else {
+				builder.WriteString("\n    - " + issue.Rule + ": " + issue.Message)
+			}
+		}
+	}
+	return builder.String()
+}
+
+// Cause returns the validation.Errors of the failures, see errors.Cause.
+func (setupErr *SetupError) Cause() error {
+	return setupErr.errs
+}
+
+// Unwrap returns the validation.Errors of the failures, see errors.As.
+func (setupErr *SetupError) Unwrap() error {
+	return setupErr.errs
+}
+
+// setupError validates the current values and returns the SetupError of the failures, nil if the
+// configuration is valid.
+func (appConf *AppConfig) setupError() *SetupError {
+	appConf.mu.RLock()
+	values := appConf.values()
+	appConf.mu.RUnlock()
+	errs, report := appConf.validate(values)
+	if len(errs) == 0 {
+		return nil
+	}
+
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	setupErr := &SetupError{errs: errs}
+	var constraints []VariableError
+	for _, issue := range report {
+		if issue.Variable == "" {
+			constraints = append(constraints, VariableError{Variable: issue.Rule, Issues: []ValidationIssue{issue}, constraint: true})
+			continue
+		}
+		// The report is sorted by Variable, so the issues of a Variable are adjacent
+		if last := len(setupErr.Variables) - 1; last >= 0 && setupErr.Variables[last].Variable == issue.Variable {
+			setupErr.Variables[last].Issues = append(setupErr.Variables[last].Issues, issue)
+			continue
+		}
+		name := appConf.internalName(issue.Variable)
+		setupErr.Variables = append(setupErr.Variables, VariableError{
+			Variable:    issue.Variable,
+			Value:       issue.Value,
+			Description: appConf.vars[name].Description,
+			Missing:     values[name] == "",
+			Issues:      []ValidationIssue{issue},
+		})
+	}
+	setupErr.Variables = append(setupErr.Variables, constraints...)
+	return setupErr
+}
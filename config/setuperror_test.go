@@ -0,0 +1,86 @@
+package config
+
+import (
+	"os"
+
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+func (cts *ConfigTestSuite) TestSetupError() {
+	names := []string{"APP_DB_HOST", "APP_DB_PASSWORD", "APP_HTTP_PORT", "APP_MIN", "APP_MAX"}
+	unsetEnv := func() {
+		for _, name := range names {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+
+	conf := NewConfig(map[string]*Variable{
+		"APP_DB_HOST": {
+			Description: "Host of the database server",
+			Rules:       map[string]validation.Rule{"Required": validation.Required},
+		},
+		"APP_DB_PASSWORD": {
+			DefaultValue: "secret",
+			Sensitive:    true,
+			Rules:        map[string]validation.Rule{"Long password": validation.Length(8, 0)},
+		},
+		"APP_HTTP_PORT": {
+			DefaultValue: "http",
+			Description:  "Port of the HTTP server",
+			Rules: map[string]validation.Rule{
+				"Digits":     is.Digit,
+				"Valid port": is.Port,
+			},
+		},
+		"APP_MIN": {DefaultValue: "2"},
+		"APP_MAX": {DefaultValue: "1"},
+	})
+	calls := 0
+	conf.AddConstraints(Constraint{
+		Name: "Ordered range",
+		Validate: func(values map[string]string) error {
+			calls++
+			if values["APP_MIN"] > values["APP_MAX"] {
+				return errors.New("APP_MIN must not exceed APP_MAX")
+			}
+			return nil
+		},
+	})
+
+	err := conf.Setup()
+	setupErr, ok := err.(*SetupError)
+	cts.Require().True(ok, "Setup should return a SetupError")
+	cts.Equal(1, calls, "The Constraints should be validated once")
+	cts.Equal("Invalid Application Configuration, 4 variables failed:\n"+
+		"  APP_DB_HOST is not set\n"+
+		"    Host of the database server\n"+
+		"    - Required: cannot be blank\n"+
+		"  APP_DB_PASSWORD = *****\n"+
+		"    - Long password: the length must be no less than 8\n"+
+		"  APP_HTTP_PORT = http\n"+
+		"    Port of the HTTP server\n"+
+		"    - Digits: must contain digits only\n"+
+		"    - Valid port: must be a valid port number\n"+
+		"  Constraint Ordered range\n"+
+		"    - APP_MIN must not exceed APP_MAX", err.Error())
+	cts.Len(setupErr.Variables, 4)
+	cts.True(setupErr.Variables[0].Missing)
+	cts.False(setupErr.Variables[2].Missing)
+	cts.Len(setupErr.Variables[2].Issues, 2, "The issues should be grouped by Variable")
+
+	errs, ok := errors.Cause(err).(validation.Errors)
+	cts.Require().True(ok, "The cause should be the validation errors")
+	cts.Len(errs, 4)
+
+	cts.setEnvVars(map[string]string{
+		"APP_DB_HOST":     "localhost",
+		"APP_DB_PASSWORD": "long enough",
+		"APP_HTTP_PORT":   "8080",
+		"APP_MIN":         "0",
+	})
+	cts.NoError(conf.Setup())
+}
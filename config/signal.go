@@ -0,0 +1,33 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+)
+
+// ReloadOnSignal Reloads the configuration whenever the process receives any of the supplied signals,
+// SIGHUP if none supplied. The changes are published to the OnChange callbacks, failed reloads keep the
+// last-known-good values and are reported to the OnReloadError callbacks.
+// ReloadOnSignal returns immediately, the signal handling stops when ctx is done.
+func (appConf *AppConfig) ReloadOnSignal(ctx context.Context, signals ...os.Signal) {
+	if len(signals) == 0 {
+		signals = []os.Signal{syscall.SIGHUP}
+	}
+	received := make(chan os.Signal, 1)
+	signal.Notify(received, signals...)
+
+	go func() {
+		defer signal.Stop(received)
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-received:
+				// The error is already reported to the OnReloadError callbacks
+				_ = appConf.Reload()
+			}
+		}
+	}()
+}
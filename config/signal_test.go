@@ -0,0 +1,52 @@
+//go:build !windows
+
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"syscall"
+	"time"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestReloadOnSignal() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer os.Remove(envFile)
+	cts.writeEnvfile(envFile, map[string]string{"APP_PORT": "9090"})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(envFile), "Config should have been set up")
+
+	changes := make(chan []string, 1)
+	conf.OnChange(func(changed []string) { changes <- changed })
+	reloadErrors := make(chan error, 1)
+	conf.OnReloadError(func(err error) { reloadErrors <- err })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	conf.ReloadOnSignal(ctx)
+
+	// Invalid values should keep the last-known-good config
+	cts.NoError(ioutil.WriteFile(envFile, []byte("APP_PORT=notAportNum\n"), 0600))
+	cts.NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	select {
+	case err := <-reloadErrors:
+		cts.Contains(err.Error(), "must be a valid port number")
+		cts.Equal("9090", conf.Port(), "Last-known-good value should have been kept")
+	case <-time.After(5 * time.Second):
+		cts.Fail("SIGHUP should have triggered a reload")
+	}
+
+	cts.NoError(ioutil.WriteFile(envFile, []byte("APP_PORT=7070\n"), 0600))
+	cts.NoError(syscall.Kill(os.Getpid(), syscall.SIGHUP))
+	select {
+	case changed := <-changes:
+		cts.Equal([]string{constants.APP_PORT}, changed)
+		cts.Equal("7070", conf.Port())
+	case <-time.After(5 * time.Second):
+		cts.Fail("SIGHUP should have triggered a reload")
+	}
+}
@@ -0,0 +1,79 @@
+package config
+
+// ConfigSnapshot is the state of the values of an AppConfig at a point in time, created by Snapshot.
+type ConfigSnapshot struct {
+	values  map[string]string
+	origins map[string]string
+}
+
+// Values returns a copy of the values of the snapshot by Variable name.
+func (snapshot ConfigSnapshot) Values() map[string]string {
+	values := make(map[string]string, len(snapshot.values))
+	for name, value := range snapshot.values {
+		values[name] = value
+	}
+	return values
+}
+
+// Snapshot returns the current values of the Variables, which can be restored later by Restore, e.g.
+// before a hot-reload or a runtime experiment.
+func (appConf *AppConfig) Snapshot() ConfigSnapshot {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	return appConf.snapshot()
+}
+
+// snapshot returns the current values and origins of the Variables.
+// The caller must hold the lock.
+func (appConf *AppConfig) snapshot() ConfigSnapshot {
+	snapshot := ConfigSnapshot{
+		values:  appConf.values(),
+		origins: make(map[string]string, len(appConf.vars)),
+	}
+	for name, confVar := range appConf.vars {
+		snapshot.origins[name] = confVar.origin
+	}
+	return snapshot
+}
+
+// Restore sets the values of the snapshot at once. The values are validated first (against the current
+// Variables and Constraints), on failure the current values are kept and the error is returned.
+// The Variables registered after the Snapshot keep their current values.
+// The OnChange callbacks and the subscriptions are invoked if any value has changed.
+// The values are read, validated and written under the lock, so the concurrent Sets are not lost: the Constraints
+// must not use the AppConfig, and the validators read the values from their own AppConfig (see AddValidator).
+func (appConf *AppConfig) Restore(snapshot ConfigSnapshot) error {
+	appConf.reloadMu.Lock()
+	defer appConf.reloadMu.Unlock()
+
+	appConf.mu.Lock()
+	current := appConf.snapshot()
+	before := current.Values()
+	after := current.Values()
+	mergeValues(after, snapshot.values)
+	errs, _ := appConf.validateVars(after)
+	var view *AppConfig
+	if appConf.validators > 0 {
+		view = appConf.view(after)
+	}
+	applyConstraints(appConf.constraints, view, after, errs)
+	if len(errs) > 0 {
+		appConf.mu.Unlock()
+		return errs.Filter()
+	}
+
+	changed := changedNames(before, after)
+	mergeValues(current.origins, snapshot.origins)
+	appConf.applyValues(after, current.origins)
+	callbacks := append([]func([]string){}, appConf.onChange...)
+	dropped := appConf.publish(before, after, changed)
+	appConf.mu.Unlock()
+
+	if len(changed) > 0 {
+		for _, fn := range callbacks {
+			fn(changed)
+		}
+		appConf.reportDropped(dropped)
+	}
+	return nil
+}
@@ -0,0 +1,72 @@
+package config
+
+import (
+	"os"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSnapshot() {
+	for _, name := range constants.BasicEnvs {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup())
+	snapshot := conf.Snapshot()
+	cts.Equal("8080", snapshot.Values()[constants.APP_PORT])
+
+	changes := [][]string{}
+	conf.OnChange(func(changed []string) { changes = append(changes, changed) })
+	cts.NoError(conf.Set(constants.APP_PORT, "9090"))
+	cts.NoError(conf.Set(constants.APP_LOG_LEVEL, constants.LOG_LEVEL_WARN))
+	cts.NoError(conf.AddVariable("APP_DB_HOST", &Variable{DefaultValue: "localhost"}))
+
+	cts.NoError(conf.Restore(snapshot))
+	cts.Equal("8080", conf.Port(), "The value of the snapshot should be restored")
+	cts.Equal(constants.LOG_LEVEL_DEBUG, conf.LogLevel(), "The value of the snapshot should be restored")
+	cts.Equal(OriginDefault, conf.Source(constants.APP_PORT), "The origin of the snapshot should be restored")
+	cts.Equal("localhost", conf.Get("APP_DB_HOST"), "The Variables added after the snapshot should be kept")
+	cts.Equal([]string{constants.APP_LOG_LEVEL, constants.APP_PORT}, changes[len(changes)-1])
+
+	invalid := conf.Snapshot()
+	invalid.values[constants.APP_PORT] = "not-a-port"
+	cts.Error(conf.Restore(invalid), "Invalid snapshot should not be restored")
+	cts.Equal("8080", conf.Port(), "The current values should be kept")
+}
+
+func (cts *ConfigTestSuite) TestRestoreConcurrentSet() {
+	conf := NewConfig(map[string]*Variable{"APP_A": {}, "APP_B": {}})
+	conf.AddValidator(func(c *AppConfig) error {
+		if c.Get("APP_B") == "invalid" {
+			return errors.New("Invalid APP_B")
+		}
+		return nil
+	})
+	cts.NoError(conf.Setup())
+	snapshot := conf.Snapshot()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cts.NoError(conf.Set("APP_A", "set"))
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 100; i++ {
+			cts.NoError(conf.Restore(snapshot))
+		}
+	}()
+	wg.Wait()
+	cts.NoError(conf.Set("APP_A", "set"))
+	cts.NoError(conf.Restore(snapshot))
+	cts.Equal("", conf.Get("APP_A"), "The value of the snapshot should be restored")
+
+	invalid := conf.Snapshot()
+	invalid.values["APP_B"] = "invalid"
+	cts.EqualError(conf.Restore(invalid), "Validator 1: Invalid APP_B.")
+}
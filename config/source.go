@@ -0,0 +1,177 @@
+package config
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Source provides variable values from a location other than the environment and the local files,
+// e.g. an object storage or a key-value store.
+type Source interface {
+	// Name identifies the source in error messages.
+	Name() string
+
+	// Load returns the variable values provided by the source.
+	Load(ctx context.Context) (map[string]string, error)
+}
+
+// WatchableSource is a Source which can detect the changes of its values.
+type WatchableSource interface {
+	Source
+
+	// Watch blocks until ctx is done and calls changed whenever the values of the source may have changed.
+	// The transient errors, e.g. a failed poll, are passed to failed and the watching goes on, the returned
+	// error ends the watching.
+	Watch(ctx context.Context, changed func(), failed func(err error)) error
+}
+
+// Format is the format of a configuration document.
+type Format string
+
+const (
+	// FormatEnv is the dotenv format (KEY=value lines).
+	FormatEnv Format = "env"
+
+	// FormatJSON is a JSON object, nested keys are joined by "_".
+	FormatJSON Format = "json"
+
+	// FormatYAML is a YAML mapping, nested keys are joined by "_".
+	FormatYAML Format = "yaml"
+)
+
+// FormatFromPath detects the Format of a document by its file extension, FormatEnv by default.
+func FormatFromPath(path string) Format {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return FormatJSON
+	case ".yaml", ".yml":
+		return FormatYAML
+	default:
+		return FormatEnv
+	}
+}
+
+// ParseDocument parses a configuration document into variable names and values.
+// The keys of JSON and YAML documents are flattened the same way as the YAML files of WithYAMLFiles.
+func ParseDocument(format Format, content []byte) (map[string]string, error) {
+	switch format {
+	case FormatEnv:
+		values, err := ParseEnvfile(content)
+		return values, errors.Wrap(err, "Failed to parse envfile document")
+	case FormatJSON:
+		doc := map[string]interface{}{}
+		// UseNumber keeps the numbers in their original representation
+		decoder := json.NewDecoder(bytes.NewReader(content))
+		decoder.UseNumber()
+		if err := decoder.Decode(&doc); err != nil {
+			return nil, errors.Wrap(err, "Failed to parse JSON document")
+		}
+		values := map[string]string{}
+		if err := flattenYAML("", doc, values); err != nil {
+			return nil, errors.Wrap(err, "Failed to parse JSON document")
+		}
+		return values, nil
+	case FormatYAML:
+		doc := map[string]interface{}{}
+		if err := yaml.Unmarshal(content, &doc); err != nil {
+			return nil, errors.Wrap(err, "Failed to parse YAML document")
+		}
+		values := map[string]string{}
+		if err := flattenYAML("", doc, values); err != nil {
+			return nil, errors.Wrap(err, "Failed to parse YAML document")
+		}
+		return values, nil
+	default:
+		return nil, errors.Errorf("Unknown document format %q", format)
+	}
+}
+
+// WithSources loads the supplied Sources. Variables in the Sources take precedence over every other
+// location, later Sources take precedence over earlier ones.
+func WithSources(sources ...Source) SetupOption {
+	return func(o *setupOptions) {
+		o.sources = append(o.sources, sources...)
+	}
+}
+
+// sourceLoadTimeout is the timeout of the Load of each Source during the Setup and the Reloads.
+const sourceLoadTimeout = time.Minute
+
+// loadSources loads the variables from the Sources into values and their origins.
+// Only the registered Variables are set, unknown keys are ignored.
+func loadSources(values, origins map[string]string, sources ...Source) error {
+	for _, source := range sources {
+		loaded, err := loadSource(source)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to load variables from %s", source.Name())
+		}
+		setOrigins(origins, values, loaded, source.Name())
+		mergeValues(values, loaded)
+	}
+	return nil
+}
+
+// loadSource loads the variables of the Source with sourceLoadTimeout.
+func loadSource(source Source) (map[string]string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), sourceLoadTimeout)
+	defer cancel()
+	return source.Load(ctx)
+}
+
+// mergeValues overwrites the values which are present in loaded, keys missing from values are ignored.
+func mergeValues(values, loaded map[string]string) {
+	for key, val := range loaded {
+		if _, ok := values[key]; ok {
+			values[key] = val
+		}
+	}
+}
+
+// setOrigins sets origin for the keys of loaded which are present in values, like mergeValues.
+func setOrigins(origins, values, loaded map[string]string, origin string) {
+	for key := range loaded {
+		if _, ok := values[key]; ok {
+			origins[key] = origin
+		}
+	}
+}
+
+// applyValues sets the registered Variables which are present in values, with their origins.
+// The caller must hold the lock.
+func (appConf *AppConfig) applyValues(values, origins map[string]string) {
+	for confKey, confVar := range appConf.vars {
+		if val, ok := values[confKey]; ok {
+			confVar.setValue(val, origins[confKey])
+		}
+	}
+}
+
+// WatchSources starts watching every WatchableSource of the last Setup and Reloads the configuration
+// whenever any of them reports a change. The watching stops when ctx is done.
+// Reload errors are reported to the OnReloadError callbacks, Watch errors to the OnWatchError callbacks.
+func (appConf *AppConfig) WatchSources(ctx context.Context) {
+	for _, source := range newSetupOptions(appConf.lastSetupOptions()...).sources {
+		watchable, ok := source.(WatchableSource)
+		if !ok {
+			continue
+		}
+		go func(source WatchableSource) {
+			err := source.Watch(ctx, func() {
+				// The error is already reported to the OnReloadError callbacks
+				_ = appConf.Reload()
+			}, func(err error) {
+				appConf.reportWatchError(errors.Wrapf(err, "Failed to watch %s", source.Name()))
+			})
+			if err != nil && ctx.Err() == nil {
+				appConf.reportWatchError(errors.Wrapf(err, "Failed to watch %s", source.Name()))
+			}
+		}(watchable)
+	}
+}
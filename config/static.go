@@ -0,0 +1,51 @@
+package config
+
+import (
+	"strconv"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// StaticGetter is a fixed set of configuration values, which can be passed to the constructors expecting
+// a configuration (e.g. logger.NewCommonLoggerFromConfiguration) by the services without an AppConfig.
+//
+//	log := logger.NewCommonLoggerFromConfiguration("service", "v1.0.0", config.StaticGetter{
+//	    constants.APP_ENV:       constants.ENV_PRODUCTION,
+//	    constants.APP_LOG_LEVEL: constants.LOG_LEVEL_INFO,
+//	})
+type StaticGetter map[string]string
+
+// Get returns the named value. If it is not set, an empty string is returned.
+func (s StaticGetter) Get(name string) string {
+	return s[name]
+}
+
+// Lookup returns the named value, and a boolean indicating if it was set.
+func (s StaticGetter) Lookup(name string) (string, bool) {
+	value, ok := s[name]
+	return value, ok
+}
+
+// Env returns the APP_ENV value.
+func (s StaticGetter) Env() string {
+	return s[constants.APP_ENV]
+}
+
+// IsDebug returns true if the APP_DEBUG value is a true boolean.
+func (s StaticGetter) IsDebug() bool {
+	debug, _ := strconv.ParseBool(s[constants.APP_DEBUG])
+	return debug
+}
+
+// IsProduction returns true if the APP_ENV value is production.
+func (s StaticGetter) IsProduction() bool {
+	return s[constants.APP_ENV] == constants.ENV_PRODUCTION
+}
+
+// Hostname returns the EC2_ID value if set, otherwise the hostname like GetHostName.
+func (s StaticGetter) Hostname() string {
+	if hostname := s[constants.EC2_ID]; hostname != "" {
+		return hostname
+	}
+	return GetHostName()
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestStaticGetter() {
+	static := StaticGetter{constants.APP_PORT: "8080"}
+	cts.Equal("8080", static.Get(constants.APP_PORT))
+	cts.Empty(static.Get(constants.APP_ENV), "Missing values should be empty")
+	cts.Equal(GetHostName(), static.Hostname(), "Hostname should fall back to GetHostName")
+
+	static[constants.EC2_ID] = "i-0123456789"
+	cts.Equal("i-0123456789", static.Hostname(), "EC2_ID should be the hostname")
+}
+
+func (cts *ConfigTestSuite) TestStaticGetterConfigReader() {
+	var reader ConfigReader = StaticGetter{
+		constants.APP_ENV:   constants.ENV_PRODUCTION,
+		constants.APP_DEBUG: "1",
+		constants.APP_PORT:  "",
+	}
+	value, ok := reader.Lookup(constants.APP_PORT)
+	cts.True(ok, "Empty values should be found")
+	cts.Empty(value)
+	_, ok = reader.Lookup(constants.APP_LOG_LEVEL)
+	cts.False(ok, "Missing values should not be found")
+	cts.Equal(constants.ENV_PRODUCTION, reader.Env())
+	cts.True(reader.IsProduction())
+	cts.True(reader.IsDebug())
+	cts.False(StaticGetter{}.IsDebug(), "Debug mode should be disabled by default")
+}
@@ -0,0 +1,62 @@
+package config
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// WithStrictEnv makes the Setup fail if environment variables with the prefix of the Variables (APP_ or the
+// prefix of WithPrefix) are set, but not registered, e.g. the APP_LOGLEVEL typo of APP_LOG_LEVEL, which would
+// silently fall back to the default otherwise. The variables set by the envfiles are checked too.
+func WithStrictEnv() SetupOption {
+	return func(o *setupOptions) {
+		o.strictEnv = true
+	}
+}
+
+// WithUnknownEnvHandler calls handler with the sorted names of the unknown environment variables (see
+// WithStrictEnv) instead of failing the Setup, e.g. to log a warning. It is not called if there is none.
+func WithUnknownEnvHandler(handler func(names []string)) SetupOption {
+	return func(o *setupOptions) {
+		o.unknownEnvHandler = handler
+	}
+}
+
+// checkUnknownEnv looks for the unknown environment variables, if enabled by the SetupOptions.
+// values holds the values of every Variable by name, env the environment variables and the envfiles.
+func (appConf *AppConfig) checkUnknownEnv(values map[string]string, env envLookup, options *setupOptions) error {
+	if !options.strictEnv && options.unknownEnvHandler == nil {
+		return nil
+	}
+	unknown := appConf.unknownEnv(values, env)
+	if len(unknown) == 0 {
+		return nil
+	}
+	if options.unknownEnvHandler != nil {
+		options.unknownEnvHandler(unknown)
+		return nil
+	}
+	return errors.Errorf("Unknown environment variables: %s", strings.Join(unknown, ", "))
+}
+
+// unknownEnv returns the sorted names of the environment variables which have the prefix of the Variables,
+// but are neither a Variable nor its FileSuffix variant.
+func (appConf *AppConfig) unknownEnv(values map[string]string, env envLookup) []string {
+	prefix := appConf.externalName(DefaultPrefix)
+	known := make(map[string]bool, 2*len(values))
+	for name := range values {
+		known[appConf.externalName(name)] = true
+		known[appConf.externalName(name)+FileSuffix] = true
+	}
+
+	unknown := []string{}
+	for _, name := range env.names() {
+		if strings.HasPrefix(name, prefix) && !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	return unknown
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"os"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestStrictEnv() {
+	cts.setEnvVars(map[string]string{
+		"STRICT_PORT":      "9090",
+		"STRICT_LOGLEVEL":  constants.LOG_LEVEL_INFO,
+		"STRICT_ENV_FILE":  "",
+		"STRICT_DEBUG_TMP": "1",
+	})
+	defer func() {
+		for _, name := range []string{"STRICT_PORT", "STRICT_LOGLEVEL", "STRICT_ENV_FILE", "STRICT_DEBUG_TMP"} {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}()
+
+	conf := NewConfig(cts.getDefaultConfigs(), WithPrefix("STRICT_"))
+	cts.NoError(conf.Setup(), "Unknown environment variables should be ignored by default")
+
+	cts.EqualError(conf.SetupWithOptions(WithStrictEnv()),
+		"Failed to set Application Configuration: Unknown environment variables: STRICT_DEBUG_TMP, STRICT_LOGLEVEL")
+
+	var unknown []string
+	cts.NoError(conf.SetupWithOptions(WithStrictEnv(), WithUnknownEnvHandler(func(names []string) {
+		unknown = names
+	})))
+	cts.Equal([]string{"STRICT_DEBUG_TMP", "STRICT_LOGLEVEL"}, unknown, "The handler should receive the unknown variables")
+	cts.Equal("9090", conf.Port())
+}
@@ -0,0 +1,176 @@
+package config
+
+import (
+	"reflect"
+	"strconv"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+// durationType is the reflect.Type of time.Duration, which is parsed by time.ParseDuration.
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// FromStruct creates an AppConfig from the tagged fields of the struct ptr points to, e.g.
+//
+//	type Config struct {
+//	    Port     int           `env:"APP_PORT" default:"8080" required:"true" desc:"The port"`
+//	    Timeout  time.Duration `env:"APP_TIMEOUT" default:"30s"`
+//	    Password string        `env:"APP_DB_PASSWORD" sensitive:"true" group:"Database"`
+//	}
+//
+// The tags are env (the name of the Variable, the fields without it are skipped), default, desc, group,
+// required and sensitive, every env tag must be unique. The string, bool, integer, float and time.Duration fields get a rule checking
+// the conversion, the nested structs are walked too. After a successful Setup the values are written
+// into the struct, use Unmarshal to update it after a Reload.
+func FromStruct(ptr interface{}) (*AppConfig, error) {
+	fields, err := structFields(ptr)
+	if err != nil {
+		return nil, err
+	}
+	vars := make(map[string]*Variable, len(fields))
+	for _, field := range fields {
+		typ := field.value.Type()
+		if !supportedType(typ) {
+			return nil, errors.Errorf("Unsupported type %s of %s", typ, field.name)
+		}
+		if _, ok := vars[field.name]; ok {
+			return nil, errors.Errorf("Duplicate env tag %s", field.name)
+		}
+		tag := field.tag
+		confVar := &Variable{
+			DefaultValue: tag.Get("default"),
+			Description:  tag.Get("desc"),
+			Group:        tag.Get("group"),
+			Rules: map[string]validation.Rule{
+				"Valid " + typ.String(): validation.By(func(value interface{}) error {
+					str, _ := value.(string)
+					if str == "" {
+						return nil
+					}
+					if err := setField(reflect.New(typ).Elem(), str); err != nil {
+						return validation.NewError("validation_struct_field", "must be a valid "+typ.String())
+					}
+					return nil
+				}),
+			},
+		}
+		if required, _ := strconv.ParseBool(tag.Get("required")); required {
+			confVar.Rules["Required"] = validation.Required
+		}
+		confVar.Sensitive, _ = strconv.ParseBool(tag.Get("sensitive"))
+		vars[field.name] = confVar
+	}
+	conf := NewConfig(vars)
+	conf.target = ptr
+	return conf, nil
+}
+
+// Unmarshal writes the current values into the fields of the struct ptr points to, by their env tags
+// (see FromStruct). The empty values leave the fields unchanged.
+func (appConf *AppConfig) Unmarshal(ptr interface{}) error {
+	fields, err := structFields(ptr)
+	if err != nil {
+		return err
+	}
+	for _, field := range fields {
+		value := appConf.Get(field.name)
+		if value == "" {
+			continue
+		}
+		if err := setField(field.value, value); err != nil {
+			return errors.Wrapf(err, "Failed to set %s", field.name)
+		}
+	}
+	return nil
+}
+
+// structField is a tagged field of a struct.
+type structField struct {
+	name  string
+	tag   reflect.StructTag
+	value reflect.Value
+}
+
+// structFields returns the fields of the struct ptr points to with an env tag, including the nested structs.
+func structFields(ptr interface{}) ([]structField, error) {
+	value := reflect.ValueOf(ptr)
+	if value.Kind() != reflect.Ptr || value.Elem().Kind() != reflect.Struct {
+		return nil, errors.Errorf("Expected a pointer to a struct, got %T", ptr)
+	}
+	return collectFields(value.Elem(), nil), nil
+}
+
+// collectFields appends the tagged fields of the struct value to fields.
+func collectFields(value reflect.Value, fields []structField) []structField {
+	for i := 0; i < value.NumField(); i++ {
+		field, fieldType := value.Field(i), value.Type().Field(i)
+		if !fieldType.IsExported() {
+			continue
+		}
+		name := fieldType.Tag.Get("env")
+		if name == "" {
+			if field.Kind() == reflect.Struct && field.Type() != durationType {
+				fields = collectFields(field, fields)
+			}
+			continue
+		}
+		fields = append(fields, structField{name: name, tag: fieldType.Tag, value: field})
+	}
+	return fields
+}
+
+// supportedType reports whether setField can set the fields of type t.
+func supportedType(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.String, reflect.Bool, reflect.Float32, reflect.Float64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return true
+	}
+	return false
+}
+
+// setField converts value to the type of field and sets it.
+func setField(field reflect.Value, value string) error {
+	if field.Type() == durationType {
+		duration, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		field.SetInt(int64(duration))
+		return nil
+	}
+	switch field.Kind() {
+	case reflect.String:
+		field.SetString(value)
+	case reflect.Bool:
+		parsed, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		field.SetBool(parsed)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		parsed, err := strconv.ParseInt(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetInt(parsed)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		parsed, err := strconv.ParseUint(value, 10, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetUint(parsed)
+	case reflect.Float32, reflect.Float64:
+		parsed, err := strconv.ParseFloat(value, field.Type().Bits())
+		if err != nil {
+			return err
+		}
+		field.SetFloat(parsed)
+	default:
+		return errors.Errorf("Unsupported field type %s", field.Type())
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// structConfig is the tagged struct of the FromStruct tests
+type structConfig struct {
+	Port    int           `env:"APP_PORT" default:"8080" required:"true" desc:"The port"`
+	Debug   bool          `env:"APP_DEBUG"`
+	Timeout time.Duration `env:"APP_TIMEOUT" default:"30s"`
+	Ratio   float64       `env:"APP_RATIO" default:"0.5"`
+	DB      struct {
+		Host     string `env:"APP_DB_HOST" default:"localhost" group:"Database"`
+		Password string `env:"APP_DB_PASSWORD" sensitive:"true" group:"Database"`
+	}
+	Ignored string
+}
+
+func (cts *ConfigTestSuite) TestFromStruct() {
+	for _, name := range []string{constants.APP_PORT, constants.APP_DEBUG} {
+		cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+	}
+	cts.setEnvVars(map[string]string{"APP_DB_PASSWORD": "s3cr3t", "APP_TIMEOUT": "1m"})
+	defer func() {
+		for _, name := range []string{"APP_DB_PASSWORD", "APP_TIMEOUT", constants.APP_PORT} {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}()
+
+	cfg := &structConfig{Debug: true}
+	conf, err := FromStruct(cfg)
+	cts.Require().NoError(err)
+	cts.NoError(conf.Setup())
+	cts.Equal(8080, cfg.Port)
+	cts.True(cfg.Debug, "Empty values should leave the fields unchanged")
+	cts.Equal(time.Minute, cfg.Timeout)
+	cts.Equal(0.5, cfg.Ratio)
+	cts.Equal("localhost", cfg.DB.Host)
+	cts.Equal("s3cr3t", cfg.DB.Password)
+
+	table := conf.DumpTable()
+	cts.Contains(table, "The port")
+	cts.Contains(table, "Required, Valid int")
+	cts.NotContains(table, "s3cr3t", "Sensitive values should be masked")
+
+	cts.setEnvVars(map[string]string{constants.APP_PORT: "port"})
+	cts.Error(conf.Setup(), "Invalid values should fail the Setup")
+	cts.Equal(8080, cfg.Port, "Invalid values should not be unmarshalled")
+
+	cts.NoError(conf.Set("APP_RATIO", "0.75"))
+	cts.Error(conf.Unmarshal(cfg), "The invalid port should fail the Unmarshal")
+
+	mixed, err := FromStruct(&struct {
+		Port int    `env:"APP_PORT"`
+		Name string `env:"APP_NAME"`
+	}{})
+	cts.Require().NoError(err)
+	cts.EqualError(mixed.Set(constants.APP_PORT, "abc"), "Invalid value for APP_PORT = abc: Valid int: must be a valid int.",
+		"Every field should be checked with its own type")
+	cts.NoError(mixed.Set("APP_NAME", "abc"))
+
+	_, err = FromStruct(structConfig{})
+	cts.EqualError(err, "Expected a pointer to a struct, got config.structConfig")
+	_, err = FromStruct(&struct {
+		Hosts []string `env:"APP_HOSTS"`
+	}{})
+	cts.EqualError(err, "Unsupported type []string of APP_HOSTS")
+	_, err = FromStruct(&struct {
+		Host string `env:"APP_HOST"`
+		DB   struct {
+			Host string `env:"APP_HOST"`
+		}
+	}{})
+	cts.EqualError(err, "Duplicate env tag APP_HOST")
+}
@@ -0,0 +1,103 @@
+package config
+
+// subscriptionBuffer is the capacity of the channels returned by Subscribe.
+const subscriptionBuffer = 64
+
+// Change is the change of the value of a Variable.
+type Change struct {
+	// Name is the name of the changed Variable.
+	Name string
+
+	// OldValue is the value before the change.
+	OldValue string
+
+	// NewValue is the value after the change.
+	NewValue string
+}
+
+// subscription is a channel registered by Subscribe.
+type subscription struct {
+	// names are the names of the Variables the subscription receives the Changes of, all if empty.
+	names map[string]bool
+
+	ch chan Change
+}
+
+// Subscribe returns a channel receiving the Changes of the named Variables (of every Variable if no name
+// is supplied) made by Reload, the watchers, Set and Override, e.g. to adjust the log level at runtime:
+//
+//	for change := range conf.Subscribe(constants.APP_LOG_LEVEL) {
+//	    log.SetLevel(change.NewValue)
+//	}
+//
+// The Changes are delivered in the order the values changed. The channel is buffered, if it is full the Change
+// is dropped and reported to the OnDroppedChange callbacks, so a slow subscriber cannot block the reloads.
+// Call Unsubscribe to close the channel.
+func (appConf *AppConfig) Subscribe(names ...string) <-chan Change {
+	sub := &subscription{names: map[string]bool{}, ch: make(chan Change, subscriptionBuffer)}
+	for _, name := range names {
+		sub.names[name] = true
+	}
+	appConf.subMu.Lock()
+	defer appConf.subMu.Unlock()
+	appConf.subscriptions = append(appConf.subscriptions, sub)
+	return sub.ch
+}
+
+// Unsubscribe stops the delivery of the Changes to ch returned by Subscribe, and closes it.
+func (appConf *AppConfig) Unsubscribe(ch <-chan Change) {
+	appConf.subMu.Lock()
+	defer appConf.subMu.Unlock()
+	for i, sub := range appConf.subscriptions {
+		if sub.ch == ch {
+			appConf.subscriptions = append(appConf.subscriptions[:i], appConf.subscriptions[i+1:]...)
+			close(sub.ch)
+			return
+		}
+	}
+}
+
+// OnDroppedChange registers a callback which is invoked with the Changes dropped because the channel of a
+// subscription was full.
+func (appConf *AppConfig) OnDroppedChange(fn func(change Change)) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.onDroppedChange = append(appConf.onDroppedChange, fn)
+}
+
+// publish delivers the changes of the named Variables between before and after to the subscriptions, and
+// returns the dropped Changes. The caller must hold the lock, so the Changes are delivered in order.
+func (appConf *AppConfig) publish(before, after map[string]string, names []string) []Change {
+	appConf.subMu.Lock()
+	defer appConf.subMu.Unlock()
+	dropped := []Change{}
+	for _, name := range names {
+		change := Change{Name: name, OldValue: before[name], NewValue: after[name]}
+		for _, sub := range appConf.subscriptions {
+			if len(sub.names) > 0 && !sub.names[name] {
+				continue
+			}
+			select {
+			case sub.ch <- change:
+			default:
+				dropped = append(dropped, change)
+			}
+		}
+	}
+	return dropped
+}
+
+// reportDropped invokes the OnDroppedChange callbacks with the dropped Changes.
+func (appConf *AppConfig) reportDropped(dropped []Change) {
+	if len(dropped) == 0 {
+		return
+	}
+	appConf.mu.RLock()
+	callbacks := append([]func(Change){}, appConf.onDroppedChange...)
+	appConf.mu.RUnlock()
+	for _, change := range dropped {
+		for _, fn := range callbacks {
+			fn(change)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+	"strconv"
+	"sync"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestSubscribe() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer os.Remove(envFile)
+	cts.writeEnvfile(envFile, map[string]string{constants.APP_PORT: "9090"})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(envFile), "Config should have been set up")
+	all := conf.Subscribe()
+	logLevel := conf.Subscribe(constants.APP_LOG_LEVEL)
+
+	cts.NoError(ioutil.WriteFile(envFile, []byte("APP_PORT=7070\nAPP_LOG_LEVEL=warn\n"), 0600))
+	cts.NoError(conf.Reload(), "Changed config should be reloaded")
+	cts.Equal(Change{Name: constants.APP_LOG_LEVEL, OldValue: constants.LOG_LEVEL_DEBUG, NewValue: constants.LOG_LEVEL_WARN}, <-logLevel)
+	cts.Equal(Change{Name: constants.APP_LOG_LEVEL, OldValue: constants.LOG_LEVEL_DEBUG, NewValue: constants.LOG_LEVEL_WARN}, <-all)
+	cts.Equal(Change{Name: constants.APP_PORT, OldValue: "9090", NewValue: "7070"}, <-all)
+
+	cts.NoError(conf.Set(constants.APP_LOG_LEVEL, constants.LOG_LEVEL_ERROR))
+	cts.Equal(Change{Name: constants.APP_LOG_LEVEL, OldValue: constants.LOG_LEVEL_WARN, NewValue: constants.LOG_LEVEL_ERROR}, <-logLevel)
+	conf.Override(map[string]string{constants.APP_PORT: "6060"})
+	cts.Equal(Change{Name: constants.APP_LOG_LEVEL, OldValue: constants.LOG_LEVEL_WARN, NewValue: constants.LOG_LEVEL_ERROR}, <-all)
+	cts.Equal(Change{Name: constants.APP_PORT, OldValue: "7070", NewValue: "6060"}, <-all)
+	cts.Empty(logLevel, "Changes of other Variables should not be delivered")
+
+	conf.Unsubscribe(logLevel)
+	_, ok := <-logLevel
+	cts.False(ok, "The channel should be closed")
+	cts.NoError(conf.Set(constants.APP_LOG_LEVEL, constants.LOG_LEVEL_INFO), "Unsubscribed channels should not receive changes")
+
+	dropped := []Change{}
+	conf.OnDroppedChange(func(change Change) { dropped = append(dropped, change) })
+	conf.OnReloadError(func(err error) { cts.Fail("A dropped change is not a reload error", err) })
+	for i := 0; i < subscriptionBuffer; i++ {
+		conf.Override(map[string]string{constants.APP_PORT: string(rune('a' + i%2))})
+	}
+	cts.Equal([]Change{{Name: constants.APP_PORT, OldValue: "a", NewValue: "b"}}, dropped, "The change should be dropped when the channel is full")
+}
+
+func (cts *ConfigTestSuite) TestSubscribeOrder() {
+	conf := NewConfig(map[string]*Variable{constants.APP_PORT: {DefaultValue: "8080"}})
+	cts.NoError(os.Unsetenv(constants.APP_PORT))
+	cts.NoError(conf.Setup())
+	changes := conf.Subscribe(constants.APP_PORT)
+
+	var wg sync.WaitGroup
+	for i := 0; i < subscriptionBuffer/2; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			cts.NoError(conf.Set(constants.APP_PORT, strconv.Itoa(9000+i)))
+		}(i)
+	}
+	wg.Wait()
+	last := "8080"
+	for len(changes) > 0 {
+		change := <-changes
+		cts.Equal(last, change.OldValue, "The changes should be delivered in order")
+		last = change.NewValue
+	}
+	cts.Equal(conf.Port(), last, "The last change should be the current value")
+}
@@ -0,0 +1,26 @@
+package config
+
+import (
+	"text/template"
+
+	"github.com/pkg/errors"
+)
+
+// TemplateFuncs returns the functions exposing the configuration to text/template, so the generated files
+// (e.g. nginx or systemd configurations) are rendered from the same validated values:
+//
+//	tmpl := template.Must(template.New("nginx").Funcs(conf.TemplateFuncs()).Parse(
+//	    `listen {{ config "APP_PORT" }};`))
+//
+// The config function fails the rendering if the Variable is unknown.
+func (appConf *AppConfig) TemplateFuncs() template.FuncMap {
+	return template.FuncMap{
+		"config": func(name string) (string, error) {
+			value, ok := appConf.Lookup(name)
+			if !ok {
+				return "", errors.Errorf("Unknown configuration variable %s", name)
+			}
+			return value, nil
+		},
+	}
+}
@@ -0,0 +1,25 @@
+package config
+
+import (
+	"strings"
+	"text/template"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestTemplateFuncs() {
+	conf := NewConfig(map[string]*Variable{constants.APP_PORT: {}})
+	conf.Override(map[string]string{constants.APP_PORT: "9090"})
+
+	tmpl, err := template.New("nginx").Funcs(conf.TemplateFuncs()).Parse(`listen {{ config "APP_PORT" }};`)
+	cts.Require().NoError(err)
+	out := &strings.Builder{}
+	cts.NoError(tmpl.Execute(out, nil))
+	cts.Equal("listen 9090;", out.String())
+
+	tmpl, err = template.New("unknown").Funcs(conf.TemplateFuncs()).Parse(`{{ config "APP_UNKNOWN" }}`)
+	cts.Require().NoError(err)
+	err = tmpl.Execute(&strings.Builder{}, nil)
+	cts.Error(err)
+	cts.Contains(err.Error(), "Unknown configuration variable APP_UNKNOWN")
+}
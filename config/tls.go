@@ -0,0 +1,158 @@
+package config
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"io/ioutil"
+	"os"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// TLSGroup is the Group of the TLSVariables.
+const TLSGroup = "TLS"
+
+// tlsVersions maps the values of APP_TLS_MIN_VERSION to the crypto/tls versions.
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// tlsClientAuths maps the values of APP_TLS_CLIENT_AUTH to the crypto/tls client authentication types.
+var tlsClientAuths = map[string]tls.ClientAuthType{
+	constants.TLS_CLIENT_AUTH_NONE:               tls.NoClientCert,
+	constants.TLS_CLIENT_AUTH_REQUEST:            tls.RequestClientCert,
+	constants.TLS_CLIENT_AUTH_REQUIRE:            tls.RequireAnyClientCert,
+	constants.TLS_CLIENT_AUTH_VERIFY_IF_GIVEN:    tls.VerifyClientCertIfGiven,
+	constants.TLS_CLIENT_AUTH_REQUIRE_AND_VERIFY: tls.RequireAndVerifyClientCert,
+}
+
+// TLSVariables returns the standard Variables of a TLS server (APP_TLS_CERT_FILE, APP_TLS_KEY_FILE,
+// APP_TLS_CA_FILE, APP_TLS_MIN_VERSION and APP_TLS_CLIENT_AUTH), to be added to the defaults of NewConfig
+// or registered with AddVariable. The files must exist, the certificate and the key must be set together, and
+// the CA bundle is required if APP_TLS_CLIENT_AUTH verifies the client certificates.
+// The *tls.Config of the server is built by TLSConfig.
+func TLSVariables() map[string]*Variable {
+	return map[string]*Variable{
+		constants.APP_TLS_CERT_FILE: {
+			Description: "Path of the PEM encoded certificate (chain) of the server",
+			Group:       TLSGroup,
+			Rules: map[string]validation.Rule{
+				"Existing file":              existingFile,
+				"Required with the key file": requiredWith(constants.APP_TLS_KEY_FILE),
+			},
+		},
+		constants.APP_TLS_KEY_FILE: {
+			Description: "Path of the PEM encoded private key of the server",
+			Group:       TLSGroup,
+			Rules: map[string]validation.Rule{
+				"Existing file":               existingFile,
+				"Required with the cert file": requiredWith(constants.APP_TLS_CERT_FILE),
+			},
+		},
+		constants.APP_TLS_CA_FILE: {
+			Description: "Path of the PEM encoded CA bundle verifying the client certificates",
+			Group:       TLSGroup,
+			Rules: map[string]validation.Rule{
+				"Existing file":                  existingFile,
+				"Required to verify the clients": requiredToVerifyClients,
+			},
+		},
+		constants.APP_TLS_MIN_VERSION: {
+			DefaultValue: "1.2",
+			Description:  "Minimum TLS version (1.0, 1.1, 1.2 or 1.3)",
+			Group:        TLSGroup,
+			Rules: map[string]validation.Rule{
+				"Valid TLS version": validation.In(constants.ValidTLSVersions...),
+			},
+		},
+		constants.APP_TLS_CLIENT_AUTH: {
+			DefaultValue: constants.TLS_CLIENT_AUTH_NONE,
+			Description:  "Client certificate authentication (none, request, require, verify-if-given, require-and-verify)",
+			Group:        TLSGroup,
+			Rules: map[string]validation.Rule{
+				"Valid client auth": validation.In(constants.ValidTLSClientAuths...),
+			},
+		},
+	}
+}
+
+// TLSConfig returns the *tls.Config of a server built from the TLSVariables: the certificate and key pair
+// (if set), the minimum version, the client authentication and the CA bundle verifying the client certificates.
+// It returns an error if the files cannot be loaded.
+func (appConf *AppConfig) TLSConfig() (*tls.Config, error) {
+	// The values are validated by the Setup, the invalid ones fall back to the defaults
+	tlsConf := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ClientAuth: tlsClientAuths[appConf.Get(constants.APP_TLS_CLIENT_AUTH)],
+	}
+	if version, ok := tlsVersions[appConf.Get(constants.APP_TLS_MIN_VERSION)]; ok {
+		tlsConf.MinVersion = version
+	}
+
+	certFile, keyFile := appConf.Get(constants.APP_TLS_CERT_FILE), appConf.Get(constants.APP_TLS_KEY_FILE)
+	if certFile != "" || keyFile != "" {
+		cert, err := tls.LoadX509KeyPair(certFile, keyFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to load the TLS certificate")
+		}
+		tlsConf.Certificates = []tls.Certificate{cert}
+	}
+
+	if caFile := appConf.Get(constants.APP_TLS_CA_FILE); caFile != "" {
+		content, err := ioutil.ReadFile(caFile)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Failed to read %s", constants.APP_TLS_CA_FILE)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(content) {
+			return nil, errors.Errorf("No certificate found in %s", caFile)
+		}
+		tlsConf.ClientCAs = pool
+	}
+	return tlsConf, nil
+}
+
+// existingFile validates if a non-empty string is the path of an existing regular file.
+var existingFile = validation.By(func(value interface{}) error {
+	path, err := validation.EnsureString(value)
+	if err != nil || path == "" {
+		return err
+	}
+	if info, err := os.Stat(path); err != nil || !info.Mode().IsRegular() {
+		return validation.NewError("validation_config_file_exists", "must be the path of an existing file")
+	}
+	return nil
+})
+
+// requiredToVerifyClients requires the CA bundle if APP_TLS_CLIENT_AUTH verifies the client certificates,
+// which cannot be verified without it.
+var requiredToVerifyClients = validation.WithContext(func(ctx context.Context, value interface{}) error {
+	switch ValuesFromContext(ctx)[constants.APP_TLS_CLIENT_AUTH] {
+	case constants.TLS_CLIENT_AUTH_VERIFY_IF_GIVEN, constants.TLS_CLIENT_AUTH_REQUIRE_AND_VERIFY:
+	default:
+		return nil
+	}
+	if err := validation.Required.Validate(value); err != nil {
+		return validation.NewError("validation_required_to_verify", "cannot be blank when "+constants.APP_TLS_CLIENT_AUTH+" verifies the client certificates")
+	}
+	return nil
+})
+
+// requiredWith returns a rule which requires the value if the named Variable is set.
+func requiredWith(name string) validation.Rule {
+	return validation.WithContext(func(ctx context.Context, value interface{}) error {
+		if ValuesFromContext(ctx)[name] == "" {
+			return nil
+		}
+		if err := validation.Required.Validate(value); err != nil {
+			return validation.NewError("validation_required_with", "cannot be blank when "+name+" is set")
+		}
+		return nil
+	})
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"io/ioutil"
+	"math/big"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// writeCertificate writes a self-signed certificate and its key into dir, and returns their paths.
+func (cts *ConfigTestSuite) writeCertificate(dir string) (string, string) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	cts.Require().NoError(err)
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "localhost"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	cts.Require().NoError(err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	cts.Require().NoError(err)
+
+	certFile, keyFile := filepath.Join(dir, "tls.crt"), filepath.Join(dir, "tls.key")
+	cts.Require().NoError(ioutil.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	cts.Require().NoError(ioutil.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	return certFile, keyFile
+}
+
+func (cts *ConfigTestSuite) TestTLSConfig() {
+	unsetEnv := func() {
+		for name := range TLSVariables() {
+			cts.NoError(os.Unsetenv(name), "Environment variable should have been unset")
+		}
+	}
+	unsetEnv()
+	defer unsetEnv()
+
+	conf := NewConfig(TLSVariables())
+	cts.NoError(conf.Setup())
+	tlsConf, err := conf.TLSConfig()
+	cts.NoError(err)
+	cts.Equal(uint16(tls.VersionTLS12), tlsConf.MinVersion)
+	cts.Equal(tls.NoClientCert, tlsConf.ClientAuth)
+	cts.Empty(tlsConf.Certificates, "No certificate should be loaded by default")
+
+	dir := cts.T().TempDir()
+	certFile, keyFile := cts.writeCertificate(dir)
+	cts.setEnvVars(map[string]string{
+		constants.APP_TLS_CERT_FILE:   certFile,
+		constants.APP_TLS_KEY_FILE:    keyFile,
+		constants.APP_TLS_CA_FILE:     certFile,
+		constants.APP_TLS_MIN_VERSION: "1.3",
+		constants.APP_TLS_CLIENT_AUTH: constants.TLS_CLIENT_AUTH_REQUIRE_AND_VERIFY,
+	})
+	cts.NoError(conf.Setup())
+	tlsConf, err = conf.TLSConfig()
+	cts.NoError(err)
+	cts.Equal(uint16(tls.VersionTLS13), tlsConf.MinVersion)
+	cts.Equal(tls.RequireAndVerifyClientCert, tlsConf.ClientAuth)
+	cts.Len(tlsConf.Certificates, 1)
+	cts.NotNil(tlsConf.ClientCAs)
+
+	cts.NoError(ioutil.WriteFile(filepath.Join(dir, "ca.pem"), []byte("not a certificate"), 0600))
+	cts.setEnvVars(map[string]string{constants.APP_TLS_CA_FILE: filepath.Join(dir, "ca.pem")})
+	cts.NoError(conf.Setup())
+	_, err = conf.TLSConfig()
+	cts.EqualError(err, "No certificate found in "+filepath.Join(dir, "ca.pem"))
+
+	cts.NoError(os.Unsetenv(constants.APP_TLS_CA_FILE))
+	cts.EqualError(errors.Cause(conf.Setup()), "APP_TLS_CA_FILE = : (Required to verify the clients: cannot be blank when "+
+		"APP_TLS_CLIENT_AUTH verifies the client certificates.).", "The CA bundle should be required to verify the clients")
+
+	cts.NoError(os.Unsetenv(constants.APP_TLS_KEY_FILE))
+	cts.setEnvVars(map[string]string{
+		constants.APP_TLS_CA_FILE:     filepath.Join(dir, "missing.pem"),
+		constants.APP_TLS_MIN_VERSION: "1.4",
+	})
+	cts.EqualError(errors.Cause(conf.Setup()), "APP_TLS_CA_FILE = "+filepath.Join(dir, "missing.pem")+": (Existing file: must be the path of an existing file.); "+
+		"APP_TLS_KEY_FILE = : (Required with the cert file: cannot be blank when APP_TLS_CERT_FILE is set.); "+
+		"APP_TLS_MIN_VERSION = 1.4: (Valid TLS version: must be a valid value.).")
+}
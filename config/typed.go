@@ -0,0 +1,148 @@
+package config
+
+import (
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+// TypedVariable is a Variable with a typed value, created by NewTyped or the helpers like Int, and
+// registered by AppConfig.Register.
+type TypedVariable interface {
+	// Name returns the name of the Variable.
+	Name() string
+
+	// variable returns the definition of the Variable.
+	variable() *Variable
+}
+
+// Typed is a Variable converted to T, which is validated and converted once at Setup (and whenever its value
+// changes), so the call sites don't parse the strings, e.g.
+//
+//	port := config.Int(constants.APP_PORT, 8080, validation.Min(1), validation.Max(65535))
+//	if err := conf.Register(port); err != nil {
+//	    ...
+//	}
+//	...
+//	listen(port.Value())
+type Typed[T any] struct {
+	name         string
+	defaultValue T
+	parse        func(string) (T, error)
+	def          *Variable
+
+	// mu guards the cached conversion
+	mu    sync.Mutex
+	value T
+}
+
+// NewTyped creates a Typed Variable with its default value and conversions. The rules are applied on the
+// converted value, e.g. validation.Min on an int. Empty values are converted to the default value.
+func NewTyped[T any](name string, defaultValue T, parse func(string) (T, error), format func(T) string,
+	rules ...validation.Rule) *Typed[T] {
+	t := &Typed[T]{name: name, defaultValue: defaultValue, parse: parse, value: defaultValue}
+	t.def = &Variable{
+		DefaultValue: format(defaultValue),
+		convert:      t.convert,
+		Rules: map[string]validation.Rule{
+			fmt.Sprintf("Valid %T", defaultValue): validation.By(func(value interface{}) error {
+				raw, _ := value.(string)
+				if raw == "" {
+					return nil
+				}
+				converted, err := parse(raw)
+				if err != nil {
+					return validation.NewError("validation_typed", fmt.Sprintf("must be a valid %T", defaultValue))
+				}
+				return validation.Validate(converted, rules...)
+			}),
+		},
+	}
+	return t
+}
+
+// WithDescription sets the Description of the Variable, it must be called before the Register.
+func (t *Typed[T]) WithDescription(description string) *Typed[T] {
+	t.def.Description = description
+	return t
+}
+
+// Name implements the TypedVariable interface.
+func (t *Typed[T]) Name() string {
+	return t.name
+}
+
+// variable implements the TypedVariable interface.
+func (t *Typed[T]) variable() *Variable {
+	return t.def
+}
+
+// convert caches the value converted to T, the default value if raw is empty or invalid (e.g. set by Override).
+func (t *Typed[T]) convert(raw string) {
+	value := t.defaultValue
+	if raw != "" {
+		if converted, err := t.parse(raw); err == nil {
+			value = converted
+		}
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.value = value
+}
+
+// Value returns the value of the Variable converted to T at the last Setup, Reload or change. It returns the
+// default value if the Variable is not registered, empty or invalid (e.g. set by Override).
+func (t *Typed[T]) Value() T {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.value
+}
+
+// Register adds the Variables of the TypedVariables to the AppConfig like AddVariable, their values are
+// converted by the Setup.
+func (appConf *AppConfig) Register(vars ...TypedVariable) error {
+	for _, typed := range vars {
+		if err := appConf.AddVariable(typed.Name(), typed.variable()); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// String creates a Typed string Variable.
+func String(name, defaultValue string, rules ...validation.Rule) *Typed[string] {
+	return NewTyped(name, defaultValue, func(raw string) (string, error) { return raw, nil },
+		func(value string) string { return value }, rules...)
+}
+
+// Int creates a Typed int Variable.
+func Int(name string, defaultValue int, rules ...validation.Rule) *Typed[int] {
+	return NewTyped(name, defaultValue, strconv.Atoi, strconv.Itoa, rules...)
+}
+
+// Int64 creates a Typed int64 Variable.
+func Int64(name string, defaultValue int64, rules ...validation.Rule) *Typed[int64] {
+	return NewTyped(name, defaultValue, func(raw string) (int64, error) { return strconv.ParseInt(raw, 10, 64) },
+		func(value int64) string { return strconv.FormatInt(value, 10) }, rules...)
+}
+
+// Float64 creates a Typed float64 Variable.
+func Float64(name string, defaultValue float64, rules ...validation.Rule) *Typed[float64] {
+	return NewTyped(name, defaultValue, func(raw string) (float64, error) { return strconv.ParseFloat(raw, 64) },
+		func(value float64) string { return strconv.FormatFloat(value, 'g', -1, 64) }, rules...)
+}
+
+// Bool creates a Typed bool Variable, the values are parsed by strconv.ParseBool like IsDebug.
+func Bool(name string, defaultValue bool, rules ...validation.Rule) *Typed[bool] {
+	return NewTyped(name, defaultValue, strconv.ParseBool, strconv.FormatBool, rules...)
+}
+
+// Duration creates a Typed time.Duration Variable, the values are parsed by time.ParseDuration (e.g. 1m30s).
+func Duration(name string, defaultValue time.Duration, rules ...validation.Rule) *Typed[time.Duration] {
+	t := NewTyped(name, defaultValue, time.ParseDuration, time.Duration.String, rules...)
+	t.def.Hint = DurationHint
+	return t
+}
@@ -0,0 +1,63 @@
+package config
+
+import (
+	"os"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestTyped() {
+	cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	cts.setEnvVars(map[string]string{"APP_TIMEOUT": "1m30s", "APP_RATIO": "0.5"})
+	defer func() {
+		cts.NoError(os.Unsetenv("APP_TIMEOUT"), "Environment variable should have been unset")
+		cts.NoError(os.Unsetenv("APP_RATIO"), "Environment variable should have been unset")
+	}()
+
+	port := Int(constants.APP_PORT, 8080, validation.Min(1), validation.Max(65535)).WithDescription("The port")
+	timeout := Duration("APP_TIMEOUT", time.Second)
+	ratio := Float64("APP_RATIO", 1)
+	debug := Bool(constants.APP_DEBUG, false)
+	name := String("APP_NAME", "service", validation.Length(3, 0))
+	cts.Equal(8080, port.Value(), "The default value should be returned before the Register")
+
+	conf := NewConfig(nil)
+	cts.NoError(conf.Register(port, timeout, ratio, debug, name))
+	cts.Error(conf.Register(Int(constants.APP_PORT, 1)), "Duplicate Variables should not be registered")
+	cts.NoError(conf.Setup())
+	cts.Equal(8080, port.Value())
+	cts.Equal(90*time.Second, timeout.Value())
+	cts.Equal(0.5, ratio.Value())
+	cts.False(debug.Value())
+	cts.Equal("service", name.Value())
+	cts.Contains(conf.DumpTable(), "The port")
+
+	cts.NoError(conf.Set(constants.APP_PORT, "9090"))
+	cts.Equal(9090, port.Value(), "The changed value should be converted")
+	cts.EqualError(conf.Set(constants.APP_PORT, "70000"), "Invalid value for APP_PORT = 70000: Valid int: must be no greater than 65535.")
+	cts.EqualError(conf.Set(constants.APP_PORT, "port"), "Invalid value for APP_PORT = port: Valid int: must be a valid int.")
+	cts.EqualError(conf.Set("APP_NAME", "ab"), "Invalid value for APP_NAME = ab: Valid string: the length must be no less than 3.")
+
+	conf.Override(map[string]string{constants.APP_PORT: "port"})
+	cts.Equal(8080, port.Value(), "Invalid values should be converted to the default")
+}
+
+func (cts *ConfigTestSuite) TestTypedSetup() {
+	cts.setEnvVars(map[string]string{constants.APP_PORT: "9090"})
+	defer func() {
+		cts.NoError(os.Unsetenv(constants.APP_PORT), "Environment variable should have been unset")
+	}()
+
+	port := Int(constants.APP_PORT, 8080)
+	conf := NewConfig(nil)
+	cts.NoError(conf.Register(port))
+	cts.Equal(8080, port.Value(), "The default value should be returned before the Setup")
+	cts.NoError(conf.Setup())
+	cts.NoError(os.Setenv(constants.APP_PORT, "port"), "Environment variable should have been set")
+	cts.Equal(9090, port.Value(), "The value should be converted at the Setup")
+
+	cts.Error(conf.Setup(), "Invalid values should fail the Setup")
+	cts.Equal(8080, port.Value(), "Invalid values should be converted to the default")
+}
@@ -0,0 +1,85 @@
+package config
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+)
+
+const (
+	// DurationHint is the Hint of the duration Variables, see Duration.
+	DurationHint = "duration, e.g. 300ms, 30s, 5m, 1h30m"
+
+	// ByteSizeHint is the Hint of the byte size Variables, see ByteSize.
+	ByteSizeHint = "byte size, e.g. 512, 64KB, 512MB, 2GiB"
+)
+
+// byteUnits are the multipliers of the byte size units, KB is 1000 bytes and KiB is 1024 bytes.
+var byteUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1000,
+	"MB":  1000 * 1000,
+	"GB":  1000 * 1000 * 1000,
+	"TB":  1000 * 1000 * 1000 * 1000,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+}
+
+// ParseByteSize parses a byte size, a non-negative integer with an optional unit (e.g. 512, 64KB, 2GiB).
+// The units are case-insensitive, the decimal ones (KB, MB, GB, TB) are powers of 1000, the binary ones
+// (KiB, MiB, GiB, TiB) are powers of 1024.
+func ParseByteSize(value string) (int64, error) {
+	trimmed := strings.TrimSpace(value)
+	split := strings.IndexFunc(trimmed, func(r rune) bool { return r < '0' || r > '9' })
+	if split < 0 {
+		split = len(trimmed)
+	}
+	number, err := strconv.ParseInt(trimmed[:split], 10, 64)
+	if err != nil {
+		return 0, errors.Errorf("Invalid byte size %q", value)
+	}
+	multiplier, ok := byteUnits[strings.ToUpper(strings.TrimSpace(trimmed[split:]))]
+	if !ok {
+		return 0, errors.Errorf("Invalid byte size unit in %q", value)
+	}
+	if number > 0 && multiplier > (1<<63-1)/number {
+		return 0, errors.Errorf("Byte size %q is out of range", value)
+	}
+	return number * multiplier, nil
+}
+
+// FormatByteSize formats size with the largest binary unit it is a multiple of, e.g. 2GiB or 1500.
+func FormatByteSize(size int64) string {
+	for _, unit := range []string{"TiB", "GiB", "MiB", "KiB"} {
+		multiplier := byteUnits[strings.ToUpper(unit)]
+		if size != 0 && size%multiplier == 0 {
+			return strconv.FormatInt(size/multiplier, 10) + unit
+		}
+	}
+	return strconv.FormatInt(size, 10)
+}
+
+// GetDuration returns the named Variable's value parsed by time.ParseDuration.
+func (appConf *AppConfig) GetDuration(name string) (time.Duration, error) {
+	duration, err := time.ParseDuration(appConf.Get(name))
+	return duration, errors.Wrapf(err, "Invalid duration %s", name)
+}
+
+// GetByteSize returns the named Variable's value parsed by ParseByteSize.
+func (appConf *AppConfig) GetByteSize(name string) (int64, error) {
+	size, err := ParseByteSize(appConf.Get(name))
+	return size, errors.Wrapf(err, "Invalid byte size %s", name)
+}
+
+// ByteSize creates a Typed byte size Variable, the values are parsed by ParseByteSize (e.g. 512MB, 2GiB).
+func ByteSize(name string, defaultValue int64, rules ...validation.Rule) *Typed[int64] {
+	t := NewTyped(name, defaultValue, ParseByteSize, FormatByteSize, rules...)
+	t.def.Hint = ByteSizeHint
+	return t
+}
@@ -0,0 +1,60 @@
+package config
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"time"
+)
+
+func (cts *ConfigTestSuite) TestParseByteSize() {
+	for value, expected := range map[string]int64{
+		"0":      0,
+		"512":    512,
+		"512B":   512,
+		"64KB":   64000,
+		"64kb":   64000,
+		"512 MB": 512000000,
+		"2GiB":   2 << 30,
+		"1TiB":   1 << 40,
+	} {
+		size, err := ParseByteSize(value)
+		cts.NoErrorf(err, "%q should be parsed", value)
+		cts.Equalf(expected, size, "%q should be parsed", value)
+	}
+	for _, value := range []string{"", "MB", "-1", "1.5GB", "2 gigabytes", "9999999999TiB"} {
+		_, err := ParseByteSize(value)
+		cts.Errorf(err, "%q should be rejected", value)
+	}
+
+	cts.Equal("2GiB", FormatByteSize(2<<30))
+	cts.Equal("1536KiB", FormatByteSize(1536<<10))
+	cts.Equal("1500", FormatByteSize(1500))
+	cts.Equal("0", FormatByteSize(0))
+}
+
+func (cts *ConfigTestSuite) TestUnits() {
+	conf := NewConfig(nil)
+	timeout := Duration("APP_TIMEOUT", 30*time.Second)
+	buffer := ByteSize("APP_BUFFER_SIZE", 512<<20)
+	cts.NoError(conf.Register(timeout, buffer))
+	cts.NoError(conf.Setup())
+	cts.Equal(int64(512<<20), buffer.Value())
+	cts.Equal("512MiB", conf.Get("APP_BUFFER_SIZE"))
+
+	cts.NoError(conf.Set("APP_BUFFER_SIZE", "2GB"))
+	size, err := conf.GetByteSize("APP_BUFFER_SIZE")
+	cts.NoError(err)
+	cts.Equal(int64(2000000000), size)
+	duration, err := conf.GetDuration("APP_TIMEOUT")
+	cts.NoError(err)
+	cts.Equal(30*time.Second, duration)
+	_, err = conf.GetDuration("APP_BUFFER_SIZE")
+	cts.Error(err, "Invalid durations should fail")
+
+	sampleFile := filepath.Join(cts.T().TempDir(), ".env.sample")
+	cts.NoError(conf.CreateSampleFile(sampleFile), "The sample file should have been created")
+	content, err := ioutil.ReadFile(sampleFile)
+	cts.NoError(err, "The sample file should be readable")
+	cts.Contains(string(content), "# Format: byte size, e.g. 512, 64KB, 512MB, 2GiB\nAPP_BUFFER_SIZE=512MiB\n")
+	cts.Contains(string(content), "# Format: duration, e.g. 300ms, 30s, 5m, 1h30m\nAPP_TIMEOUT=30s\n")
+}
@@ -0,0 +1,32 @@
+package config
+
+import (
+	"github.com/pkg/errors"
+)
+
+// AddVariable registers a new Variable after the AppConfig is created, so the libraries (e.g. a database
+// module) can add their own configuration schema to a shared AppConfig at init time.
+// It returns an error if a Variable with the same name is already registered. Until the next Setup or
+// Reload loads it, the value of the Variable is its DefaultValue.
+func (appConf *AppConfig) AddVariable(name string, v *Variable) error {
+	if v == nil {
+		return errors.Errorf("Configuration variable %s should not be nil", name)
+	}
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	if _, ok := appConf.vars[name]; ok {
+		return errors.Errorf("Configuration variable %s is already registered", name)
+	}
+	if v.Value == "" {
+		v.Value = v.DefaultValue
+	}
+	appConf.vars[name] = v
+	return nil
+}
+
+// RemoveVariable unregisters the named Variable. Removing an unknown Variable is a no-op.
+func (appConf *AppConfig) RemoveVariable(name string) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	delete(appConf.vars, name)
+}
@@ -0,0 +1,31 @@
+package config
+
+import (
+	"github.com/go-ozzo/ozzo-validation/is"
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestAddRemoveVariable() {
+	conf := NewConfig(map[string]*Variable{
+		constants.APP_PORT: {DefaultValue: "8080"},
+	})
+	cts.NoError(conf.AddVariable("APP_DB_PORT", &Variable{
+		DefaultValue: "5432",
+		Rules: map[string]validation.Rule{
+			"Valid port": is.Port,
+		},
+	}))
+	cts.Equal("5432", conf.Get("APP_DB_PORT"), "The default value should be returned before the Setup")
+	cts.EqualError(conf.AddVariable(constants.APP_PORT, &Variable{}), "Configuration variable APP_PORT is already registered")
+	cts.EqualError(conf.AddVariable("APP_DB_HOST", nil), "Configuration variable APP_DB_HOST should not be nil")
+
+	cts.NoError(conf.Setup())
+	cts.Error(conf.Set("APP_DB_PORT", "not-a-port"), "The rules of the added Variable should be validated")
+
+	conf.RemoveVariable("APP_DB_PORT")
+	_, ok := conf.Lookup("APP_DB_PORT")
+	cts.False(ok, "The removed Variable should not be found")
+	conf.RemoveVariable("APP_DB_PORT")
+	cts.NoError(conf.AddVariable("APP_DB_PORT", &Variable{}), "The removed Variable should be registered again")
+}
@@ -0,0 +1,217 @@
+package config
+
+import (
+	"context"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/pkg/errors"
+)
+
+// watchDebounce is the quiet period after the last file event before the configuration is reloaded,
+// editors and deployment tools usually produce several events for one change.
+const watchDebounce = 100 * time.Millisecond
+
+// k8sDataDir is the symlink swapped by Kubernetes when a mounted ConfigMap or Secret is updated.
+const k8sDataDir = "..data"
+
+// OnChange registers a callback which is invoked with the names of the changed Variables
+// after a Reload changed any value.
+func (appConf *AppConfig) OnChange(fn func(changed []string)) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.onChange = append(appConf.onChange, fn)
+}
+
+// OnReloadError registers a callback which is invoked with the error of a failed Reload.
+func (appConf *AppConfig) OnReloadError(fn func(err error)) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.onReloadError = append(appConf.onReloadError, fn)
+}
+
+// OnWatchError registers a callback which is invoked with the errors of Watch and WatchSources, e.g. a failed
+// poll of a Source. The watching goes on, unless the error ends the watching of a Source.
+func (appConf *AppConfig) OnWatchError(fn func(err error)) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.onWatchError = append(appConf.onWatchError, fn)
+}
+
+// OnReload registers a callback which is invoked after every successful Reload, even if no value has changed,
+// e.g. to export the time of the last reload.
+func (appConf *AppConfig) OnReload(fn func()) {
+	appConf.mu.Lock()
+	defer appConf.mu.Unlock()
+	appConf.onReload = append(appConf.onReload, fn)
+}
+
+// Reload re-runs the last Setup with the same SetupOptions, then invokes the OnChange callbacks and delivers the
+// Changes to the subscriptions if any value has changed. The new values are validated before they are applied, on
+// failure the last-known-good values are kept, the OnReloadError callbacks are invoked and the error is returned.
+// Concurrent Reloads are serialized.
+func (appConf *AppConfig) Reload() error {
+	appConf.reloadMu.Lock()
+	defer appConf.reloadMu.Unlock()
+
+	values, origins, err := appConf.resolve(appConf.lastSetupOptions()...)
+	if err != nil {
+		err = errors.Wrap(err, "Failed to reload Application Configuration")
+		appConf.reportReloadError(err)
+		return err
+	}
+
+	if errs := appConf.validateValues(values); len(errs) > 0 {
+		err := errs.Filter()
+		appConf.reportReloadError(err)
+		return err
+	}
+	appConf.mu.Lock()
+	before := appConf.values()
+	changed := changedNames(before, values)
+	appConf.applyValues(values, origins)
+	callbacks := append([]func([]string){}, appConf.onChange...)
+	reloadCallbacks := append([]func(){}, appConf.onReload...)
+	dropped := appConf.publish(before, values, changed)
+	appConf.mu.Unlock()
+
+	if len(changed) > 0 {
+		for _, fn := range callbacks {
+			fn(changed)
+		}
+		appConf.reportDropped(dropped)
+	}
+	for _, fn := range reloadCallbacks {
+		fn()
+	}
+	return nil
+}
+
+// lastSetupOptions returns the SetupOptions of the last Setup.
+func (appConf *AppConfig) lastSetupOptions() []SetupOption {
+	appConf.mu.RLock()
+	defer appConf.mu.RUnlock()
+	return appConf.setupOpts
+}
+
+// reportReloadError invokes the OnReloadError callbacks with err.
+func (appConf *AppConfig) reportReloadError(err error) {
+	appConf.mu.RLock()
+	callbacks := append([]func(error){}, appConf.onReloadError...)
+	appConf.mu.RUnlock()
+	for _, fn := range callbacks {
+		fn(err)
+	}
+}
+
+// reportWatchError invokes the OnWatchError callbacks with err.
+func (appConf *AppConfig) reportWatchError(err error) {
+	appConf.mu.RLock()
+	callbacks := append([]func(error){}, appConf.onWatchError...)
+	appConf.mu.RUnlock()
+	for _, fn := range callbacks {
+		fn(err)
+	}
+}
+
+// Watch watches the supplied files and Reloads the configuration whenever any of them changes.
+// If no paths are supplied the envfiles and YAML files of the last Setup are watched.
+// The parent directories are watched, so files replaced by rename (editors, Kubernetes volume updates) are followed.
+// Watch returns after the watcher has started, the watching stops when ctx is done.
+// Reload errors are reported to the OnReloadError callbacks, the errors of the watcher to the OnWatchError
+// callbacks.
+func (appConf *AppConfig) Watch(ctx context.Context, paths ...string) error {
+	if len(paths) == 0 {
+		paths = newSetupOptions(appConf.lastSetupOptions()...).files()
+	}
+	if len(paths) == 0 {
+		return errors.New("No files to watch")
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return errors.Wrap(err, "Failed to create file watcher")
+	}
+
+	files := map[string]bool{}
+	dirs := map[string]bool{}
+	for _, path := range paths {
+		abs, err := filepath.Abs(path)
+		if err != nil {
+			watcher.Close()
+			return errors.Wrapf(err, "Failed to resolve %s", path)
+		}
+		files[abs] = true
+		dirs[filepath.Dir(abs)] = true
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			watcher.Close()
+			return errors.Wrapf(err, "Failed to watch %s", dir)
+		}
+	}
+
+	go appConf.watchLoop(ctx, watcher, files)
+	return nil
+}
+
+// watchLoop reloads the configuration on the relevant file events until ctx is done.
+func (appConf *AppConfig) watchLoop(ctx context.Context, watcher *fsnotify.Watcher, files map[string]bool) {
+	defer watcher.Close()
+
+	debounce := time.NewTimer(watchDebounce)
+	debounce.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			debounce.Stop()
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+			if files[event.Name] || filepath.Base(event.Name) == k8sDataDir {
+				debounce.Reset(watchDebounce)
+			}
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+			appConf.reportWatchError(errors.Wrap(err, "File watcher failed"))
+		case <-debounce.C:
+			// The error is already reported to the OnReloadError callbacks
+			_ = appConf.Reload()
+		}
+	}
+}
+
+// values returns the current value of every Variable.
+// The caller must hold the lock.
+func (appConf *AppConfig) values() map[string]string {
+	values := make(map[string]string, len(appConf.vars))
+	for confKey, confVar := range appConf.vars {
+		values[confKey] = confVar.Value
+	}
+	return values
+}
+
+// changedNames returns the sorted names of the variables which differ between before and after.
+func changedNames(before, after map[string]string) []string {
+	changed := []string{}
+	for name, value := range after {
+		if old, ok := before[name]; !ok || old != value {
+			changed = append(changed, name)
+		}
+	}
+	for name := range before {
+		if _, ok := after[name]; !ok {
+			changed = append(changed, name)
+		}
+	}
+	// Sort is needed because maps always return values in random order
+	sort.Strings(changed)
+	return changed
+}
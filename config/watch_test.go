@@ -0,0 +1,89 @@
+package config
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (cts *ConfigTestSuite) TestReload() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer os.Remove(envFile)
+	cts.writeEnvfile(envFile, map[string]string{"APP_PORT": "9090"})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(envFile), "Config should have been set up")
+
+	changes := [][]string{}
+	conf.OnChange(func(changed []string) { changes = append(changes, changed) })
+	reloadErrors := []error{}
+	conf.OnReloadError(func(err error) { reloadErrors = append(reloadErrors, err) })
+	reloads := 0
+	conf.OnReload(func() { reloads++ })
+
+	cts.NoError(conf.Reload(), "Unchanged config should be reloaded")
+	cts.Empty(changes, "OnChange should not be called without changes")
+	cts.Equal(1, reloads, "OnReload should be called without changes")
+
+	cts.NoError(ioutil.WriteFile(envFile, []byte("APP_PORT=7070\nAPP_LOG_LEVEL=warn\n"), 0600))
+	cts.NoError(conf.Reload(), "Changed config should be reloaded")
+	cts.Equal([][]string{{constants.APP_LOG_LEVEL, constants.APP_PORT}}, changes)
+	cts.Equal("7070", conf.Port())
+
+	cts.NoError(ioutil.WriteFile(envFile, []byte("APP_PORT=notAportNum\n"), 0600))
+	cts.Error(conf.Reload(), "Invalid config should fail")
+	cts.Len(reloadErrors, 1, "OnReloadError should have been called")
+	cts.Equal(2, reloads, "OnReload should not be called after a failed Reload")
+	cts.Equal("7070", conf.Port(), "Failed reload should keep the last-known-good value")
+}
+
+func (cts *ConfigTestSuite) TestWatch() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer os.Remove(envFile)
+	cts.writeEnvfile(envFile, map[string]string{"APP_PORT": "9090"})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(envFile), "Config should have been set up")
+
+	changes := make(chan []string, 1)
+	conf.OnChange(func(changed []string) { changes <- changed })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	cts.NoError(conf.Watch(ctx), "Watch should watch the envfile of the Setup")
+
+	cts.NoError(ioutil.WriteFile(envFile, []byte("APP_PORT=7070\n"), 0600))
+	select {
+	case changed := <-changes:
+		cts.Equal([]string{constants.APP_PORT}, changed)
+	case <-time.After(5 * time.Second):
+		cts.Fail("Change of the envfile should have been detected")
+	}
+
+	cts.Error(NewConfig(nil).Watch(ctx), "Watch without files should fail")
+}
+
+func (cts *ConfigTestSuite) TestConcurrentReload() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer os.Remove(envFile)
+	cts.writeEnvfile(envFile, map[string]string{"APP_PORT": "9090"})
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.Setup(envFile), "Config should have been set up")
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		for i := 0; i < 100; i++ {
+			_ = conf.Reload()
+		}
+	}()
+	for i := 0; i < 1000; i++ {
+		cts.Equal("9090", conf.Port(), "Readers should always see a complete configuration")
+		cts.NoError(conf.Validate())
+	}
+	<-done
+}
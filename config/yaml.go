@@ -0,0 +1,98 @@
+package config
+
+import (
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// loadYAML loads variables from the YAML file(s) into values and their origins.
+// Only the registered Variables are set, unknown keys are ignored. The files of the earlier schema versions
+// are migrated like the envfiles, see WithSchemaVersion.
+func (appConf *AppConfig) loadYAML(values, origins map[string]string, files ...string) error {
+	for _, file := range files {
+		loaded, err := readYAMLFile(file)
+		if err != nil {
+			return err
+		}
+		if loaded, err = appConf.migrateEnvfile(file, loaded); err != nil {
+			return err
+		}
+		setOrigins(origins, values, loaded, "yaml "+file)
+		mergeValues(values, loaded)
+	}
+	return nil
+}
+
+// readYAMLFile reads a YAML file and flattens it into variable names and values.
+// Nested keys are joined by "_" and upper-cased, so
+//
+//	app:
+//	  port: 8080
+//
+// results in APP_PORT=8080. Sequences are joined by ",", null values result in an empty string.
+func readYAMLFile(filename string) (map[string]string, error) {
+	content, err := ioutil.ReadFile(filename)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Failed to read YAML file %s", filename)
+	}
+	values, err := ParseDocument(FormatYAML, content)
+	return values, errors.Wrapf(err, "Failed to parse YAML file %s", filename)
+}
+
+// flattenYAML walks the decoded YAML document and collects the scalar values by their joined keys.
+// The sequences of scalars are joined, the other values which are not scalars (e.g. a sequence of mappings)
+// cannot be flattened into a variable and are rejected.
+func flattenYAML(prefix string, node interface{}, values map[string]string) error {
+	switch typed := node.(type) {
+	case map[string]interface{}:
+		for key, child := range typed {
+			if err := flattenYAML(joinYAMLKey(prefix, key), child, values); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		items := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if !isYAMLScalar(item) {
+				return errors.Errorf("Invalid value of %s: the items of a list must be scalars", prefix)
+			}
+			items = append(items, yamlScalar(item))
+		}
+		values[prefix] = strings.Join(items, ",")
+	default:
+		if !isYAMLScalar(typed) {
+			return errors.Errorf("Invalid value of %s: must be a scalar, a list or a mapping with string keys", prefix)
+		}
+		values[prefix] = yamlScalar(typed)
+	}
+	return nil
+}
+
+// isYAMLScalar returns true if the decoded YAML value is a scalar, neither a mapping nor a sequence.
+func isYAMLScalar(value interface{}) bool {
+	switch value.(type) {
+	case map[string]interface{}, map[interface{}]interface{}, []interface{}:
+		return false
+	}
+	return true
+}
+
+// joinYAMLKey joins a parent and a child key into a variable name.
+func joinYAMLKey(prefix, key string) string {
+	key = strings.ToUpper(key)
+	if prefix == "" {
+		return key
+	}
+	return prefix + "_" + key
+}
+
+// yamlScalar converts a decoded YAML scalar into its string representation.
+func yamlScalar(value interface{}) string {
+	if value == nil {
+		return ""
+	}
+	return fmt.Sprint(value)
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"io/ioutil"
+	"os"
+
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// writeYAMLFile creates a temporary YAML file with the supplied content
+func (cts *ConfigTestSuite) writeYAMLFile(content string) string {
+	tmpfile, err := ioutil.TempFile(os.TempDir(), "devops-testing-*.yaml")
+	cts.NoError(err, "Temp file should have been created")
+	_, err = tmpfile.WriteString(content)
+	cts.NoError(err, "YAML content should have been written")
+	cts.NoError(tmpfile.Close(), "Temp file should have been closed")
+	return tmpfile.Name()
+}
+
+func (cts *ConfigTestSuite) TestYAMLFile() {
+	envFile := cts.setupEnvTest(constants.BasicEnvs...)
+	defer os.Remove(envFile)
+	cts.writeEnvfile(envFile, map[string]string{
+		"APP_PORT":      "9090",
+		"APP_LOG_LEVEL": "error",
+	})
+	yamlFile := cts.writeYAMLFile(`
+app:
+  port: 7070
+  log:
+    dev: true
+APP_DB_SECRET_NAME: yaml-secret
+UNKNOWN_KEY: [a, b]
+`)
+	defer os.Remove(yamlFile)
+
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.NoError(conf.SetupWithOptions(WithEnvfiles(envFile), WithYAMLFiles(yamlFile)), "Config should have been set up")
+	cts.Equal("7070", conf.Port(), "YAML should take precedence over the envfile")
+	cts.Equal("error", conf.LogLevel(), "Variables missing from the YAML file should keep the envfile value")
+	cts.Equal("true", conf.Get(constants.APP_LOG_DEV), "Nested keys should be joined by _")
+	cts.Equal("yaml-secret", conf.DBSecretName(), "Flat keys should be loaded")
+	_, ok := conf.Lookup("UNKNOWN_KEY")
+	cts.False(ok, "Unknown keys should not be registered")
+}
+
+func (cts *ConfigTestSuite) TestYAMLFileErrors() {
+	conf := NewConfig(cts.getDefaultConfigs())
+	cts.Error(conf.SetupWithOptions(WithYAMLFiles("AfileThatDoesNotExists.yaml")), "Missing YAML file should fail")
+
+	yamlFile := cts.writeYAMLFile("app: [unclosed")
+	defer os.Remove(yamlFile)
+	err := conf.SetupWithOptions(WithYAMLFiles(yamlFile))
+	cts.Error(err, "Invalid YAML should fail")
+	cts.Contains(err.Error(), "Failed to parse YAML file")
+
+	values := map[string]string{}
+	cts.NoError(flattenYAML("", map[string]interface{}{"list": []interface{}{1, "two", nil}, "empty": nil}, values))
+	cts.Equal(map[string]string{"LIST": "1,two,", "EMPTY": ""}, values)
+
+	yamlFile = cts.writeYAMLFile("app:\n  hosts:\n    - name: a\n    - name: b\n")
+	defer os.Remove(yamlFile)
+	err = conf.SetupWithOptions(WithYAMLFiles(yamlFile))
+	cts.Error(err, "Lists of mappings should fail")
+	cts.Contains(err.Error(), "Invalid value of APP_HOSTS: the items of a list must be scalars")
+	yamlFile = cts.writeYAMLFile("app:\n  1: one\n")
+	defer os.Remove(yamlFile)
+	cts.Error(conf.SetupWithOptions(WithYAMLFiles(yamlFile)), "Mappings with non-string keys should be rejected")
+}
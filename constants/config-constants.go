@@ -13,8 +13,152 @@ const (
 
 	APP_DB_SECRET_NAME = "APP_DB_SECRET_NAME"
 
+	APP_DB_HOST = "APP_DB_HOST"
+
+	APP_DB_PORT = "APP_DB_PORT"
+
+	APP_DB_USER = "APP_DB_USER"
+
+	APP_DB_PASSWORD = "APP_DB_PASSWORD"
+
+	APP_DB_NAME = "APP_DB_NAME"
+
+	APP_DB_SSL_MODE = "APP_DB_SSL_MODE"
+
+	APP_DB_TLS = "APP_DB_TLS"
+
+	APP_DB_CHARSET = "APP_DB_CHARSET"
+
+	APP_REDIS_ADDR = "APP_REDIS_ADDR"
+
+	APP_REDIS_PASSWORD = "APP_REDIS_PASSWORD"
+
+	APP_REDIS_DB = "APP_REDIS_DB"
+
+	APP_REDIS_TLS = "APP_REDIS_TLS"
+
+	APP_REDIS_POOL_SIZE = "APP_REDIS_POOL_SIZE"
+
+	APP_TLS_CERT_FILE = "APP_TLS_CERT_FILE"
+
+	APP_TLS_KEY_FILE = "APP_TLS_KEY_FILE"
+
+	APP_TLS_CA_FILE = "APP_TLS_CA_FILE"
+
+	APP_TLS_MIN_VERSION = "APP_TLS_MIN_VERSION"
+
+	APP_TLS_CLIENT_AUTH = "APP_TLS_CLIENT_AUTH"
+
+	APP_HTTP_PROXY = "APP_HTTP_PROXY"
+
+	APP_HTTPS_PROXY = "APP_HTTPS_PROXY"
+
+	APP_NO_PROXY = "APP_NO_PROXY"
+
+	APP_TIMEZONE = "APP_TIMEZONE"
+
+	APP_LOCALE = "APP_LOCALE"
+
+	APP_LOG_FORMAT = "APP_LOG_FORMAT"
+
+	APP_LOG_TIME_KEY         = "APP_LOG_TIME_KEY"
+	APP_LOG_LEVEL_KEY        = "APP_LOG_LEVEL_KEY"
+	APP_LOG_MESSAGE_KEY      = "APP_LOG_MESSAGE_KEY"
+	APP_LOG_TIMESTAMP_FORMAT = "APP_LOG_TIMESTAMP_FORMAT"
+
+	APP_LOG_UTC = "APP_LOG_UTC"
+
+	APP_LOG_CALLER = "APP_LOG_CALLER"
+
 	APP_LOG_FORMAT_ERRORS = "APP_LOG_FORMAT_ERRORS"
 
+	APP_LOG_SCAN_SECRETS = "APP_LOG_SCAN_SECRETS"
+
+	APP_LOG_FILE = "APP_LOG_FILE"
+
+	APP_LOG_SAMPLING_INITIAL = "APP_LOG_SAMPLING_INITIAL"
+
+	APP_LOG_SAMPLING_THEREAFTER = "APP_LOG_SAMPLING_THEREAFTER"
+
+	APP_LOG_DEDUP_WINDOW = "APP_LOG_DEDUP_WINDOW"
+
+	APP_LOG_MASK_PII = "APP_LOG_MASK_PII"
+
+	APP_LOG_SYSLOG_ADDR = "APP_LOG_SYSLOG_ADDR"
+
+	APP_LOG_JOURNALD = "APP_LOG_JOURNALD"
+
+	APP_LOG_CLOUDWATCH_GROUP  = "APP_LOG_CLOUDWATCH_GROUP"
+	APP_LOG_CLOUDWATCH_STREAM = "APP_LOG_CLOUDWATCH_STREAM"
+
+	APP_LOG_LOKI_URL    = "APP_LOG_LOKI_URL"
+	APP_LOG_LOKI_LABELS = "APP_LOG_LOKI_LABELS"
+
+	APP_LOG_ELASTICSEARCH_URL            = "APP_LOG_ELASTICSEARCH_URL"
+	APP_LOG_ELASTICSEARCH_INDEX          = "APP_LOG_ELASTICSEARCH_INDEX"
+	APP_LOG_ELASTICSEARCH_BUFFER_SIZE    = "APP_LOG_ELASTICSEARCH_BUFFER_SIZE"
+	APP_LOG_ELASTICSEARCH_FLUSH_INTERVAL = "APP_LOG_ELASTICSEARCH_FLUSH_INTERVAL"
+
+	APP_LOG_FLUENTD_ADDR = "APP_LOG_FLUENTD_ADDR"
+	APP_LOG_FLUENTD_TAG  = "APP_LOG_FLUENTD_TAG"
+
+	APP_LOG_KAFKA_BROKERS      = "APP_LOG_KAFKA_BROKERS"
+	APP_LOG_KAFKA_TOPIC        = "APP_LOG_KAFKA_TOPIC"
+	APP_LOG_KAFKA_QUEUE_SIZE   = "APP_LOG_KAFKA_QUEUE_SIZE"
+	APP_LOG_KAFKA_QUEUE_POLICY = "APP_LOG_KAFKA_QUEUE_POLICY"
+
+	APP_LOG_SENTRY_DSN = "APP_LOG_SENTRY_DSN"
+
+	APP_LOG_GELF_ADDR = "APP_LOG_GELF_ADDR"
+
+	APP_LOG_GCP_PROJECT = "APP_LOG_GCP_PROJECT"
+
+	APP_LOG_DATADOG = "APP_LOG_DATADOG"
+
+	APP_PREFLIGHT_MODE = "APP_PREFLIGHT_MODE"
+
+	APP_PREFLIGHT_TIMEOUT = "APP_PREFLIGHT_TIMEOUT"
+
+	APP_NOTIFY_WEBHOOK_URL = "APP_NOTIFY_WEBHOOK_URL"
+
+	APP_NOTIFY_SMTP_ADDR = "APP_NOTIFY_SMTP_ADDR"
+
+	APP_NOTIFY_SMTP_FROM = "APP_NOTIFY_SMTP_FROM"
+
+	APP_NOTIFY_SMTP_TO = "APP_NOTIFY_SMTP_TO"
+
+	APP_NOTIFY_SMTP_USERNAME = "APP_NOTIFY_SMTP_USERNAME"
+
+	APP_NOTIFY_SMTP_PASSWORD = "APP_NOTIFY_SMTP_PASSWORD"
+
+	APP_NOTIFY_SNS_TOPIC_ARN = "APP_NOTIFY_SNS_TOPIC_ARN"
+
+	APP_CONFIG_S3_BUCKET = "APP_CONFIG_S3_BUCKET"
+
+	APP_CONFIG_S3_KEY = "APP_CONFIG_S3_KEY"
+
+	APP_CONFIG_S3_KMS_KEY_ID = "APP_CONFIG_S3_KMS_KEY_ID"
+
+	APP_CONFIG_S3_POLL_INTERVAL = "APP_CONFIG_S3_POLL_INTERVAL"
+
+	APP_CONFIG_SECRET_POLL_INTERVAL = "APP_CONFIG_SECRET_POLL_INTERVAL"
+
+	APP_CONFIG_ETCD_ENDPOINTS = "APP_CONFIG_ETCD_ENDPOINTS"
+
+	APP_CONFIG_ETCD_PREFIX = "APP_CONFIG_ETCD_PREFIX"
+
+	APP_CONFIG_ETCD_USERNAME = "APP_CONFIG_ETCD_USERNAME"
+
+	APP_CONFIG_ETCD_PASSWORD = "APP_CONFIG_ETCD_PASSWORD"
+
+	APP_CONFIG_CONSUL_PREFIX = "APP_CONFIG_CONSUL_PREFIX"
+
+	APP_CONFIG_HTTP_URL = "APP_CONFIG_HTTP_URL"
+
+	APP_CONFIG_HTTP_POLL_INTERVAL = "APP_CONFIG_HTTP_POLL_INTERVAL"
+
+	APP_CONFIG_SCHEMA_VERSION = "APP_CONFIG_SCHEMA_VERSION"
+
 	EC2_ID = "EC2_ID"
 )
 
@@ -91,3 +235,35 @@ var (
 		SSL_MODE_VERIFY_FULL,
 	}
 )
+
+// TLS client authentication modes of the servers, see crypto/tls.ClientAuthType.
+const (
+	// TLS_CLIENT_AUTH_NONE does not request client certificates.
+	TLS_CLIENT_AUTH_NONE = "none"
+
+	// TLS_CLIENT_AUTH_REQUEST requests a client certificate, but does not require or verify it.
+	TLS_CLIENT_AUTH_REQUEST = "request"
+
+	// TLS_CLIENT_AUTH_REQUIRE requires a client certificate, but does not verify it.
+	TLS_CLIENT_AUTH_REQUIRE = "require"
+
+	// TLS_CLIENT_AUTH_VERIFY_IF_GIVEN verifies the client certificate if one is sent.
+	TLS_CLIENT_AUTH_VERIFY_IF_GIVEN = "verify-if-given"
+
+	// TLS_CLIENT_AUTH_REQUIRE_AND_VERIFY requires a client certificate and verifies it with the CA.
+	TLS_CLIENT_AUTH_REQUIRE_AND_VERIFY = "require-and-verify"
+)
+
+var (
+	// ValidTLSClientAuths are the valid TLS client authentication modes. Used in validation.
+	ValidTLSClientAuths = []interface{}{
+		TLS_CLIENT_AUTH_NONE,
+		TLS_CLIENT_AUTH_REQUEST,
+		TLS_CLIENT_AUTH_REQUIRE,
+		TLS_CLIENT_AUTH_VERIFY_IF_GIVEN,
+		TLS_CLIENT_AUTH_REQUIRE_AND_VERIFY,
+	}
+
+	// ValidTLSVersions are the valid minimum TLS versions. Used in validation.
+	ValidTLSVersions = []interface{}{"1.0", "1.1", "1.2", "1.3"}
+)
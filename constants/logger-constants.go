@@ -19,3 +19,36 @@ var (
 		LOG_LEVEL_ERROR,
 	}
 )
+
+// Log formats of the Common Logger, see APP_LOG_FORMAT.
+const (
+	// LOG_FORMAT_JSON formats the entries as JSON objects.
+	LOG_FORMAT_JSON = "json"
+
+	// LOG_FORMAT_TEXT formats the entries as text, the format of APP_LOG_DEV.
+	LOG_FORMAT_TEXT = "text"
+
+	// LOG_FORMAT_GELF formats the entries as GELF 1.1 messages for Graylog.
+	LOG_FORMAT_GELF = "gelf"
+
+	// LOG_FORMAT_GCP formats the entries as the structured logs of Google Cloud Logging.
+	LOG_FORMAT_GCP = "gcp"
+
+	// LOG_FORMAT_LOGFMT formats the entries as logfmt key=value pairs.
+	LOG_FORMAT_LOGFMT = "logfmt"
+
+	// LOG_FORMAT_PRETTY formats the entries for the local development, colored and on multiple lines.
+	LOG_FORMAT_PRETTY = "pretty"
+)
+
+var (
+	// ValidLogFormats are the valid log formats. Used in validation.
+	ValidLogFormats = []interface{}{
+		LOG_FORMAT_JSON,
+		LOG_FORMAT_TEXT,
+		LOG_FORMAT_GELF,
+		LOG_FORMAT_GCP,
+		LOG_FORMAT_LOGFMT,
+		LOG_FORMAT_PRETTY,
+	}
+)
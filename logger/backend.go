@@ -0,0 +1,167 @@
+package logger
+
+import (
+	"crypto/tls"
+	"io"
+	"os"
+	"reflect"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// Backend encodes and writes the log entries of a Logger instead of the Logrus formatters, e.g. zapbackend.New.
+// The entries are still created by the Logrus API of the Logger, so the default fields, the hooks and the
+// level filtering are the same with every Backend.
+type Backend interface {
+	// Write encodes and writes the entry, its Data holds the default fields and the fields of the entry.
+	Write(entry *logrus.Entry) error
+
+	// Sync flushes the buffered entries.
+	Sync() error
+}
+
+// BackendFactory creates the Backend of a Common Logger writing to output. dev is set by APP_LOG_DEV to
+// select a human readable format instead of JSON.
+type BackendFactory func(output io.Writer, dev bool) Backend
+
+// LoggerOption is an optional setting of NewCommonLoggerFromConfiguration.
+type LoggerOption func(*loggerOptions)
+
+// loggerOptions are the settings of NewCommonLoggerFromConfiguration set by the LoggerOptions.
+type loggerOptions struct {
+	backend          BackendFactory
+	piiPatterns      []SensitivePattern
+	syslogTLSConfig  *tls.Config
+	datadog          bool
+	jsonFormat       JSONFormat
+	utc              bool
+	callerFormatter  CallerFormatter
+	shipperFactories []ShipperFactory
+	hooks            []logrus.Hook
+}
+
+// WithBackend makes the Common Logger write its entries by the Backend created by factory,
+// e.g. NewCommonLoggerFromConfiguration(name, version, conf, WithBackend(zapbackend.New)).
+func WithBackend(factory BackendFactory) LoggerOption {
+	return func(o *loggerOptions) {
+		o.backend = factory
+	}
+}
+
+// WithHooks adds the Logrus hooks to the Common Logger, e.g. WithHooks(otelhook.New()). The hooks are fired after
+// the UTC, caller and redaction hooks, and before the Datadog correlation.
+func WithHooks(hooks ...logrus.Hook) LoggerOption {
+	return func(o *loggerOptions) {
+		o.hooks = append(o.hooks, hooks...)
+	}
+}
+
+// backendHook passes the entries to a Backend, it is the last hook of the Logger.
+type backendHook struct {
+	backend Backend
+}
+
+// Levels implements the logrus.Hook interface, the hook fires on every level.
+func (h *backendHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (h *backendHook) Fire(entry *logrus.Entry) error {
+	return h.backend.Write(entry)
+}
+
+// discardFormatter skips the formatting of the entries written by a Backend.
+type discardFormatter struct{}
+
+// Format implements the logrus.Formatter interface.
+func (discardFormatter) Format(*logrus.Entry) ([]byte, error) {
+	return nil, nil
+}
+
+// useBackend replaces the formatter and the output of log with backend.
+func useBackend(log *logrus.Logger, backend Backend) {
+	log.AddHook(&backendHook{backend: backend})
+	log.SetFormatter(discardFormatter{})
+	log.SetOutput(io.Discard)
+}
+
+// flusher is implemented by the hooks and the Shippers buffering the entries, e.g. the LokiHook.
+type flusher interface {
+	Flush() error
+}
+
+// Sync writes the summaries of the suppressed duplicate entries and flushes the entries buffered by the
+// hooks, the Shippers and the Backend of the Logger, if any. Call it before the application exits.
+func (l *Logger) Sync() error {
+	if l.dedup != nil {
+		l.dedup.flush()
+	}
+	var syncErr error
+	for _, hook := range l.outputs() {
+		if hook, ok := hook.(flusher); ok {
+			if err := hook.Flush(); err != nil && syncErr == nil {
+				syncErr = err
+			}
+		}
+	}
+	if l.backend == nil {
+		return syncErr
+	}
+	if err := l.backend.Sync(); err != nil {
+		return err
+	}
+	return syncErr
+}
+
+// Close flushes the Logger (see Sync) and closes its hooks and Shippers holding connections, e.g. the kafkahook
+// publishes its queued entries, and the log file (see APP_LOG_FILE). Call it instead of Sync before the application
+// exits, the Logger must not be used afterwards.
+func (l *Logger) Close() error {
+	closeErr := l.Sync()
+	for _, hook := range l.outputs() {
+		if hook, ok := hook.(io.Closer); ok {
+			if err := hook.Close(); err != nil && closeErr == nil {
+				closeErr = err
+			}
+		}
+	}
+	if l.logFile != nil {
+		if err := l.logFile.Close(); err != nil && !errors.Is(err, os.ErrClosed) && closeErr == nil {
+			closeErr = err
+		}
+	}
+	return closeErr
+}
+
+// outputs returns the hooks and the Shippers of the Logger.
+func (l *Logger) outputs() []interface{} {
+	var outputs []interface{}
+	if log := l.logrusLogger(); log != nil {
+		for _, hook := range uniqueHooks(log.Hooks) {
+			outputs = append(outputs, hook)
+		}
+	}
+	for _, shipper := range l.shippers {
+		outputs = append(outputs, shipper)
+	}
+	return outputs
+}
+
+// uniqueHooks returns every hook once, a hook of several levels is added to the hooks of each level.
+func uniqueHooks(levelHooks logrus.LevelHooks) []logrus.Hook {
+	var unique []logrus.Hook
+	for _, level := range logrus.AllLevels {
+	next:
+		for _, hook := range levelHooks[level] {
+			for _, added := range unique {
+				if reflect.TypeOf(hook).Comparable() && reflect.TypeOf(hook) == reflect.TypeOf(added) && hook == added {
+					continue next
+				}
+			}
+			unique = append(unique, hook)
+		}
+	}
+	return unique
+}
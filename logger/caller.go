@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"path"
+	"path/filepath"
+	"runtime/debug"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The formats of the callers of APP_LOG_CALLER.
+const (
+	// CallerFull writes the full paths of the files and the full import paths of the functions.
+	CallerFull = "full"
+
+	// CallerRelative writes the paths relative to the module, see RelativeCaller.
+	CallerRelative = "relative"
+)
+
+// ValidCallerFormats are the valid formats of APP_LOG_CALLER. Used in validation.
+var ValidCallerFormats = []interface{}{CallerFull, CallerRelative}
+
+// mainModulePath is the module path of the application, empty if the binary has no build information.
+var mainModulePath = func() string {
+	if info, ok := debug.ReadBuildInfo(); ok {
+		return info.Main.Path
+	}
+	return ""
+}()
+
+// CallerFormatter rewrites the function and the file of the caller of the entries reported by APP_DEBUG, e.g.
+// RelativeCaller. The line is kept, the formatters write the file as file:line.
+type CallerFormatter func(function, file string) (string, string)
+
+// RelativeCaller is a CallerFormatter which trims the file to its path relative to the module of the application,
+// e.g. logger/logger.go instead of /home/user/go/src/github.com/org/service/logger/logger.go (the import path of
+// the package for the other modules), and drops the import path of the function, e.g. logger.(*Logger).Entry.
+func RelativeCaller(function, file string) (string, string) {
+	slash := strings.LastIndex(function, "/")
+	dot := strings.Index(function[slash+1:], ".")
+	if dot < 0 {
+		return function, file
+	}
+	pkgPath := function[:slash+1+dot]
+	file = filepath.ToSlash(file)
+	base := path.Base(file)
+	switch {
+	case pkgPath == "main":
+		// The import path of the main packages is not known
+		file = path.Join(path.Base(path.Dir(file)), base)
+	case mainModulePath != "" && pkgPath == mainModulePath:
+		file = base
+	case mainModulePath != "" && strings.HasPrefix(pkgPath, mainModulePath+"/"):
+		file = path.Join(strings.TrimPrefix(pkgPath, mainModulePath+"/"), base)
+	default:
+		file = path.Join(pkgPath, base)
+	}
+	return function[slash+1:], file
+}
+
+// CallerHook is a Logrus Hook which rewrites the callers of the entries by a CallerFormatter, so every formatter
+// and Backend writes the formatted callers.
+type CallerHook struct {
+	format CallerFormatter
+}
+
+// NewCallerHook creates a new CallerHook formatting the callers by format, it is added to the Common Logger if
+// APP_LOG_CALLER is relative (RelativeCaller), or by WithCallerFormatter.
+func NewCallerHook(format CallerFormatter) *CallerHook {
+	return &CallerHook{format: format}
+}
+
+// Levels implements the logrus.Hook interface, the hook is fired on all levels.
+func (hook *CallerHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (hook *CallerHook) Fire(entry *logrus.Entry) error {
+	if entry.Caller == nil {
+		return nil
+	}
+	frame := *entry.Caller
+	frame.Function, frame.File = hook.format(frame.Function, frame.File)
+	entry.Caller = &frame
+	return nil
+}
+
+// WithCallerFormatter formats the callers of the entries of the Common Logger by format, e.g. RelativeCaller,
+// instead of the APP_LOG_CALLER format.
+func WithCallerFormatter(format CallerFormatter) LoggerOption {
+	return func(o *loggerOptions) {
+		o.callerFormatter = format
+	}
+}
+
+// callerFormatterFromConfiguration returns the CallerFormatter of the APP_LOG_CALLER format, nil for the full
+// paths.
+func callerFormatterFromConfiguration(format string) CallerFormatter {
+	if format == CallerRelative {
+		return RelativeCaller
+	}
+	return nil
+}
@@ -0,0 +1,64 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestCallerHook() {
+	output := &bytes.Buffer{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_DEBUG:      "true",
+		constants.APP_LOG_CALLER: CallerRelative,
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().Info("Order paid")
+
+	fields := logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields), "Entry should be logged in JSON")
+	ls.Equal("logger.(*LoggerSuite).TestCallerHook", fields["func"], "Import path of the function should be dropped")
+	ls.Regexp(`^logger/caller_test\.go:\d+$`, fields["file"], "File should be relative to the module")
+
+	output.Reset()
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_DEBUG: "true",
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().Info("Order paid")
+	fields = logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.True(strings.HasPrefix(fields["func"].(string), "github.com/universal-devs/go-utilities/logger."),
+		"Callers should be full by default")
+
+	output.Reset()
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_DEBUG: "true",
+	}, WithCallerFormatter(func(function, file string) (string, string) { return "f", "file.go" }))
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().Info("Order paid")
+	fields = logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("f", fields["func"])
+	ls.Regexp(`^file\.go:\d+$`, fields["file"])
+}
+
+func (ls *LoggerSuite) TestRelativeCaller() {
+	function, file := RelativeCaller("github.com/universal-devs/go-utilities/config.(*AppConfig).Get",
+		"/home/user/go/src/github.com/universal-devs/go-utilities/config/config.go")
+	ls.Equal("config.(*AppConfig).Get", function)
+	ls.Equal("config/config.go", file)
+
+	function, file = RelativeCaller("github.com/sirupsen/logrus.(*Entry).Info",
+		"/home/user/go/pkg/mod/github.com/sirupsen/logrus@v1.9.3/entry.go")
+	ls.Equal("logrus.(*Entry).Info", function)
+	ls.Equal("github.com/sirupsen/logrus/entry.go", file, "Other modules should keep the import path")
+
+	function, file = RelativeCaller("main.main", "/app/cmd/service/main.go")
+	ls.Equal("main.main", function)
+	ls.Equal("service/main.go", file)
+}
@@ -0,0 +1,263 @@
+// Package cloudwatchhook provides a logger.Shipper which ships the entries of the Common Logger to CloudWatch Logs.
+// Use FromConfiguration with logger.WithShippers to ship to the APP_LOG_CLOUDWATCH_GROUP
+// Use New to create the Hook with a custom CloudWatch Logs client
+package cloudwatchhook
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+	"github.com/universal-devs/go-utilities/logger/internal/batch"
+)
+
+// DefaultFlushInterval is the interval of the batches sent by the Hook if no interval is set.
+const DefaultFlushInterval = 5 * time.Second
+
+// The limits of a PutLogEvents batch, see the CloudWatch Logs quotas.
+const (
+	maxBatchEvents = 10000
+	maxBatchBytes  = 1048576
+	maxBatchSpan   = 24 * time.Hour
+	eventOverhead  = 26
+	maxEventBytes  = 262144 - eventOverhead
+)
+
+// maxBuffered is the number of buffered entries after which the new entries are dropped, e.g. while
+// CloudWatch Logs is unreachable.
+const maxBuffered = 10 * maxBatchEvents
+
+// timeout is the timeout of a CloudWatch Logs request.
+const timeout = 30 * time.Second
+
+// API is the part of the CloudWatch Logs client used by the Hook, e.g. a *cloudwatchlogs.Client.
+type API interface {
+	PutLogEvents(ctx context.Context, params *cloudwatchlogs.PutLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error)
+	CreateLogGroup(ctx context.Context, params *cloudwatchlogs.CreateLogGroupInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogGroupOutput, error)
+	CreateLogStream(ctx context.Context, params *cloudwatchlogs.CreateLogStreamInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error)
+}
+
+// Hook is a logger.Shipper which ships the entries as JSON to a CloudWatch Logs group and stream, so the
+// services without a log agent (e.g. on Fargate) still get centralized logs. The entries are buffered and sent
+// in batches every flush interval, or as soon as a batch is full. The group and the stream are created if they
+// do not exist, the failed batches are retried with a backoff.
+type Hook struct {
+	// Group is the name of the log group.
+	Group string
+
+	// Stream is the name of the log stream.
+	Stream string
+
+	// Retries is the number of retries of a failed batch.
+	Retries int
+
+	client  API
+	backoff time.Duration
+
+	// mu guards the buffered events
+	mu      sync.Mutex
+	events  []types.InputLogEvent
+	size    int
+	dropped int
+
+	// sendMu serializes the batches and guards the sequence token
+	sendMu        sync.Mutex
+	sequenceToken *string
+
+	loop *batch.Loop
+}
+
+// New creates a new Hook which sends the entries to the group and stream with the supplied
+// CloudWatch Logs client, e.g. cloudwatchlogs.NewFromConfig(awsConf), every flushInterval
+// (DefaultFlushInterval if zero). Close the hook to send the buffered entries on shutdown.
+func New(client API, group, stream string, flushInterval time.Duration) *Hook {
+	if flushInterval <= 0 {
+		flushInterval = DefaultFlushInterval
+	}
+	hook := &Hook{
+		Group:   group,
+		Stream:  stream,
+		Retries: 3,
+		client:  client,
+		backoff: 200 * time.Millisecond,
+	}
+	hook.loop = batch.Start("CloudWatch Logs", flushInterval, hook.Flush)
+	return hook
+}
+
+// Levels implements the logger.Shipper interface, every level is shipped.
+func (hook *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Ship implements the logger.Shipper interface, the entry is buffered until the next batch.
+func (hook *Hook) Ship(entry *logrus.Entry, encoded []byte) error {
+	message := string(encoded)
+	if len(message) > maxEventBytes {
+		message = message[:maxEventBytes]
+	}
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.events) >= maxBuffered {
+		hook.dropped++
+		return errors.Errorf("CloudWatch Logs buffer is full, %d entries dropped", hook.dropped)
+	}
+	hook.events = append(hook.events, types.InputLogEvent{
+		Message:   aws.String(message),
+		Timestamp: aws.Int64(timestamp.UnixNano() / int64(time.Millisecond)),
+	})
+	hook.size += len(message) + eventOverhead
+	if len(hook.events) >= maxBatchEvents || hook.size >= maxBatchBytes {
+		hook.loop.Full()
+	}
+	return nil
+}
+
+// Flush sends the buffered entries, it is called by Logger.Sync.
+func (hook *Hook) Flush() error {
+	hook.mu.Lock()
+	events := hook.events
+	hook.events, hook.size, hook.dropped = nil, 0, 0
+	hook.mu.Unlock()
+	if len(events) == 0 {
+		return nil
+	}
+
+	hook.sendMu.Lock()
+	defer hook.sendMu.Unlock()
+	// The events of a batch must be in chronological order
+	sort.SliceStable(events, func(i, j int) bool {
+		return *events[i].Timestamp < *events[j].Timestamp
+	})
+	for len(events) > 0 {
+		n := batchLen(events)
+		if err := hook.put(events[:n]); err != nil {
+			return errors.Wrapf(err, "Failed to send %d entries to CloudWatch Logs", len(events))
+		}
+		events = events[n:]
+	}
+	return nil
+}
+
+// Close stops the hook and sends the buffered entries.
+func (hook *Hook) Close() error {
+	hook.loop.Stop()
+	return hook.Flush()
+}
+
+// put sends a batch with the sequence token of the stream. The batch is sent again with the expected token on
+// an invalid token, and after creating the group and the stream if they do not exist. The caller must hold sendMu.
+func (hook *Hook) put(events []types.InputLogEvent) error {
+	return batch.Retry(hook.Retries, hook.backoff, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		output, err := hook.client.PutLogEvents(ctx, &cloudwatchlogs.PutLogEventsInput{
+			LogGroupName:  aws.String(hook.Group),
+			LogStreamName: aws.String(hook.Stream),
+			LogEvents:     events,
+			SequenceToken: hook.sequenceToken,
+		})
+		if err == nil {
+			hook.sequenceToken = output.NextSequenceToken
+			return false, nil
+		}
+
+		var acceptedErr *types.DataAlreadyAcceptedException
+		var tokenErr *types.InvalidSequenceTokenException
+		var notFoundErr *types.ResourceNotFoundException
+		var paramErr *types.InvalidParameterException
+		switch {
+		case errors.As(err, &acceptedErr):
+			hook.sequenceToken = acceptedErr.ExpectedSequenceToken
+			return false, nil
+		case errors.As(err, &tokenErr):
+			hook.sequenceToken = tokenErr.ExpectedSequenceToken
+		case errors.As(err, &notFoundErr):
+			if err := hook.createStream(); err != nil {
+				return false, err
+			}
+			hook.sequenceToken = nil
+		case errors.As(err, &paramErr):
+			return false, err
+		}
+		return true, err
+	})
+}
+
+// createStream creates the log group and the log stream, unless they already exist.
+func (hook *Hook) createStream() error {
+	ctx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+	var existsErr *types.ResourceAlreadyExistsException
+	_, err := hook.client.CreateLogGroup(ctx, &cloudwatchlogs.CreateLogGroupInput{LogGroupName: aws.String(hook.Group)})
+	if err != nil && !errors.As(err, &existsErr) {
+		return errors.Wrapf(err, "Failed to create log group %s", hook.Group)
+	}
+	_, err = hook.client.CreateLogStream(ctx, &cloudwatchlogs.CreateLogStreamInput{
+		LogGroupName:  aws.String(hook.Group),
+		LogStreamName: aws.String(hook.Stream),
+	})
+	if err != nil && !errors.As(err, &existsErr) {
+		return errors.Wrapf(err, "Failed to create log stream %s", hook.Stream)
+	}
+	return nil
+}
+
+// batchLen returns the number of the sorted events fitting into the first batch.
+func batchLen(events []types.InputLogEvent) int {
+	size := 0
+	for i, event := range events {
+		size += len(*event.Message) + eventOverhead
+		if i == maxBatchEvents || size > maxBatchBytes ||
+			time.Duration(*event.Timestamp-*events[0].Timestamp)*time.Millisecond > maxBatchSpan {
+			return i
+		}
+	}
+	return len(events)
+}
+
+// FromConfiguration is a logger.ShipperFactory which creates the Hook of the Common Logger with the client of
+// the default AWS configuration, see NewFactory.
+func FromConfiguration(serviceName, serviceVersion string, conf logger.ConfigGetter) (logger.Shipper, error) {
+	return NewFactory(nil)(serviceName, serviceVersion, conf)
+}
+
+// NewFactory returns a logger.ShipperFactory which creates the Hook of the Common Logger with client, e.g. with a
+// custom region or credentials, or the client of the default AWS configuration if nil. The entries are shipped to
+// the group of APP_LOG_CLOUDWATCH_GROUP and to the stream of APP_LOG_CLOUDWATCH_STREAM (service/host if not set),
+// no Hook is created if the group is not set.
+func NewFactory(client API) logger.ShipperFactory {
+	return func(serviceName, _ string, conf logger.ConfigGetter) (logger.Shipper, error) {
+		group := conf.Get(constants.APP_LOG_CLOUDWATCH_GROUP)
+		if group == "" {
+			return nil, nil
+		}
+		stream := conf.Get(constants.APP_LOG_CLOUDWATCH_STREAM)
+		if stream == "" {
+			stream = serviceName + "/" + conf.Hostname()
+		}
+		api := client
+		if api == nil {
+			awsConf, err := awsConfig.LoadDefaultConfig(context.Background())
+			if err != nil {
+				return nil, errors.Wrap(err, "Failed to load AWS configuration")
+			}
+			api = cloudwatchlogs.NewFromConfig(awsConf)
+		}
+		return New(api, group, stream, 0), nil
+	}
+}
@@ -0,0 +1,156 @@
+package cloudwatchhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs"
+	"github.com/aws/aws-sdk-go-v2/service/cloudwatchlogs/types"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// CloudWatchHookSuite extends testify's Suite.
+type CloudWatchHookSuite struct {
+	suite.Suite
+}
+
+// fakeCloudWatchLogs stores the events of a single stream in memory, with the sequence tokens of the stream
+type fakeCloudWatchLogs struct {
+	mu      sync.Mutex
+	created bool
+	token   int
+	events  []types.InputLogEvent
+	streams []string
+	puts    int
+}
+
+func (f *fakeCloudWatchLogs) PutLogEvents(ctx context.Context, params *cloudwatchlogs.PutLogEventsInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.PutLogEventsOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.puts++
+	if !f.created {
+		return nil, &types.ResourceNotFoundException{Message: aws.String("The specified log stream does not exist.")}
+	}
+	expected := aws.String(string(rune('a' + f.token)))
+	if aws.ToString(params.SequenceToken) != *expected {
+		return nil, &types.InvalidSequenceTokenException{ExpectedSequenceToken: expected}
+	}
+	f.token++
+	f.events = append(f.events, params.LogEvents...)
+	return &cloudwatchlogs.PutLogEventsOutput{NextSequenceToken: aws.String(string(rune('a' + f.token)))}, nil
+}
+
+func (f *fakeCloudWatchLogs) CreateLogGroup(ctx context.Context, params *cloudwatchlogs.CreateLogGroupInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogGroupOutput, error) {
+	return nil, &types.ResourceAlreadyExistsException{Message: aws.String("The specified log group already exists")}
+}
+
+func (f *fakeCloudWatchLogs) CreateLogStream(ctx context.Context, params *cloudwatchlogs.CreateLogStreamInput, optFns ...func(*cloudwatchlogs.Options)) (*cloudwatchlogs.CreateLogStreamOutput, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.created = true
+	f.streams = append(f.streams, aws.ToString(params.LogGroupName)+":"+aws.ToString(params.LogStreamName))
+	return &cloudwatchlogs.CreateLogStreamOutput{}, nil
+}
+
+// messages returns the decoded messages of the stored events.
+func (f *fakeCloudWatchLogs) messages() []map[string]interface{} {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	messages := []map[string]interface{}{}
+	for _, event := range f.events {
+		message := map[string]interface{}{}
+		if err := json.Unmarshal([]byte(aws.ToString(event.Message)), &message); err == nil {
+			messages = append(messages, message)
+		}
+	}
+	return messages
+}
+
+// ship ships the entry to the hook, encoded by logger.BasicJSONFormatter.
+func ship(hook logger.Shipper, entry *logrus.Entry) error {
+	encoded, err := logger.BasicJSONFormatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	return hook.Ship(entry, bytes.TrimSuffix(encoded, []byte("\n")))
+}
+
+func (cs *CloudWatchHookSuite) TestFromConfiguration() {
+	client := &fakeCloudWatchLogs{}
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:                  constants.ENV_TEST,
+		constants.APP_LOG_FILE:             filepath.Join(cs.T().TempDir(), "app.log"),
+		constants.APP_LOG_CLOUDWATCH_GROUP: "/ecs/test",
+	}, logger.WithShippers(NewFactory(client)))
+
+	commonLog.WithField("order", 42).Info("Order created")
+	commonLog.Entry().Error("Payment failed")
+	cs.Empty(client.messages(), "Entries should be buffered until the next batch")
+	cs.NoError(commonLog.Sync())
+	cs.Require().Len(client.messages(), 2)
+	cs.Equal("Order created", client.messages()[0]["msg"])
+	cs.Equal(float64(42), client.messages()[0]["order"])
+	cs.Equal("test-service", client.messages()[1]["service"])
+	cs.Equal([]string{"/ecs/test:test-service/" + config.StaticGetter{}.Hostname()}, client.streams,
+		"Stream should be created on the first batch")
+
+	commonLog.Entry().Warn("Retrying")
+	cs.NoError(commonLog.Sync())
+	cs.Len(client.messages(), 3, "Next batch should be sent with the sequence token of the last batch")
+	cs.Equal(4, client.puts)
+
+	shipper, err := FromConfiguration("test-service", "v1.2.3", config.StaticGetter{})
+	cs.NoError(err)
+	cs.Nil(shipper, "No hook should be created without a group")
+}
+
+func (cs *CloudWatchHookSuite) TestSequenceToken() {
+	client := &fakeCloudWatchLogs{created: true, token: 5}
+	hook := New(client, "/ecs/test", "worker", 20*time.Millisecond)
+	defer hook.Close()
+
+	now := time.Now()
+	cs.NoError(ship(hook, &logrus.Entry{Time: now.Add(time.Second), Message: "Second", Data: logrus.Fields{}}))
+	cs.NoError(ship(hook, &logrus.Entry{Time: now, Message: "First", Data: logrus.Fields{}}))
+	cs.Eventually(func() bool {
+		return len(client.messages()) == 2
+	}, 5*time.Second, 10*time.Millisecond, "Batch should be sent on the flush interval with the expected token")
+	cs.Equal("First", client.messages()[0]["msg"], "Events should be sent in chronological order")
+}
+
+func (cs *CloudWatchHookSuite) TestBatchLen() {
+	now := time.Now().UnixNano() / int64(time.Millisecond)
+	events := make([]types.InputLogEvent, maxBatchEvents+1)
+	for i := range events {
+		events[i] = types.InputLogEvent{Message: aws.String("entry"), Timestamp: aws.Int64(now)}
+	}
+	cs.Equal(maxBatchEvents, batchLen(events), "Batch should be limited to 10000 events")
+
+	large := strings.Repeat("x", maxEventBytes)
+	events = []types.InputLogEvent{}
+	for i := 0; i < 5; i++ {
+		events = append(events, types.InputLogEvent{Message: aws.String(large), Timestamp: aws.Int64(now)})
+	}
+	cs.Equal(4, batchLen(events), "Batch should be limited to 1 MiB")
+
+	events = []types.InputLogEvent{
+		{Message: aws.String("old"), Timestamp: aws.Int64(now - int64(25*time.Hour/time.Millisecond))},
+		{Message: aws.String("new"), Timestamp: aws.Int64(now)},
+	}
+	cs.Equal(1, batchLen(events), "Batch should not span more than 24 hours")
+}
+
+func TestCloudWatchHook(t *testing.T) {
+	suite.Run(t, new(CloudWatchHookSuite))
+}
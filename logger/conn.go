@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// connTimeout is the timeout of the dials and the writes of the connected Shippers, which write while the
+// Logger holds its lock.
+const connTimeout = time.Second
+
+// connRetryInterval is the time the entries are dropped after a failed dial, before dialing again, so an
+// unreachable server does not slow down every entry by the dial timeout.
+const connRetryInterval = 5 * time.Second
+
+// dialedConn is the connection of a Shipper writing the entries synchronously, e.g. the SyslogHook. The dials and
+// the writes time out, a lost connection is dialed again. It is not safe for concurrent use.
+type dialedConn struct {
+	// name is the name of the server in the errors, e.g. syslog.
+	name string
+
+	// dial connects to the server by dialer.
+	dial func(dialer *net.Dialer) (net.Conn, error)
+
+	conn    net.Conn
+	retryAt time.Time
+}
+
+// connect closes the connection if any, and dials the server.
+func (c *dialedConn) connect() error {
+	_ = c.close()
+	if time.Now().Before(c.retryAt) {
+		return errors.Errorf("Failed to connect to %s: the server is unreachable", c.name)
+	}
+	conn, err := c.dial(&net.Dialer{Timeout: connTimeout})
+	if err != nil {
+		c.retryAt = time.Now().Add(connRetryInterval)
+		return errors.Wrapf(err, "Failed to connect to %s", c.name)
+	}
+	c.conn = conn
+	return nil
+}
+
+// write writes the message, it connects first if the connection was lost. If retry is set, the message is written
+// again after a reconnect if the write fails, e.g. the server closed the connection.
+func (c *dialedConn) write(message []byte, retry bool) error {
+	if c.conn == nil {
+		retry = false
+		if err := c.connect(); err != nil {
+			return err
+		}
+	}
+	err := c.writeOnce(message)
+	if err == nil || !retry {
+		return err
+	}
+	if err := c.connect(); err != nil {
+		return err
+	}
+	return c.writeOnce(message)
+}
+
+// writeOnce writes the message with the write timeout, the connection is closed if the write fails.
+func (c *dialedConn) writeOnce(message []byte) error {
+	if err := c.conn.SetWriteDeadline(time.Now().Add(connTimeout)); err != nil {
+		_ = c.close()
+		return errors.Wrapf(err, "Failed to write to %s", c.name)
+	}
+	if _, err := c.conn.Write(message); err != nil {
+		_ = c.close()
+		return errors.Wrapf(err, "Failed to write to %s", c.name)
+	}
+	return nil
+}
+
+// close closes the connection if any.
+func (c *dialedConn) close() error {
+	if c.conn == nil {
+		return nil
+	}
+	err := c.conn.Close()
+	c.conn = nil
+	return err
+}
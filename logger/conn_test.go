@@ -0,0 +1,29 @@
+package logger
+
+import (
+	"net"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func (ls *LoggerSuite) TestDialedConn() {
+	dials := 0
+	conn := dialedConn{name: "syslog", dial: func(*net.Dialer) (net.Conn, error) {
+		dials++
+		return nil, errors.New("connection refused")
+	}}
+	ls.EqualError(conn.write([]byte("entry"), true), "Failed to connect to syslog: connection refused")
+	ls.EqualError(conn.write([]byte("entry"), true), "Failed to connect to syslog: the server is unreachable")
+	ls.Equal(1, dials, "The server should not be dialed again for every entry")
+
+	client, server := net.Pipe()
+	defer server.Close()
+	conn = dialedConn{name: "syslog", dial: func(*net.Dialer) (net.Conn, error) {
+		return client, nil
+	}}
+	start := time.Now()
+	ls.Error(conn.write([]byte("entry"), false), "The write should time out if the server does not read")
+	ls.Less(time.Since(start), 5*time.Second)
+	ls.Nil(conn.conn, "The connection should be closed after a failed write")
+}
@@ -0,0 +1,81 @@
+package logger
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The Datadog correlation fields added by the DatadogHook.
+const (
+	DatadogTraceIDField = "dd.trace_id"
+	DatadogSpanIDField  = "dd.span_id"
+	DatadogServiceField = "dd.service"
+	DatadogEnvField     = "dd.env"
+	DatadogVersionField = "dd.version"
+)
+
+// DatadogHook is a Logrus Hook which adds the fields correlating the logs with the traces and the services in
+// Datadog: dd.service, dd.env and dd.version from the common fields, and dd.trace_id and dd.span_id (in the
+// decimal format of Datadog) from the trace_id and span_id fields, e.g. added by the otelhook.
+type DatadogHook struct{}
+
+// NewDatadogHook creates a new DatadogHook, it is added to the Common Logger if APP_LOG_DATADOG is enabled, or
+// by WithDatadogCorrelation.
+func NewDatadogHook() *DatadogHook {
+	return &DatadogHook{}
+}
+
+// Levels implements the logrus.Hook interface, the hook is fired on all levels.
+func (hook *DatadogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (hook *DatadogHook) Fire(entry *logrus.Entry) error {
+	for field, ddField := range map[string]string{
+		"service": DatadogServiceField,
+		"env":     DatadogEnvField,
+		"version": DatadogVersionField,
+	} {
+		if value, ok := entry.Data[field]; ok && value != "" {
+			entry.Data[ddField] = fmt.Sprint(value)
+		}
+	}
+	traceID, ok := datadogID(entry.Data[TraceIDField])
+	if !ok {
+		return nil
+	}
+	spanID, ok := datadogID(entry.Data[SpanIDField])
+	if !ok {
+		return nil
+	}
+	entry.Data[DatadogTraceIDField] = traceID
+	entry.Data[DatadogSpanIDField] = spanID
+	return nil
+}
+
+// datadogID returns the decimal Datadog ID of a hexadecimal trace or span ID field. Datadog correlates by the
+// lower 64 bits of the 128-bit trace IDs.
+func datadogID(field interface{}) (string, bool) {
+	hex, ok := field.(string)
+	if !ok || hex == "" {
+		return "", false
+	}
+	if len(hex) > 16 {
+		hex = hex[len(hex)-16:]
+	}
+	id, err := strconv.ParseUint(hex, 16, 64)
+	if err != nil {
+		return "", false
+	}
+	return strconv.FormatUint(id, 10), true
+}
+
+// WithDatadogCorrelation adds the DatadogHook to the Common Logger, the same as enabling APP_LOG_DATADOG.
+func WithDatadogCorrelation() LoggerOption {
+	return func(o *loggerOptions) {
+		o.datadog = true
+	}
+}
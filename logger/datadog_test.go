@@ -0,0 +1,47 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestDatadogHook() {
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:         constants.ENV_TEST,
+		constants.APP_LOG_DATADOG: "true",
+	})
+	output := &bytes.Buffer{}
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+
+	commonLog.WithFields(logrus.Fields{
+		TraceIDField: "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanIDField:  "00f067aa0ba902b7",
+	}).Info("Traced")
+	fields := logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields), "Entry should be logged in JSON")
+	ls.Equal("11803532876627986230", fields[DatadogTraceIDField], "Trace ID should be the lower 64 bits in decimal")
+	ls.Equal("67667974448284343", fields[DatadogSpanIDField])
+	ls.Equal("test-service", fields[DatadogServiceField])
+	ls.Equal(constants.ENV_TEST, fields[DatadogEnvField])
+	ls.Equal("v1.2.3", fields[DatadogVersionField])
+	ls.Equal("4bf92f3577b34da6a3ce929d0e0e4736", fields[TraceIDField], "Trace fields should be kept")
+
+	output.Reset()
+	commonLog.Entry().Info("Not traced")
+	fields = logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.NotContains(fields, DatadogTraceIDField, "Entries without a trace should not have a dd.trace_id")
+	ls.Equal("test-service", fields[DatadogServiceField])
+
+	output.Reset()
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{}, WithDatadogCorrelation())
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().Info("Correlated by option")
+	fields = logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("test-service", fields[DatadogServiceField])
+}
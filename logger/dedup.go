@@ -0,0 +1,99 @@
+package logger
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// RepeatedField is the field of the entries summarizing the duplicates suppressed by the deduplication,
+// it holds the number of the suppressed entries.
+const RepeatedField = "repeated"
+
+// dedupKey identifies the duplicate entries.
+type dedupKey struct {
+	level   logrus.Level
+	message string
+}
+
+// dedupWindow counts the duplicates of an entry in the window started by the entry.
+type dedupWindow struct {
+	last     *logrus.Entry
+	repeated int
+	timer    *time.Timer
+}
+
+// deduplicator collapses the entries of the same level and message written within a window into the first
+// entry and a summary entry at the end of the window, which has the last suppressed entry's fields and the
+// number of the suppressed entries in the repeated field. It protects the log volume from tight error loops.
+type deduplicator struct {
+	window time.Duration
+
+	mu      sync.Mutex
+	windows map[dedupKey]*dedupWindow
+}
+
+// newDeduplicator creates a new deduplicator with the supplied window.
+func newDeduplicator(window time.Duration) *deduplicator {
+	return &deduplicator{
+		window:  window,
+		windows: map[dedupKey]*dedupWindow{},
+	}
+}
+
+// admit implements the entryFilter interface.
+func (d *deduplicator) admit(entry *logrus.Entry) bool {
+	if _, ok := entry.Data[RepeatedField]; ok {
+		// The summaries are written by the deduplicator itself
+		return true
+	}
+	if entry.Level <= logrus.FatalLevel {
+		// The panic and fatal entries are never suppressed, their summaries would panic or exit
+		return true
+	}
+	key := dedupKey{level: entry.Level, message: entry.Message}
+
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if window, ok := d.windows[key]; ok {
+		window.last = entry
+		window.repeated++
+		return false
+	}
+	d.windows[key] = &dedupWindow{
+		timer: time.AfterFunc(d.window, func() { d.close(key) }),
+	}
+	return true
+}
+
+// close closes the window of key, and writes its summary if there were duplicates.
+func (d *deduplicator) close(key dedupKey) {
+	d.mu.Lock()
+	window, ok := d.windows[key]
+	delete(d.windows, key)
+	d.mu.Unlock()
+	if ok {
+		window.summarize()
+	}
+}
+
+// flush closes every window and writes their summaries, e.g. before the application exits.
+func (d *deduplicator) flush() {
+	d.mu.Lock()
+	windows := d.windows
+	d.windows = map[dedupKey]*dedupWindow{}
+	d.mu.Unlock()
+	for _, window := range windows {
+		window.timer.Stop()
+		window.summarize()
+	}
+}
+
+// summarize writes the summary of the window if there were duplicates.
+func (window *dedupWindow) summarize() {
+	if window.repeated == 0 {
+		return
+	}
+	window.last.WithField(RepeatedField, window.repeated).Log(window.last.Level, window.last.Message)
+}
@@ -0,0 +1,106 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// lockedBuffer is a bytes.Buffer safe for concurrent use.
+type lockedBuffer struct {
+	mu     sync.Mutex
+	buffer bytes.Buffer
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) lines() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return strings.Split(strings.TrimSpace(b.buffer.String()), "\n")
+}
+
+func (ls *LoggerSuite) TestDeduplicator() {
+	output := &lockedBuffer{}
+	l := logrus.New()
+	l.SetOutput(output)
+	l.SetFormatter(&filteringFormatter{
+		pipeline:  newPipeline([]entryFilter{newDeduplicator(50 * time.Millisecond)}, nil, nil),
+		formatter: BasicJSONFormatter,
+	})
+	for i := 0; i < 5; i++ {
+		l.WithField("attempt", i).Error("Connection refused")
+	}
+	l.Warn("Connection refused")
+	ls.Len(output.lines(), 2, "The duplicates should be suppressed within the window")
+
+	ls.Eventually(func() bool { return len(output.lines()) == 3 }, time.Second, 10*time.Millisecond,
+		"The summary should be written at the end of the window")
+	summary := logrus.Fields{}
+	ls.NoError(json.Unmarshal([]byte(output.lines()[2]), &summary))
+	ls.Equal("Connection refused", summary[logrus.FieldKeyMsg])
+	ls.Equal("error", summary[logrus.FieldKeyLevel])
+	ls.Equal(float64(4), summary[RepeatedField])
+	ls.Equal(float64(4), summary["attempt"], "The summary should have the fields of the last duplicate")
+
+	l.Error("Connection refused")
+	ls.Len(output.lines(), 4, "A new window should be started after the summary")
+}
+
+func (ls *LoggerSuite) TestDeduplicatorPanic() {
+	output := &lockedBuffer{}
+	l := logrus.New()
+	l.SetOutput(output)
+	dedup := newDeduplicator(10 * time.Millisecond)
+	l.SetFormatter(&filteringFormatter{
+		pipeline:  newPipeline([]entryFilter{dedup}, nil, nil),
+		formatter: BasicJSONFormatter,
+	})
+	for i := 0; i < 2; i++ {
+		ls.Panics(func() { l.Panic("Invariant violated") })
+	}
+	ls.Len(output.lines(), 2, "The panic entries should not be suppressed")
+
+	ls.NotPanics(dedup.flush, "No summary should be written for the panic entries")
+	time.Sleep(30 * time.Millisecond)
+	ls.Len(output.lines(), 2)
+}
+
+func (ls *LoggerSuite) TestDedupFromConfiguration() {
+	for name, opts := range map[string][]LoggerOption{
+		"logrus":  nil,
+		"backend": {WithBackend(jsonBackend)},
+	} {
+		filename := filepath.Join(ls.T().TempDir(), "app.log")
+		commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+			constants.APP_LOG_FILE:             filename,
+			constants.APP_LOG_DEDUP_WINDOW:     "1m",
+			constants.APP_LOG_SAMPLING_INITIAL: "1",
+		}, opts...).NewComponentLogger("worker")
+		for i := 0; i < 10; i++ {
+			commonLog.Entry().Error("Connection refused")
+		}
+		ls.NoError(commonLog.Sync(), "Sync should write the summaries")
+
+		content, err := ioutil.ReadFile(filename)
+		ls.NoError(err)
+		lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+		ls.Require().Len(lines, 2, "Duplicates should be collapsed with %s", name)
+		summary := logrus.Fields{}
+		ls.NoError(json.Unmarshal([]byte(lines[1]), &summary))
+		ls.Equal(float64(9), summary[RepeatedField], "The summary should not be sampled")
+		ls.Equal("worker", summary["component"])
+	}
+}
@@ -0,0 +1,148 @@
+package logger
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// elasticsearchServer records the indexed documents, the documents of the queued item statuses are not indexed
+type elasticsearchServer struct {
+	mu        sync.Mutex
+	requests  int
+	indices   []string
+	documents []map[string]interface{}
+	statuses  [][]int
+}
+
+func (s *elasticsearchServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests++
+	if r.URL.Path != "/_bulk" || r.Header.Get("Content-Type") != "application/x-ndjson" {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	var statuses []int
+	if len(s.statuses) > 0 {
+		statuses, s.statuses = s.statuses[0], s.statuses[1:]
+	}
+
+	items := []string{}
+	hasErrors := false
+	scanner := bufio.NewScanner(r.Body)
+	for i := 0; scanner.Scan(); i++ {
+		action := map[string]map[string]string{}
+		_ = json.Unmarshal(scanner.Bytes(), &action)
+		scanner.Scan()
+		status := http.StatusCreated
+		if i < len(statuses) {
+			status = statuses[i]
+		}
+		if status != http.StatusCreated {
+			hasErrors = true
+			items = append(items, fmt.Sprintf(`{"create":{"status":%d,"error":{"type":"mapper_parsing_exception","reason":"failed to parse"}}}`, status))
+			continue
+		}
+		document := map[string]interface{}{}
+		_ = json.Unmarshal(scanner.Bytes(), &document)
+		s.indices = append(s.indices, action["create"]["_index"])
+		s.documents = append(s.documents, document)
+		items = append(items, `{"create":{"status":201}}`)
+	}
+	fmt.Fprintf(w, `{"errors":%t,"items":[%s]}`, hasErrors, strings.Join(items, ","))
+}
+
+func (ls *LoggerSuite) TestElasticsearchHook() {
+	server := &elasticsearchServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	commonLog := NewCommonLoggerFromConfiguration("Test-Service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:                           constants.ENV_TEST,
+		constants.APP_LOG_ELASTICSEARCH_URL:         httpServer.URL + "/",
+		constants.APP_LOG_ELASTICSEARCH_BUFFER_SIZE: "2",
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(&strings.Builder{})
+
+	yesterday := time.Date(2024, 1, 30, 23, 30, 0, 0, time.UTC)
+	commonLog.WithField("order", 42).WithTime(yesterday).Info("Order created")
+	commonLog.Entry().WithTime(yesterday.Add(time.Hour)).Error("Payment failed")
+	commonLog.Entry().WithTime(yesterday.Add(time.Hour)).Warn("Retrying")
+	ls.NoError(commonLog.Sync())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	ls.Equal(2, server.requests, "Entries should be indexed in bulk requests of the buffer size")
+	ls.Equal([]string{"logs-test-service-2024.01.30", "logs-test-service-2024.01.31", "logs-test-service-2024.01.31"}, server.indices,
+		"Entries should be indexed into the daily indices")
+	ls.Require().Len(server.documents, 3)
+	ls.Equal("Order created", server.documents[0]["msg"])
+	ls.Equal(float64(42), server.documents[0]["order"])
+	ls.Equal("Test-Service", server.documents[1]["service"])
+}
+
+func (ls *LoggerSuite) TestElasticsearchHookFallback() {
+	server := &elasticsearchServer{statuses: [][]int{{http.StatusTooManyRequests, http.StatusBadRequest, http.StatusCreated}}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	hook, err := NewElasticsearchHook(httpServer.URL, "audit", 0, time.Hour)
+	ls.Require().NoError(err)
+	defer hook.Close()
+	fallback := &strings.Builder{}
+	hook.Fallback = fallback
+	hook.backoff = time.Millisecond
+	for _, message := range []string{"Throttled", "Invalid", "Indexed"} {
+		ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: message, Data: logrus.Fields{}}))
+	}
+	ls.EqualError(hook.Flush(), "Failed to index 1 entries into Elasticsearch: Elasticsearch rejected an entry: mapper_parsing_exception: failed to parse")
+	ls.Equal(2, server.requests, "Entries rejected with 429 should be retried")
+	ls.Len(server.documents, 2)
+	ls.Contains(fallback.String(), `"msg":"Invalid"`, "Entries failed to index should be written to the fallback")
+	ls.Equal(1, strings.Count(fallback.String(), "\n"))
+
+	httpServer.Close()
+	hook.Retries = 0
+	ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: "Unreachable", Data: logrus.Fields{}}))
+	ls.Error(hook.Flush())
+	ls.Contains(fallback.String(), `"msg":"Unreachable"`, "Entries should be written to the fallback if the cluster is down")
+
+	_, err = NewElasticsearchHook(httpServer.URL, "Logs", 0, 0)
+	ls.EqualError(err, "Invalid Elasticsearch index Logs")
+}
+
+func (ls *LoggerSuite) TestElasticsearchHookFallbackRemaining() {
+	server := &elasticsearchServer{statuses: [][]int{{http.StatusBadRequest}}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	hook, err := NewElasticsearchHook(httpServer.URL, "audit", 1, time.Hour)
+	ls.Require().NoError(err)
+	defer hook.Close()
+	fallback := &strings.Builder{}
+	hook.Fallback = fallback
+	for _, message := range []string{"Invalid", "Next"} {
+		ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: message, Data: logrus.Fields{}}))
+	}
+	ls.EqualError(hook.Flush(), "Failed to index 2 entries into Elasticsearch: Elasticsearch rejected an entry: mapper_parsing_exception: failed to parse")
+	ls.Equal(1, server.requests, "The next bulk requests should not be sent after a failure")
+	ls.Contains(fallback.String(), `"msg":"Next"`, "The entries of the next bulk requests should be written to the fallback")
+
+	// A slow bulk request holds sendMu, the entries beyond the buffer go to the fallback without waiting
+	hook.sendMu.Lock()
+	defer hook.sendMu.Unlock()
+	for i := 0; i < 11; i++ {
+		ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: "Buffered", Data: logrus.Fields{}}))
+	}
+	ls.Equal(1, strings.Count(fallback.String(), `"msg":"Buffered"`))
+}
@@ -0,0 +1,49 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// entryFilter decides which entries are written, e.g. the sampler.
+type entryFilter interface {
+	// admit returns whether the entry should be written.
+	admit(entry *logrus.Entry) bool
+}
+
+// admitAll returns whether every filter admits the entry, in order.
+func admitAll(filters []entryFilter, entry *logrus.Entry) bool {
+	for _, filter := range filters {
+		if !filter.admit(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+// filteringFormatter formats the entries admitted by the pipeline with formatter, the others are dropped.
+type filteringFormatter struct {
+	pipeline
+	formatter logrus.Formatter
+}
+
+// Format implements the logrus.Formatter interface.
+func (f *filteringFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	if !f.process(entry) {
+		return nil, nil
+	}
+	return f.formatter.Format(entry)
+}
+
+// filteringBackend writes the entries admitted by the pipeline with backend, the others are dropped.
+type filteringBackend struct {
+	Backend
+	pipeline
+}
+
+// Write implements the Backend interface.
+func (b *filteringBackend) Write(entry *logrus.Entry) error {
+	if !b.process(entry) {
+		return nil
+	}
+	return b.Backend.Write(entry)
+}
@@ -0,0 +1,182 @@
+// Package fluentdhook provides a logger.Shipper which forwards the entries of the Common Logger to a fluentd or
+// fluent-bit aggregator.
+// Use FromConfiguration with logger.WithShippers to forward to the APP_LOG_FLUENTD_ADDR
+// Use New to create the Hook with custom settings
+package fluentdhook
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fluent/fluent-logger-golang/fluent"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// DefaultPort is the port of the forward input of fluentd and fluent-bit.
+const DefaultPort = 24224
+
+// timeout is the timeout of the connections and the writes of the Hook, and of the acknowledgements when the Hook
+// is closed. The fluentd client waits for the acknowledgements without a timeout.
+const timeout = 10 * time.Second
+
+// Hook is a logger.Shipper which ships the entries to a fluentd or fluent-bit aggregator by the forward
+// protocol (msgpack over TCP), so the nodes without a log collector sidecar can ship directly. The records hold
+// the msg, level and fields of the entries, with the time of the entries in nanoseconds. Every entry is
+// acknowledged by the aggregator, the entries not acknowledged are sent again after reconnecting.
+// The entries are queued and sent in the background, Close the hook to send the queued entries on shutdown.
+type Hook struct {
+	// Tag is the fluentd tag of the records, e.g. the service name.
+	Tag string
+
+	fluent *fluent.Fluent
+
+	// closeTimeout is the time Close waits for the queued entries to be acknowledged
+	closeTimeout time.Duration
+}
+
+// New creates a new Hook which sends the entries with tag to the forward input at addr:
+//   - host:port or tcp://host:port: the aggregator over TCP (port 24224 if not set)
+//   - tls://host:port: the aggregator over TLS
+//   - unix:///path: the unix socket of the aggregator
+//
+// bufferLimit is the number of queued entries, the entries are dropped while the queue is full (8192 if zero).
+func New(addr, tag string, bufferLimit int) (*Hook, error) {
+	conf := fluent.Config{
+		Async:              true,
+		RequestAck:         true,
+		SubSecondPrecision: true,
+		BufferLimit:        bufferLimit,
+		Timeout:            timeout,
+		WriteTimeout:       timeout,
+		MaxRetry:           5,
+		MaxRetryWait:       int(timeout / time.Millisecond),
+	}
+	if !strings.Contains(addr, "://") {
+		addr = "tcp://" + addr
+	}
+	parsed, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid fluentd address %s", addr)
+	}
+	switch parsed.Scheme {
+	case "unix":
+		conf.FluentNetwork, conf.FluentSocketPath = "unix", parsed.Path
+	case "tcp", "tls":
+		conf.FluentNetwork, conf.FluentHost, conf.FluentPort = parsed.Scheme, parsed.Hostname(), DefaultPort
+		if port := parsed.Port(); port != "" {
+			if conf.FluentPort, err = strconv.Atoi(port); err != nil {
+				return nil, errors.Errorf("Invalid fluentd address %s", addr)
+			}
+		}
+		if conf.FluentHost == "" {
+			return nil, errors.Errorf("Invalid fluentd address %s", addr)
+		}
+	default:
+		return nil, errors.Errorf("Invalid fluentd address %s: the scheme must be tcp, tls or unix", addr)
+	}
+
+	client, err := fluent.New(conf)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the fluentd client")
+	}
+	return &Hook{Tag: tag, fluent: client, closeTimeout: timeout}, nil
+}
+
+// Levels implements the logger.Shipper interface, every level is shipped.
+func (hook *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Ship implements the logger.Shipper interface, the entry is queued to be sent in the background as a record of its
+// fields.
+func (hook *Hook) Ship(entry *logrus.Entry, _ []byte) error {
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	if err := hook.fluent.PostWithTime(hook.Tag, timestamp, entryRecord(entry)); err != nil {
+		return errors.Wrap(err, "Failed to send the entry to fluentd")
+	}
+	return nil
+}
+
+// Close sends the queued entries and closes the connection to the aggregator. It gives up if the aggregator does not
+// acknowledge the entries in time, e.g. it accepts the connection but does not answer.
+func (hook *Hook) Close() error {
+	closed := make(chan error, 1)
+	go func() {
+		closed <- hook.fluent.Close()
+	}()
+	select {
+	case err := <-closed:
+		return err
+	case <-time.After(hook.closeTimeout):
+		return errors.New("Failed to send the queued entries to fluentd in time")
+	}
+}
+
+// entryRecord returns the record of the entry. The values are converted to the types of msgpack, the errors
+// to their messages and the other values (e.g. structs) to their JSON representation.
+func entryRecord(entry *logrus.Entry) map[string]interface{} {
+	record := make(map[string]interface{}, len(entry.Data)+4)
+	for key, value := range entry.Data {
+		record[key] = msgpackValue(value)
+	}
+	record[logrus.FieldKeyMsg] = entry.Message
+	record[logrus.FieldKeyLevel] = entry.Level.String()
+	if entry.HasCaller() {
+		record[logrus.FieldKeyFunc] = entry.Caller.Function
+		record[logrus.FieldKeyFile] = fmt.Sprintf("%s:%d", entry.Caller.File, entry.Caller.Line)
+	}
+	return record
+}
+
+// msgpackValue converts a field value to a type supported by msgpack.
+func msgpackValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case nil, bool, string, []byte,
+		int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	case error:
+		return value.Error()
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case time.Duration:
+		return value.String()
+	}
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	var converted interface{}
+	if err := json.Unmarshal(serialized, &converted); err != nil {
+		return fmt.Sprint(value)
+	}
+	return converted
+}
+
+// FromConfiguration is a logger.ShipperFactory which creates the Hook of the Common Logger forwarding to the
+// aggregator of APP_LOG_FLUENTD_ADDR, tagged by APP_LOG_FLUENTD_TAG (the service name if not set). No Hook is
+// created if the address is not set.
+func FromConfiguration(serviceName, _ string, conf logger.ConfigGetter) (logger.Shipper, error) {
+	addr := conf.Get(constants.APP_LOG_FLUENTD_ADDR)
+	if addr == "" {
+		return nil, nil
+	}
+	tag := conf.Get(constants.APP_LOG_FLUENTD_TAG)
+	if tag == "" {
+		tag = serviceName
+	}
+	hook, err := New(addr, tag, 0)
+	if err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
@@ -0,0 +1,112 @@
+package fluentdhook
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/fluent/fluent-logger-golang/fluent"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/tinylib/msgp/msgp"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// FluentdHookSuite extends testify's Suite.
+type FluentdHookSuite struct {
+	suite.Suite
+}
+
+// serveFluentd acknowledges the forward messages of the first connection, the first message is not acknowledged.
+func serveFluentd(listener net.Listener, messages chan<- *fluent.MessageExt) {
+	dropped := false
+	for {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		reader, writer := msgp.NewReader(conn), msgp.NewWriter(conn)
+		for {
+			message := &fluent.MessageExt{}
+			if err := message.DecodeMsg(reader); err != nil {
+				break
+			}
+			if !dropped {
+				// The client sends the message again on a new connection
+				dropped = true
+				break
+			}
+			messages <- message
+			if err := (fluent.AckResp{Ack: message.Option["chunk"]}).EncodeMsg(writer); err != nil || writer.Flush() != nil {
+				break
+			}
+		}
+		_ = conn.Close()
+	}
+}
+
+func (fs *FluentdHookSuite) TestFromConfiguration() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	fs.Require().NoError(err)
+	defer listener.Close()
+	messages := make(chan *fluent.MessageExt, 10)
+	go serveFluentd(listener, messages)
+
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:              constants.ENV_TEST,
+		constants.APP_LOG_FILE:         filepath.Join(fs.T().TempDir(), "app.log"),
+		constants.APP_LOG_FLUENTD_ADDR: listener.Addr().String(),
+	}, logger.WithShippers(FromConfiguration))
+	timestamp := time.Date(2024, 1, 31, 12, 0, 0, 123456789, time.UTC)
+	commonLog.WithError(errors.New("connection refused")).WithTime(timestamp).
+		WithField("retry", map[string]int{"attempt": 2}).Error("Payment failed")
+
+	var message *fluent.MessageExt
+	select {
+	case message = <-messages:
+	case <-time.After(10 * time.Second):
+		fs.FailNow("Entry should be forwarded to fluentd")
+	}
+	fs.Equal("test-service", message.Tag, "Records should be tagged by the service name")
+	fs.True(time.Time(message.Time).Equal(timestamp), "Time should have nanosecond precision")
+	fs.NotEmpty(message.Option["chunk"], "Entries should request an acknowledgement")
+	record, ok := message.Record.(map[string]interface{})
+	fs.Require().True(ok)
+	fs.Equal("Payment failed", record["msg"])
+	fs.Equal("error", record["level"])
+	fs.Equal("connection refused", record["error"])
+	fs.Equal("test-service", record["service"])
+	fs.Equal(map[string]interface{}{"attempt": float64(2)}, record["retry"])
+	fs.NoError(commonLog.Close())
+
+	_, err = New("http://fluentd:24224", "test", 0)
+	fs.EqualError(err, "Invalid fluentd address http://fluentd:24224: the scheme must be tcp, tls or unix")
+}
+
+func (fs *FluentdHookSuite) TestCloseUnacknowledged() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	fs.Require().NoError(err)
+	defer listener.Close()
+	go func() {
+		// The aggregator reads the entries but never acknowledges them
+		conn, err := listener.Accept()
+		if err == nil {
+			_, _ = io.Copy(io.Discard, conn)
+		}
+	}()
+
+	hook, err := New(listener.Addr().String(), "test", 0)
+	fs.Require().NoError(err)
+	hook.closeTimeout = 100 * time.Millisecond
+	fs.NoError(hook.Ship(&logrus.Entry{Data: logrus.Fields{}, Message: "Payment failed", Level: logrus.ErrorLevel}, nil))
+	fs.EqualError(hook.Close(), "Failed to send the queued entries to fluentd in time")
+}
+
+func TestFluentdHook(t *testing.T) {
+	suite.Run(t, new(FluentdHookSuite))
+}
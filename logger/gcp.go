@@ -0,0 +1,89 @@
+package logger
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// The special fields of the structured logs of Google Cloud Logging.
+const (
+	gcpTraceField          = "logging.googleapis.com/trace"
+	gcpSpanIDField         = "logging.googleapis.com/spanId"
+	gcpTraceSampledField   = "logging.googleapis.com/trace_sampled"
+	gcpSourceLocationField = "logging.googleapis.com/sourceLocation"
+)
+
+// gcpSeverities are the Cloud Logging severities of the Logrus levels.
+var gcpSeverities = map[logrus.Level]string{
+	logrus.PanicLevel: "ALERT",
+	logrus.FatalLevel: "CRITICAL",
+	logrus.ErrorLevel: "ERROR",
+	logrus.WarnLevel:  "WARNING",
+	logrus.InfoLevel:  "INFO",
+	logrus.DebugLevel: "DEBUG",
+	logrus.TraceLevel: "DEBUG",
+}
+
+// GCPFormatter is a logrus.Formatter which formats the entries as the structured logs of Google Cloud Logging, so
+// the logging agent of GKE and Cloud Run maps the severity, the timestamp, the trace and the source location of the
+// entries instead of logging everything as INFO. The fields of the entries are the fields of the jsonPayload, the
+// fields clashing with the special fields are prefixed by "fields.".
+type GCPFormatter struct {
+	// ProjectID is the Google Cloud project of the traces. Without a project the trace_id field is not linked
+	// to Cloud Trace.
+	ProjectID string
+}
+
+// Format implements the logrus.Formatter interface.
+func (f *GCPFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	payload := make(logrus.Fields, len(entry.Data)+6)
+	for key, value := range entry.Data {
+		switch key {
+		case "severity", "message", "timestamp", gcpTraceField, gcpSpanIDField, gcpTraceSampledField, gcpSourceLocationField:
+			key = "fields." + key
+		}
+		if err, ok := value.(error); ok {
+			// The errors are marshalled as empty objects otherwise
+			value = err.Error()
+		}
+		payload[key] = value
+	}
+
+	severity, ok := gcpSeverities[entry.Level]
+	if !ok {
+		severity = "DEFAULT"
+	}
+	payload["severity"] = severity
+	payload["message"] = entry.Message
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	payload["timestamp"] = timestamp.Format(time.RFC3339Nano)
+
+	if traceID, ok := entry.Data[TraceIDField].(string); ok && traceID != "" && f.ProjectID != "" {
+		payload[gcpTraceField] = "projects/" + f.ProjectID + "/traces/" + traceID
+		if spanID, ok := entry.Data[SpanIDField].(string); ok {
+			payload[gcpSpanIDField] = spanID
+		}
+		if sampled, ok := entry.Data[TraceSampledField].(bool); ok {
+			payload[gcpTraceSampledField] = sampled
+		}
+	}
+	if entry.HasCaller() {
+		payload[gcpSourceLocationField] = map[string]interface{}{
+			"file":     entry.Caller.File,
+			"line":     entry.Caller.Line,
+			"function": entry.Caller.Function,
+		}
+	}
+
+	serialized, err := json.Marshal(payload)
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal the entry")
+	}
+	return append(serialized, '\n'), nil
+}
@@ -0,0 +1,51 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestGCPFormatter() {
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:             constants.ENV_TEST,
+		constants.APP_DEBUG:           "true",
+		constants.APP_LOG_FORMAT:      constants.LOG_FORMAT_GCP,
+		constants.APP_LOG_GCP_PROJECT: "my-project",
+	})
+	output := &bytes.Buffer{}
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+
+	commonLog.WithFields(logrus.Fields{
+		TraceIDField:      "4bf92f3577b34da6a3ce929d0e0e4736",
+		SpanIDField:       "00f067aa0ba902b7",
+		TraceSampledField: true,
+	}).WithField("error", errors.New("connection refused")).WithField("severity", "high").Warn("Payment slow")
+
+	fields := map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields), "Entry should be logged in JSON")
+	ls.Equal("WARNING", fields["severity"])
+	ls.Equal("Payment slow", fields["message"])
+	ls.Contains(fields, "timestamp")
+	ls.Equal("projects/my-project/traces/4bf92f3577b34da6a3ce929d0e0e4736", fields[gcpTraceField])
+	ls.Equal("00f067aa0ba902b7", fields[gcpSpanIDField])
+	ls.Equal(true, fields[gcpTraceSampledField])
+	ls.Equal("high", fields["fields.severity"], "Clashing fields should be prefixed")
+	ls.Equal("connection refused", fields["error"])
+	ls.Equal("test-service", fields["service"])
+	location, ok := fields[gcpSourceLocationField].(map[string]interface{})
+	ls.Require().True(ok, "Source location should be set with the caller")
+	ls.Contains(location["file"], "gcp_test.go")
+	ls.Contains(location["function"], "TestGCPFormatter")
+
+	output.Reset()
+	commonLog.Entry().Error("Payment failed")
+	fields = map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("ERROR", fields["severity"])
+	ls.NotContains(fields, gcpTraceField, "Entries without a span should not have a trace")
+}
@@ -0,0 +1,263 @@
+package logger
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultGELFPort is the port of the GELF inputs of Graylog.
+const DefaultGELFPort = 12201
+
+// gelfChunkSize is the size of the data of a GELF chunk, so the UDP datagrams fit the MTU of most networks.
+const gelfChunkSize = 1420
+
+// gelfMaxChunks is the maximum number of the chunks of a GELF message.
+const gelfMaxChunks = 128
+
+// gelfChunkMagic are the magic bytes of a GELF chunk.
+var gelfChunkMagic = []byte{0x1e, 0x0f}
+
+// gelfInvalidKeyChars matches the characters not allowed in the names of the additional fields.
+var gelfInvalidKeyChars = regexp.MustCompile(`[^\w.\-]`)
+
+// GELFFormatter is a logrus.Formatter which formats the entries as GELF 1.1 messages for Graylog, one JSON object
+// per line. The level is the syslog severity of the entry, the fields of the entry are additional fields
+// (prefixed by _), with the caller as the _file, _line and _func fields.
+type GELFFormatter struct {
+	// Host is the host of the messages if the entries have no host field, the hostname if empty.
+	Host string
+}
+
+// Format implements the logrus.Formatter interface.
+func (f *GELFFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	serialized, err := json.Marshal(f.message(entry))
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to marshal the GELF message")
+	}
+	return append(serialized, '\n'), nil
+}
+
+// message returns the GELF message of the entry.
+func (f *GELFFormatter) message(entry *logrus.Entry) map[string]interface{} {
+	host, _ := entry.Data["host"].(string)
+	if host == "" {
+		host = f.Host
+	}
+	if host == "" {
+		host, _ = os.Hostname()
+	}
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	severity, ok := syslogSeverities[entry.Level]
+	if !ok {
+		severity = syslogSeverities[logrus.InfoLevel]
+	}
+
+	message := make(map[string]interface{}, len(entry.Data)+8)
+	for key, value := range entry.Data {
+		if key == "host" {
+			continue
+		}
+		message[gelfFieldName(key)] = gelfValue(value)
+	}
+	message["version"] = "1.1"
+	message["host"] = host
+	message["timestamp"] = math.Round(float64(timestamp.UnixNano())/1e6) / 1e3
+	message["level"] = severity
+	shortMessage := strings.TrimSpace(entry.Message)
+	if i := strings.IndexByte(shortMessage, '\n'); i >= 0 {
+		message["full_message"] = entry.Message
+		shortMessage = strings.TrimSpace(shortMessage[:i])
+	}
+	if shortMessage == "" {
+		// The short message is mandatory
+		shortMessage = entry.Level.String()
+	}
+	message["short_message"] = shortMessage
+	if entry.HasCaller() {
+		message["_file"] = entry.Caller.File
+		message["_line"] = entry.Caller.Line
+		message["_func"] = entry.Caller.Function
+	}
+	return message
+}
+
+// gelfFieldName returns the name of the additional field of a field, the _id field is reserved by Graylog.
+func gelfFieldName(key string) string {
+	key = "_" + gelfInvalidKeyChars.ReplaceAllString(key, "_")
+	if key == "_id" {
+		return "_id_"
+	}
+	return key
+}
+
+// gelfValue converts a field value to the string or number value of an additional field.
+func gelfValue(value interface{}) interface{} {
+	switch value := value.(type) {
+	case string, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return value
+	case bool:
+		return strconv.FormatBool(value)
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	case nil:
+		return ""
+	}
+	serialized, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(serialized)
+}
+
+// GELFHook is a Shipper which sends the entries as GELF 1.1 messages to a GELF input of Graylog, see
+// GELFFormatter. Over UDP the large messages are compressed and chunked, over TCP the messages are delimited by
+// null bytes (uncompressed, as required by Graylog). The entries are sent while the Logger holds its lock, so the
+// connections and the writes time out after a second, and the entries are dropped for a few seconds after a failed
+// reconnect.
+type GELFHook struct {
+	network   string
+	address   string
+	formatter *GELFFormatter
+
+	mu   sync.Mutex
+	conn dialedConn
+}
+
+// NewGELFHook creates a new GELFHook and connects to the GELF input at addr:
+//   - udp://host:port: the GELF UDP input (port 12201 if not set)
+//   - tcp://host:port: the GELF TCP input (port 12201 if not set)
+//
+// host is the host of the messages if the entries have no host field, the hostname if empty.
+func NewGELFHook(addr, host string) (*GELFHook, error) {
+	parsed, err := url.Parse(addr)
+	if err != nil {
+		return nil, errors.Wrapf(err, "Invalid GELF address %s", addr)
+	}
+	if parsed.Scheme != "udp" && parsed.Scheme != "tcp" {
+		return nil, errors.Errorf("Invalid GELF address %s: the scheme must be udp or tcp", addr)
+	}
+	if parsed.Hostname() == "" {
+		return nil, errors.Errorf("Invalid GELF address %s", addr)
+	}
+	port := parsed.Port()
+	if port == "" {
+		port = strconv.Itoa(DefaultGELFPort)
+	}
+	hook := &GELFHook{
+		network:   parsed.Scheme,
+		address:   net.JoinHostPort(parsed.Hostname(), port),
+		formatter: &GELFFormatter{Host: host},
+	}
+	hook.conn = dialedConn{name: "the GELF input", dial: func(dialer *net.Dialer) (net.Conn, error) {
+		return dialer.Dial(hook.network, hook.address)
+	}}
+	if err := hook.conn.connect(); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// Levels implements the Shipper interface, every level is shipped.
+func (hook *GELFHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Ship implements the Shipper interface, the entry is sent as a GELF message. Over TCP the entry is sent again
+// after a reconnect if the connection was lost.
+func (hook *GELFHook) Ship(entry *logrus.Entry, _ []byte) error {
+	serialized, err := hook.formatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	serialized = bytes.TrimSuffix(serialized, []byte("\n"))
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if hook.network == "udp" {
+		return hook.writeUDP(serialized)
+	}
+	return hook.conn.write(append(serialized, 0), true)
+}
+
+// Close closes the connection to the GELF input.
+func (hook *GELFHook) Close() error {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	return hook.conn.close()
+}
+
+// writeUDP sends the message in a datagram, or gzip compressed in chunks if it does not fit. The caller must hold
+// the lock.
+func (hook *GELFHook) writeUDP(message []byte) error {
+	if len(message) <= gelfChunkSize {
+		return hook.conn.write(message, false)
+	}
+
+	var compressed bytes.Buffer
+	writer := gzip.NewWriter(&compressed)
+	if _, err := writer.Write(message); err != nil {
+		return errors.Wrap(err, "Failed to compress the GELF message")
+	}
+	if err := writer.Close(); err != nil {
+		return errors.Wrap(err, "Failed to compress the GELF message")
+	}
+	chunks, err := gelfChunks(compressed.Bytes())
+	if err != nil {
+		return err
+	}
+	for _, chunk := range chunks {
+		if err := hook.conn.write(chunk, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// gelfChunks splits the message into GELF chunks, each with the magic bytes, the message id, the sequence number
+// and the sequence count before the data.
+func gelfChunks(message []byte) ([][]byte, error) {
+	if len(message) <= gelfChunkSize {
+		return [][]byte{message}, nil
+	}
+	count := (len(message) + gelfChunkSize - 1) / gelfChunkSize
+	if count > gelfMaxChunks {
+		return nil, errors.Errorf("GELF message of %d bytes is too large to be chunked", len(message))
+	}
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return nil, errors.Wrap(err, "Failed to generate the GELF message id")
+	}
+	chunks := make([][]byte, 0, count)
+	for i := 0; i < count; i++ {
+		data := message[i*gelfChunkSize:]
+		if len(data) > gelfChunkSize {
+			data = data[:gelfChunkSize]
+		}
+		chunk := make([]byte, 0, len(gelfChunkMagic)+len(id)+2+len(data))
+		chunk = append(chunk, gelfChunkMagic...)
+		chunk = append(chunk, id...)
+		chunk = append(chunk, byte(i), byte(count))
+		chunks = append(chunks, append(chunk, data...))
+	}
+	return chunks, nil
+}
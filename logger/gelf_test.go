@@ -0,0 +1,145 @@
+package logger
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"io/ioutil"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestGELFFormatter() {
+	output := &strings.Builder{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:        constants.ENV_TEST,
+		constants.APP_LOG_FORMAT: constants.LOG_FORMAT_GELF,
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	timestamp := time.Date(2024, 1, 31, 12, 0, 0, 123456789, time.UTC)
+	commonLog.WithError(errors.New("connection refused")).WithTime(timestamp).
+		WithFields(logrus.Fields{"id": "o-1", "http.status": 502, "retry": true, "order id": "o-1"}).
+		Error("Payment failed\nafter 3 retries")
+
+	message := map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal([]byte(output.String()), &message))
+	ls.Equal("1.1", message["version"])
+	ls.Equal(config.StaticGetter{}.Hostname(), message["host"], "Host should be the host field")
+	ls.Equal("Payment failed", message["short_message"])
+	ls.Equal("Payment failed\nafter 3 retries", message["full_message"])
+	ls.Equal(1706702400.123, message["timestamp"])
+	ls.Equal(float64(3), message["level"], "Level should be the syslog severity")
+	ls.Equal("test-service", message["_service"])
+	ls.Equal("connection refused", message["_error"])
+	ls.Equal("o-1", message["_id_"], "_id should be renamed")
+	ls.Equal("o-1", message["_order_id"])
+	ls.Equal(float64(502), message["_http.status"])
+	ls.Equal("true", message["_retry"], "Booleans should be strings")
+	ls.NotContains(message, "_host")
+}
+
+func (ls *LoggerSuite) TestGELFHookUDP() {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	ls.Require().NoError(err)
+	defer conn.Close()
+
+	hook, err := NewGELFHook("udp://"+conn.LocalAddr().String(), "node-1")
+	ls.Require().NoError(err)
+	defer hook.Close()
+	ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: "Order created", Data: logrus.Fields{}}))
+	random := make([]byte, 4*gelfChunkSize)
+	_, err = rand.Read(random)
+	ls.Require().NoError(err)
+	large := hex.EncodeToString(random)
+	ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: "Large", Data: logrus.Fields{"payload": large}}))
+
+	buffer := make([]byte, 65536)
+	ls.Require().NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	n, _, err := conn.ReadFrom(buffer)
+	ls.Require().NoError(err)
+	message := map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal(buffer[:n], &message))
+	ls.Equal("node-1", message["host"])
+	ls.Equal("Order created", message["short_message"])
+
+	var chunks [][]byte
+	for {
+		n, _, err := conn.ReadFrom(buffer)
+		ls.Require().NoError(err)
+		chunk := append([]byte{}, buffer[:n]...)
+		ls.Require().True(bytes.HasPrefix(chunk, gelfChunkMagic), "Large message should be chunked")
+		ls.LessOrEqual(len(chunk), gelfChunkSize+12)
+		chunks = append(chunks, chunk)
+		if int(chunk[11]) == len(chunks) {
+			break
+		}
+	}
+	var compressed []byte
+	for i, chunk := range chunks {
+		ls.Equal(chunks[0][2:10], chunk[2:10], "Chunks should have the same message id")
+		ls.Equal(byte(i), chunk[10])
+		compressed = append(compressed, chunk[12:]...)
+	}
+	reader, err := gzip.NewReader(bytes.NewReader(compressed))
+	ls.Require().NoError(err)
+	decompressed, err := ioutil.ReadAll(reader)
+	ls.Require().NoError(err)
+	message = map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal(decompressed, &message))
+	ls.Equal(large, message["_payload"])
+}
+
+func (ls *LoggerSuite) TestGELFHookTCP() {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	ls.Require().NoError(err)
+	defer listener.Close()
+	messages := make(chan string, 10)
+	go func() {
+		conn, err := listener.Accept()
+		if err != nil {
+			return
+		}
+		defer conn.Close()
+		reader := bufio.NewReader(conn)
+		for {
+			message, err := reader.ReadString(0)
+			if err != nil {
+				return
+			}
+			messages <- strings.TrimSuffix(message, "\x00")
+		}
+	}()
+
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:           constants.ENV_TEST,
+		constants.APP_LOG_GELF_ADDR: "tcp://" + listener.Addr().String(),
+	})
+	defer commonLog.Close()
+	commonLog.log.(*logrus.Logger).SetOutput(&strings.Builder{})
+	commonLog.Entry().Warn("Payment slow")
+	commonLog.Entry().Info("Order paid")
+
+	for _, expected := range []string{"Payment slow", "Order paid"} {
+		select {
+		case serialized := <-messages:
+			message := map[string]interface{}{}
+			ls.Require().NoError(json.Unmarshal([]byte(serialized), &message), "Messages should be null delimited")
+			ls.Equal(expected, message["short_message"])
+			ls.Equal("test-service", message["_service"])
+		case <-time.After(5 * time.Second):
+			ls.Fail("GELF message not received")
+		}
+	}
+
+	_, err = NewGELFHook("http://graylog:12201", "")
+	ls.EqualError(err, "Invalid GELF address http://graylog:12201: the scheme must be udp or tcp")
+}
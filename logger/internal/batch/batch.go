@@ -0,0 +1,87 @@
+// Package batch provides the background flushing and the retries shared by the batching Shippers of the logger
+// packages, e.g. the Loki, Elasticsearch and CloudWatch Logs hooks.
+package batch
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaxBackoff is the longest wait between the retries of a batch.
+const MaxBackoff = 30 * time.Second
+
+// Loop flushes the entries buffered by a hook every interval, or as soon as a batch is full, until it is
+// stopped. The failed flushes are logged by the standard logrus logger.
+type Loop struct {
+	name  string
+	flush func() error
+
+	full     chan struct{}
+	done     chan struct{}
+	stopped  chan struct{}
+	stopOnce sync.Once
+}
+
+// Start starts the Loop of the hook called name, which calls flush every interval.
+func Start(name string, interval time.Duration, flush func() error) *Loop {
+	loop := &Loop{
+		name:    name,
+		flush:   flush,
+		full:    make(chan struct{}, 1),
+		done:    make(chan struct{}),
+		stopped: make(chan struct{}),
+	}
+	go loop.run(interval)
+	return loop
+}
+
+// Full flushes the entries without waiting for the interval, it does not block.
+func (loop *Loop) Full() {
+	select {
+	case loop.full <- struct{}{}:
+	default:
+	}
+}
+
+// Stop stops the loop and waits for the running flush, the remaining entries are not flushed.
+func (loop *Loop) Stop() {
+	loop.stopOnce.Do(func() {
+		close(loop.done)
+	})
+	<-loop.stopped
+}
+
+// run calls flush every interval, or when a batch is full, until the loop is stopped.
+func (loop *Loop) run(interval time.Duration) {
+	defer close(loop.stopped)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-loop.done:
+			return
+		case <-ticker.C:
+		case <-loop.full:
+		}
+		if err := loop.flush(); err != nil {
+			logrus.StandardLogger().WithError(err).Errorf("Failed to flush the %s hook", loop.name)
+		}
+	}
+}
+
+// Retry calls send until it succeeds, it fails with an error which is not retryable, or the retries are
+// exhausted. The wait between the attempts starts at backoff and doubles up to MaxBackoff.
+func Retry(retries int, backoff time.Duration, send func() (retryable bool, err error)) error {
+	for attempt := 0; ; attempt++ {
+		retryable, err := send()
+		if err == nil || !retryable || attempt >= retries {
+			return err
+		}
+		time.Sleep(backoff)
+		if backoff *= 2; backoff > MaxBackoff {
+			backoff = MaxBackoff
+		}
+	}
+}
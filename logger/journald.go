@@ -0,0 +1,179 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// JournaldSocket is the socket of the native protocol of systemd-journald.
+const JournaldSocket = "/run/systemd/journal/socket"
+
+// journaldBackend is the Backend of JournaldBackend.
+type journaldBackend struct {
+	socket     string
+	identifier string
+
+	// fallback receives the entries formatted by formatter when journald cannot be written, if set.
+	fallback  io.Writer
+	formatter logrus.Formatter
+
+	mu   sync.Mutex
+	conn net.Conn
+}
+
+// JournaldBackend is a BackendFactory sending the entries to systemd-journald with its native protocol.
+// The levels are mapped to the syslog priorities, the message to MESSAGE, and the fields (e.g. service and
+// version) to the upper-cased journal fields (SERVICE, VERSION, ...), so they can be queried with journalctl.
+// The entries which cannot be sent to journald are written to output instead, as JSON (text if dev is set).
+// The Common Logger selects it automatically if the process runs under systemd with its output connected
+// to the journal, unless APP_LOG_JOURNALD is disabled or APP_LOG_FORMAT is set.
+func JournaldBackend(output io.Writer, dev bool) Backend {
+	backend := newJournaldBackend(JournaldSocket, "")
+	backend.fallback = output
+	backend.formatter = BasicJSONFormatter
+	if dev {
+		backend.formatter = BasicTextFormatter
+	}
+	return backend
+}
+
+// newJournaldBackend creates a new journaldBackend writing to socket, with identifier as SYSLOG_IDENTIFIER
+// (the service field of the entries if empty).
+func newJournaldBackend(socket, identifier string) *journaldBackend {
+	return &journaldBackend{socket: socket, identifier: identifier}
+}
+
+// Write implements the Backend interface. If journald cannot be written, the entry is written to the
+// fallback if any, and the connection is dialed again for the next entry.
+func (backend *journaldBackend) Write(entry *logrus.Entry) error {
+	message := backend.format(entry)
+
+	backend.mu.Lock()
+	defer backend.mu.Unlock()
+	err := backend.send(message)
+	if err == nil || backend.fallback == nil {
+		return err
+	}
+	formatted, formatErr := backend.formatter.Format(entry)
+	if formatErr != nil {
+		return errors.Wrap(formatErr, "Failed to format the entry")
+	}
+	_, writeErr := backend.fallback.Write(formatted)
+	return errors.Wrap(writeErr, "Failed to write the entry")
+}
+
+// send writes the message to journald, it connects first if the connection was lost.
+func (backend *journaldBackend) send(message []byte) error {
+	if backend.conn == nil {
+		conn, err := net.Dial("unixgram", backend.socket)
+		if err != nil {
+			return errors.Wrap(err, "Failed to connect to journald")
+		}
+		backend.conn = conn
+	}
+	if _, err := backend.conn.Write(message); err != nil {
+		_ = backend.conn.Close()
+		backend.conn = nil
+		return errors.Wrap(err, "Failed to write to journald")
+	}
+	return nil
+}
+
+// Sync implements the Backend interface, the entries are not buffered.
+func (backend *journaldBackend) Sync() error {
+	return nil
+}
+
+// format encodes the entry in the native journal protocol.
+func (backend *journaldBackend) format(entry *logrus.Entry) []byte {
+	priority, ok := syslogSeverities[entry.Level]
+	if !ok {
+		priority = syslogSeverities[logrus.InfoLevel]
+	}
+	identifier := backend.identifier
+	if service, ok := entry.Data["service"].(string); ok && identifier == "" {
+		identifier = service
+	}
+
+	buffer := &bytes.Buffer{}
+	writeJournalField(buffer, "MESSAGE", entry.Message)
+	writeJournalField(buffer, "PRIORITY", strconv.Itoa(priority))
+	if identifier != "" {
+		writeJournalField(buffer, "SYSLOG_IDENTIFIER", identifier)
+	}
+	if entry.HasCaller() {
+		writeJournalField(buffer, "CODE_FILE", entry.Caller.File)
+		writeJournalField(buffer, "CODE_LINE", strconv.Itoa(entry.Caller.Line))
+		writeJournalField(buffer, "CODE_FUNC", entry.Caller.Function)
+	}
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		writeJournalField(buffer, journalFieldName(key), fmt.Sprint(entry.Data[key]))
+	}
+	return buffer.Bytes()
+}
+
+// writeJournalField writes a field in the native journal protocol, the values with newlines are written
+// in the binary form: the name, a newline, the little-endian 64 bit length and the value.
+func writeJournalField(buffer *bytes.Buffer, name, value string) {
+	if !strings.Contains(value, "\n") {
+		buffer.WriteString(name + "=" + value + "\n")
+		return
+	}
+	buffer.WriteString(name + "\n")
+	_ = binary.Write(buffer, binary.LittleEndian, uint64(len(value)))
+	buffer.WriteString(value + "\n")
+}
+
+// journalFieldName returns the field name as a journal field name: upper-case letters, digits and
+// underscores, not starting with an underscore (reserved for the trusted fields), at most 64 characters.
+func journalFieldName(name string) string {
+	name = strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z':
+			return r - 'a' + 'A'
+		case r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+			return r
+		}
+		return '_'
+	}, name)
+	name = strings.TrimLeft(name, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') {
+		name = "FIELD_" + name
+	}
+	if len(name) > 64 {
+		name = name[:64]
+	}
+	return name
+}
+
+// useJournald returns whether the Common Logger should write to journald: always if APP_LOG_JOURNALD is
+// enabled, never if it is disabled or an APP_LOG_FORMAT is set, otherwise if the process runs under systemd
+// with its output connected to the journal.
+func useJournald(setting, format string) bool {
+	if enabled, err := strconv.ParseBool(setting); err == nil {
+		return enabled
+	}
+	if format != "" {
+		return false
+	}
+	if _, err := os.Stat(JournaldSocket); err != nil {
+		return false
+	}
+	return connectedToJournal(os.Getenv("JOURNAL_STREAM"))
+}
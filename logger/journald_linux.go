@@ -0,0 +1,25 @@
+package logger
+
+import (
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// connectedToJournal returns whether the stdout or the stderr of the process is the journal stream of
+// journalStream (the device and the inode set by systemd, e.g. 8:12345).
+func connectedToJournal(journalStream string) bool {
+	if journalStream == "" {
+		return false
+	}
+	for _, file := range []*os.File{os.Stdout, os.Stderr} {
+		stat := syscall.Stat_t{}
+		if err := syscall.Fstat(int(file.Fd()), &stat); err != nil {
+			continue
+		}
+		if journalStream == strconv.FormatUint(uint64(stat.Dev), 10)+":"+strconv.FormatUint(stat.Ino, 10) {
+			return true
+		}
+	}
+	return false
+}
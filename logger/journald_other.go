@@ -0,0 +1,8 @@
+//go:build !linux
+
+package logger
+
+// connectedToJournal returns false, systemd runs only on Linux.
+func connectedToJournal(string) bool {
+	return false
+}
@@ -0,0 +1,68 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+func (ls *LoggerSuite) TestJournaldBackend() {
+	socket := filepath.Join(ls.T().TempDir(), "journal.socket")
+	server, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	ls.Require().NoError(err)
+	defer server.Close()
+
+	l := logrus.New()
+	log := NewLogger(l, logrus.Fields{"service": "test-service", "version": "v1.2.3"})
+	useBackend(l, newJournaldBackend(socket, ""))
+	log.WithFields(logrus.Fields{"error": "line 1\nline 2", "request-id": 42, "_trusted": "no"}).Warn("Request failed")
+
+	buffer := make([]byte, 4096)
+	ls.Require().NoError(server.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	n, err := server.Read(buffer)
+	ls.Require().NoError(err)
+
+	expected := &bytes.Buffer{}
+	expected.WriteString("MESSAGE=Request failed\nPRIORITY=4\nSYSLOG_IDENTIFIER=test-service\nTRUSTED=no\nERROR\n")
+	ls.NoError(binary.Write(expected, binary.LittleEndian, uint64(len("line 1\nline 2"))))
+	expected.WriteString("line 1\nline 2\nREQUEST_ID=42\nSERVICE=test-service\nVERSION=v1.2.3\n")
+	ls.Equal(expected.String(), string(buffer[:n]), "Entry should be encoded in the native journal protocol")
+
+	ls.Error(newJournaldBackend(filepath.Join(ls.T().TempDir(), "missing.socket"), "").Write(&logrus.Entry{Data: logrus.Fields{}}))
+
+	output := &strings.Builder{}
+	fallback := JournaldBackend(output, false).(*journaldBackend)
+	fallback.socket = filepath.Join(ls.T().TempDir(), "missing.socket")
+	ls.NoError(fallback.Write(&logrus.Entry{Message: "Request failed", Level: logrus.WarnLevel, Data: logrus.Fields{}}))
+	ls.Contains(output.String(), `"msg":"Request failed"`, "The entries should be written to the output if journald is unavailable")
+}
+
+func (ls *LoggerSuite) TestJournalFieldName() {
+	ls.Equal("SERVICE", journalFieldName("service"))
+	ls.Equal("HTTP_STATUS", journalFieldName("http.status"))
+	ls.Equal("FIELD_1ST", journalFieldName("1st"))
+	ls.Equal("FIELD_", journalFieldName("__"))
+	ls.Len(journalFieldName(strings.Repeat("a", 100)), 64, "Field names should be truncated to 64 characters")
+}
+
+func (ls *LoggerSuite) TestUseJournald() {
+	journalStream, ok := os.LookupEnv("JOURNAL_STREAM")
+	ls.NoError(os.Unsetenv("JOURNAL_STREAM"))
+	defer func() {
+		if ok {
+			_ = os.Setenv("JOURNAL_STREAM", journalStream)
+		}
+	}()
+
+	ls.True(useJournald("true", ""), "APP_LOG_JOURNALD should force journald")
+	ls.True(useJournald("true", "text"), "APP_LOG_JOURNALD should force journald")
+	ls.False(useJournald("false", ""), "APP_LOG_JOURNALD should disable journald")
+	ls.False(useJournald("", ""), "Journald should not be used outside of systemd")
+	ls.False(connectedToJournal("0:0"), "The output of the tests is not the journal")
+}
@@ -0,0 +1,128 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// The timestamp formats of the JSON entries written as numbers instead of a time layout, see JSONFormat.
+const (
+	// TimestampEpoch formats the timestamps as the seconds since the Unix epoch.
+	TimestampEpoch = "epoch"
+
+	// TimestampEpochMillis formats the timestamps as the milliseconds since the Unix epoch.
+	TimestampEpochMillis = "epoch_millis"
+)
+
+// JSONFormat are the keys and the timestamp format of the JSON entries, for the ingestion pipelines expecting
+// e.g. @timestamp in epoch milliseconds. The empty settings keep the settings of BasicJSONFormatter.
+type JSONFormat struct {
+	// TimeKey is the key of the timestamp, time if empty.
+	TimeKey string
+
+	// LevelKey is the key of the level, level if empty.
+	LevelKey string
+
+	// MessageKey is the key of the message, msg if empty.
+	MessageKey string
+
+	// TimestampFormat is the time layout of the timestamp (time.RFC3339 if empty), or TimestampEpoch or
+	// TimestampEpochMillis.
+	TimestampFormat string
+}
+
+// merge returns the format with the empty settings set from defaults.
+func (format JSONFormat) merge(defaults JSONFormat) JSONFormat {
+	if format.TimeKey == "" {
+		format.TimeKey = defaults.TimeKey
+	}
+	if format.LevelKey == "" {
+		format.LevelKey = defaults.LevelKey
+	}
+	if format.MessageKey == "" {
+		format.MessageKey = defaults.MessageKey
+	}
+	if format.TimestampFormat == "" {
+		format.TimestampFormat = defaults.TimestampFormat
+	}
+	return format
+}
+
+// NewJSONFormatter creates a JSON formatter of the format, BasicJSONFormatter if the format is empty.
+func NewJSONFormatter(format JSONFormat) logrus.Formatter {
+	if format == (JSONFormat{}) {
+		return BasicJSONFormatter
+	}
+	formatter := &logrus.JSONFormatter{
+		TimestampFormat: BasicJSONFormatter.TimestampFormat,
+		FieldMap: logrus.FieldMap{
+			logrus.FieldKeyTime:  format.TimeKey,
+			logrus.FieldKeyLevel: format.LevelKey,
+			logrus.FieldKeyMsg:   format.MessageKey,
+		},
+	}
+	for key, value := range formatter.FieldMap {
+		if value == "" {
+			delete(formatter.FieldMap, key)
+		}
+	}
+	switch format.TimestampFormat {
+	case "":
+	case TimestampEpoch, TimestampEpochMillis:
+		formatter.DisableTimestamp = true
+		timeKey := format.TimeKey
+		if timeKey == "" {
+			timeKey = logrus.FieldKeyTime
+		}
+		return &epochJSONFormatter{JSONFormatter: formatter, timeKey: timeKey, millis: format.TimestampFormat == TimestampEpochMillis}
+	default:
+		formatter.TimestampFormat = format.TimestampFormat
+	}
+	return formatter
+}
+
+// WithJSONFormat sets the keys and the timestamp format of the JSON entries of the Common Logger, the
+// APP_LOG_TIME_KEY, APP_LOG_LEVEL_KEY, APP_LOG_MESSAGE_KEY and APP_LOG_TIMESTAMP_FORMAT variables take precedence.
+func WithJSONFormat(format JSONFormat) LoggerOption {
+	return func(o *loggerOptions) {
+		o.jsonFormat = format
+	}
+}
+
+// epochJSONFormatter is a logrus.JSONFormatter writing the timestamps as numbers.
+type epochJSONFormatter struct {
+	*logrus.JSONFormatter
+	timeKey string
+	millis  bool
+}
+
+// Format implements the logrus.Formatter interface, the timestamp is the first key of the JSON object.
+func (f *epochJSONFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	serialized, err := f.JSONFormatter.Format(entry)
+	if err != nil || !bytes.HasPrefix(serialized, []byte("{")) {
+		return serialized, err
+	}
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	epoch := timestamp.Unix()
+	if f.millis {
+		epoch = timestamp.UnixNano() / int64(time.Millisecond)
+	}
+	key, _ := json.Marshal(f.timeKey)
+
+	formatted := make([]byte, 0, len(serialized)+len(key)+22)
+	formatted = append(formatted, '{')
+	formatted = append(formatted, key...)
+	formatted = append(formatted, ':')
+	formatted = strconv.AppendInt(formatted, epoch, 10)
+	if !bytes.HasPrefix(serialized, []byte("{}")) {
+		formatted = append(formatted, ',')
+	}
+	return append(formatted, serialized[1:]...), nil
+}
@@ -0,0 +1,48 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestJSONFormat() {
+	output := &bytes.Buffer{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:                  constants.ENV_TEST,
+		constants.APP_LOG_TIME_KEY:         "@timestamp",
+		constants.APP_LOG_TIMESTAMP_FORMAT: TimestampEpochMillis,
+	}, WithJSONFormat(JSONFormat{MessageKey: "message", TimeKey: "ts", TimestampFormat: time.RFC3339Nano}))
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	timestamp := time.Date(2024, 1, 31, 12, 0, 0, 123456789, time.UTC)
+	commonLog.Entry().WithTime(timestamp).WithField("@timestamp", "clash").Info("Order paid")
+
+	ls.True(strings.HasPrefix(output.String(), `{"@timestamp":1706702400123,`), "Timestamp should be in epoch millis")
+	fields := map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields), "Entry should be logged in JSON")
+	ls.Equal(float64(1706702400123), fields["@timestamp"], "Configuration should take precedence over the option")
+	ls.Equal("Order paid", fields["message"], "Message key of the option should be used")
+	ls.Equal("info", fields["level"])
+	ls.Equal("clash", fields["fields.@timestamp"])
+	ls.NotContains(fields, "msg")
+	ls.NotContains(fields, "ts")
+
+	output.Reset()
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_LEVEL_KEY:        "severity",
+		constants.APP_LOG_TIMESTAMP_FORMAT: "2006-01-02 15:04:05.000",
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().WithTime(timestamp).Warn("Payment slow")
+	fields = map[string]interface{}{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("2024-01-31 12:00:00.123", fields["time"])
+	ls.Equal("warning", fields["severity"])
+
+	ls.Equal(BasicJSONFormatter, NewJSONFormatter(JSONFormat{}), "Empty format should be the basic format")
+}
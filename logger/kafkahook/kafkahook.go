@@ -0,0 +1,231 @@
+// Package kafkahook provides a logger.Shipper which publishes the entries of the Common Logger to a Kafka topic.
+// Use FromConfiguration with logger.WithShippers to publish to the APP_LOG_KAFKA_TOPIC
+// Use New to create the Hook with custom settings
+package kafkahook
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// QueuePolicy defines how the Hook handles the entries while its queue is full.
+type QueuePolicy string
+
+const (
+	// Drop drops the entries while the queue is full, so the logging never waits for Kafka.
+	Drop QueuePolicy = "drop"
+
+	// Block blocks the logging until the queue has room, so no entry is lost while Kafka is slow.
+	Block QueuePolicy = "block"
+)
+
+// ValidQueuePolicies are the valid queue policies of the Hook. Used in validation.
+var ValidQueuePolicies = []interface{}{
+	string(Drop),
+	string(Block),
+}
+
+// DefaultQueueSize is the number of entries queued by the Hook if no queue size is set.
+const DefaultQueueSize = 10000
+
+// batchSize is the maximum number of entries published at once.
+const batchSize = 100
+
+// timeout is the timeout of publishing a batch, including the retries of the writer.
+const timeout = time.Minute
+
+// messageWriter is the part of the kafka.Writer used by the Hook.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// Hook is a logger.Shipper which publishes the entries as JSON messages to a Kafka topic, keyed by the service
+// name so the entries of a service keep their order in a partition. The entries are queued and published
+// asynchronously in batches, the QueuePolicy defines what happens while the queue is full.
+// Close the hook to publish the queued entries on shutdown.
+type Hook struct {
+	// Topic is the Kafka topic of the entries.
+	Topic string
+
+	key    []byte
+	policy QueuePolicy
+	writer messageWriter
+	queue  chan kafka.Message
+	done   chan struct{}
+
+	// mu guards the queue against the Close, the Ship holds the read lock while queuing
+	mu     sync.RWMutex
+	closed bool
+
+	// pendingMu guards the number of the queued and the publishing entries, see Flush
+	pendingMu sync.Mutex
+	pending   int
+	dropped   int
+	published *sync.Cond
+}
+
+// New creates a new Hook which publishes the entries to topic on the brokers (host:port), with key
+// as the message key, e.g. the service name. queueSize is the number of queued entries (DefaultQueueSize if
+// zero), policy the handling of the entries while the queue is full (Drop if empty).
+func New(brokers []string, topic, key string, queueSize int, policy QueuePolicy) (*Hook, error) {
+	if len(brokers) == 0 || topic == "" {
+		return nil, errors.New("Kafka brokers and topic must be set")
+	}
+	writer := &kafka.Writer{
+		Addr:         kafka.TCP(brokers...),
+		Topic:        topic,
+		Balancer:     &kafka.Hash{},
+		BatchSize:    batchSize,
+		BatchTimeout: 10 * time.Millisecond,
+		RequiredAcks: kafka.RequireOne,
+	}
+	return newHook(writer, topic, key, queueSize, policy)
+}
+
+// newHook creates a new Hook publishing by writer, and starts publishing the queued entries.
+func newHook(writer messageWriter, topic, key string, queueSize int, policy QueuePolicy) (*Hook, error) {
+	if policy == "" {
+		policy = Drop
+	}
+	if policy != Drop && policy != Block {
+		return nil, errors.Errorf("Invalid Kafka queue policy %s", policy)
+	}
+	if queueSize <= 0 {
+		queueSize = DefaultQueueSize
+	}
+	hook := &Hook{
+		Topic:  topic,
+		key:    []byte(key),
+		policy: policy,
+		writer: writer,
+		queue:  make(chan kafka.Message, queueSize),
+		done:   make(chan struct{}),
+	}
+	hook.published = sync.NewCond(&hook.pendingMu)
+	go hook.run()
+	return hook, nil
+}
+
+// Levels implements the logger.Shipper interface, every level is shipped.
+func (hook *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Ship implements the logger.Shipper interface, the entry is queued to be published. While the queue is full the
+// entry is dropped with an error (Drop), or Ship waits for room in the queue (Block).
+func (hook *Hook) Ship(entry *logrus.Entry, encoded []byte) error {
+	message := kafka.Message{Key: hook.key, Value: encoded, Time: entry.Time}
+
+	hook.mu.RLock()
+	defer hook.mu.RUnlock()
+	if hook.closed {
+		return errors.New("Kafka hook is closed")
+	}
+	hook.addPending(1)
+	if hook.policy == Block {
+		hook.queue <- message
+		return nil
+	}
+	select {
+	case hook.queue <- message:
+		return nil
+	default:
+		hook.pendingMu.Lock()
+		hook.dropped++
+		dropped := hook.dropped
+		hook.pendingMu.Unlock()
+		hook.addPending(-1)
+		return errors.Errorf("Kafka queue is full, %d entries dropped", dropped)
+	}
+}
+
+// Flush waits until the queued entries are published, it is called by Logger.Sync.
+func (hook *Hook) Flush() error {
+	hook.pendingMu.Lock()
+	defer hook.pendingMu.Unlock()
+	for hook.pending > 0 {
+		hook.published.Wait()
+	}
+	return nil
+}
+
+// Close publishes the queued entries and closes the connections to the brokers.
+func (hook *Hook) Close() error {
+	hook.mu.Lock()
+	if hook.closed {
+		hook.mu.Unlock()
+		return nil
+	}
+	hook.closed = true
+	close(hook.queue)
+	hook.mu.Unlock()
+
+	<-hook.done
+	return errors.Wrap(hook.writer.Close(), "Failed to close the Kafka writer")
+}
+
+// addPending adds n to the number of the pending entries.
+func (hook *Hook) addPending(n int) {
+	hook.pendingMu.Lock()
+	defer hook.pendingMu.Unlock()
+	hook.pending += n
+	if hook.pending == 0 {
+		hook.published.Broadcast()
+	}
+}
+
+// run publishes the queued entries in batches until the queue is closed.
+func (hook *Hook) run() {
+	defer close(hook.done)
+	batch := make([]kafka.Message, 0, batchSize)
+	for message := range hook.queue {
+		batch = append(batch[:0], message)
+	drain:
+		for len(batch) < batchSize {
+			select {
+			case message, ok := <-hook.queue:
+				if !ok {
+					break drain
+				}
+				batch = append(batch, message)
+			default:
+				break drain
+			}
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		if err := hook.writer.WriteMessages(ctx, batch...); err != nil {
+			logrus.StandardLogger().WithError(err).Errorf("Failed to publish %d entries to Kafka topic %s", len(batch), hook.Topic)
+		}
+		cancel()
+		hook.addPending(-len(batch))
+	}
+}
+
+// FromConfiguration is a logger.ShipperFactory which creates the Hook of the Common Logger publishing to the topic
+// of APP_LOG_KAFKA_TOPIC on the comma separated APP_LOG_KAFKA_BROKERS, keyed by the service name, with
+// APP_LOG_KAFKA_QUEUE_SIZE queued entries handled by the APP_LOG_KAFKA_QUEUE_POLICY (drop if not set).
+// No Hook is created if the brokers are not set.
+func FromConfiguration(serviceName, _ string, conf logger.ConfigGetter) (logger.Shipper, error) {
+	brokers := conf.Get(constants.APP_LOG_KAFKA_BROKERS)
+	if brokers == "" {
+		return nil, nil
+	}
+	queueSize, _ := strconv.Atoi(conf.Get(constants.APP_LOG_KAFKA_QUEUE_SIZE))
+	policy := QueuePolicy(conf.Get(constants.APP_LOG_KAFKA_QUEUE_POLICY))
+	hook, err := New(strings.Split(brokers, ","), conf.Get(constants.APP_LOG_KAFKA_TOPIC), serviceName, queueSize, policy)
+	if err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
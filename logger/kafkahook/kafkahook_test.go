@@ -0,0 +1,139 @@
+package kafkahook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// KafkaHookSuite extends testify's Suite.
+type KafkaHookSuite struct {
+	suite.Suite
+}
+
+// fakeKafkaWriter records the published messages, the writes wait while the writer is paused
+type fakeKafkaWriter struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+	batches  int
+	paused   chan struct{}
+	closed   bool
+}
+
+func (f *fakeKafkaWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if f.paused != nil {
+		<-f.paused
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.batches++
+	f.messages = append(f.messages, msgs...)
+	return nil
+}
+
+func (f *fakeKafkaWriter) Close() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.closed = true
+	return nil
+}
+
+// ship ships the entry to the hook, encoded by logger.BasicJSONFormatter.
+func ship(hook logger.Shipper, entry *logrus.Entry) error {
+	encoded, err := logger.BasicJSONFormatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	return hook.Ship(entry, bytes.TrimSuffix(encoded, []byte("\n")))
+}
+
+func (ks *KafkaHookSuite) TestHook() {
+	writer := &fakeKafkaWriter{paused: make(chan struct{})}
+	hook, err := newHook(writer, "audit", "test-service", 0, Block)
+	ks.Require().NoError(err)
+	log := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FILE: filepath.Join(ks.T().TempDir(), "app.log"),
+	}, logger.WithShippers(func(string, string, logger.ConfigGetter) (logger.Shipper, error) {
+		return hook, nil
+	}))
+
+	for i := 0; i < 150; i++ {
+		log.WithField("order", i).Info("Order created")
+	}
+	close(writer.paused)
+	ks.NoError(log.Sync(), "Sync should wait for the queued entries")
+
+	writer.mu.Lock()
+	ks.Require().Len(writer.messages, 150)
+	ks.GreaterOrEqual(writer.batches, 2, "Entries should be published in batches")
+	ks.Equal("test-service", string(writer.messages[0].Key), "Entries should be keyed by the service name")
+	message := map[string]interface{}{}
+	ks.NoError(json.Unmarshal(writer.messages[149].Value, &message))
+	ks.Equal("Order created", message["msg"])
+	ks.Equal(float64(149), message["order"], "Entries should be published in order")
+	writer.mu.Unlock()
+
+	ks.NoError(log.Close())
+	ks.True(writer.closed)
+	ks.EqualError(ship(hook, &logrus.Entry{Data: logrus.Fields{}}), "Kafka hook is closed")
+}
+
+func (ks *KafkaHookSuite) TestDrop() {
+	writer := &fakeKafkaWriter{paused: make(chan struct{})}
+	hook, err := newHook(writer, "audit", "test-service", 2, "")
+	ks.Require().NoError(err)
+	defer hook.Close()
+
+	entry := &logrus.Entry{Level: logrus.InfoLevel, Message: "Queued", Data: logrus.Fields{}}
+	ks.NoError(ship(hook, entry))
+	// The first entry is taken from the queue by the paused publisher
+	ks.Eventually(func() bool {
+		return len(hook.queue) == 0
+	}, 5*time.Second, time.Millisecond)
+	ks.NoError(ship(hook, entry))
+	ks.NoError(ship(hook, entry))
+	ks.EqualError(ship(hook, entry), "Kafka queue is full, 1 entries dropped", "Entries should be dropped while the queue is full")
+	close(writer.paused)
+	ks.NoError(hook.Flush())
+	ks.Len(writer.messages, 3)
+
+	_, err = newHook(writer, "audit", "test-service", 0, "wait")
+	ks.EqualError(err, "Invalid Kafka queue policy wait")
+	_, err = New(nil, "audit", "test-service", 0, Drop)
+	ks.EqualError(err, "Kafka brokers and topic must be set")
+}
+
+func (ks *KafkaHookSuite) TestFromConfiguration() {
+	shipper, err := FromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:                    constants.ENV_TEST,
+		constants.APP_LOG_KAFKA_BROKERS:      "127.0.0.1:1",
+		constants.APP_LOG_KAFKA_TOPIC:        "audit",
+		constants.APP_LOG_KAFKA_QUEUE_POLICY: "block",
+	})
+	ks.Require().NoError(err)
+	hook, ok := shipper.(*Hook)
+	ks.Require().True(ok, "Kafka hook should be created by APP_LOG_KAFKA_BROKERS")
+	ks.Equal("audit", hook.Topic)
+	ks.Equal(Block, hook.policy)
+	ks.Equal(DefaultQueueSize, cap(hook.queue))
+	ks.NoError(hook.Close())
+
+	shipper, err = FromConfiguration("test-service", "v1.2.3", config.StaticGetter{})
+	ks.NoError(err)
+	ks.Nil(shipper, "No hook should be created without brokers")
+}
+
+func TestKafkaHook(t *testing.T) {
+	suite.Run(t, new(KafkaHookSuite))
+}
@@ -0,0 +1,90 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/sirupsen/logrus"
+	gormLog "gorm.io/gorm/logger"
+)
+
+// levelBody is the request and response body of the LevelHandler.
+type levelBody struct {
+	Level string `json:"level"`
+}
+
+// logrusLogger returns the Logrus Logger writing the entries of the Logger, nil if it is not a Logrus FieldLogger.
+func (l *Logger) logrusLogger() *logrus.Logger {
+	switch log := l.log.(type) {
+	case *logrus.Logger:
+		return log
+	case *logrus.Entry:
+		return log.Logger
+	}
+	return nil
+}
+
+// Level returns the current log level of the Logger.
+func (l *Logger) Level() logrus.Level {
+	if log := l.logrusLogger(); log != nil {
+		return log.GetLevel()
+	}
+	return logrus.InfoLevel
+}
+
+// SetLevel changes the log level of the Logger at runtime, it is safe to call while logging.
+// The level is shared by the component loggers of the Logger, the GORM loggers created afterwards
+// get the matching GORM log level.
+func (l *Logger) SetLevel(level logrus.Level) {
+	if log := l.logrusLogger(); log != nil {
+		log.SetLevel(level)
+	}
+	l.gormMu.Lock()
+	l.gormConf.LogLevel = gormLogLevel(level)
+	l.gormMu.Unlock()
+}
+
+// gormLogLevel returns the GORM log level of a Logrus level.
+func gormLogLevel(level logrus.Level) gormLog.LogLevel {
+	switch level {
+	case logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel:
+		return gormLog.Error
+	case logrus.WarnLevel:
+		return gormLog.Warn
+	}
+	return gormLog.Info
+}
+
+// LevelHandler returns an http.Handler to inspect and change the log level at runtime, so a production
+// incident can be debugged without a redeploy. GET responds the current level as {"level":"info"},
+// PUT sets the level of the same JSON body. It is meant for an internal admin port,
+// e.g. mux.Handle("/loglevel", commonLog.LevelHandler()).
+func (l *Logger) LevelHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+		case http.MethodPut:
+			body := levelBody{}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				http.Error(w, "Invalid request body: "+err.Error(), http.StatusBadRequest)
+				return
+			}
+			level, err := logrus.ParseLevel(body.Level)
+			if err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+			previous := l.Level()
+			l.SetLevel(level)
+			l.WithFields(logrus.Fields{"previous": previous.String(), "new_level": level.String()}).Warn("Log level changed")
+		default:
+			w.Header().Set("Allow", "GET, HEAD, PUT")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		// The response is already started, an encoding error cannot be reported to the client
+		_ = json.NewEncoder(w).Encode(levelBody{Level: l.Level().String()})
+	})
+}
@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	gormLog "gorm.io/gorm/logger"
+)
+
+func (ls *LoggerSuite) TestSetLevel() {
+	l, hook := logrusTest.NewNullLogger()
+	log := NewLogger(l, logrus.Fields{"service": "test-service"})
+	componentLog := log.NewComponentLogger("worker")
+
+	log.SetLevel(logrus.WarnLevel)
+	ls.Equal(logrus.WarnLevel, componentLog.Level(), "The level should be shared by the component loggers")
+	ls.Equal(gormLog.Warn, log.gormConf.LogLevel)
+	componentLog.Entry().Info("Filtered")
+	ls.Nil(hook.LastEntry())
+
+	wg := sync.WaitGroup{}
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				componentLog.Entry().Debug("Concurrent")
+			}
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		_ = log.NewGormLogger("database")
+	}()
+	log.SetLevel(logrus.DebugLevel)
+	wg.Wait()
+	log.Entry().Debug("Not filtered")
+	ls.Equal("Not filtered", hook.LastEntry().Message)
+}
+
+func (ls *LoggerSuite) TestLevelHandler() {
+	l, hook := logrusTest.NewNullLogger()
+	l.SetLevel(logrus.InfoLevel)
+	log := NewLogger(l, logrus.Fields{"service": "test-service"})
+	handler := log.LevelHandler()
+
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	ls.Equal(http.StatusOK, recorder.Code)
+	ls.Equal("application/json", recorder.Header().Get("Content-Type"))
+	ls.JSONEq(`{"level":"info"}`, recorder.Body.String())
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"debug"}`)))
+	ls.Equal(http.StatusOK, recorder.Code)
+	ls.JSONEq(`{"level":"debug"}`, recorder.Body.String())
+	ls.Equal(logrus.DebugLevel, l.GetLevel())
+	ls.Equal("Log level changed", hook.LastEntry().Message, "The change should be logged")
+	ls.Equal("info", hook.LastEntry().Data["previous"])
+	ls.Equal("debug", hook.LastEntry().Data["new_level"])
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`{"level":"loud"}`)))
+	ls.Equal(http.StatusBadRequest, recorder.Code)
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(`debug`)))
+	ls.Equal(http.StatusBadRequest, recorder.Code)
+	ls.Equal(logrus.DebugLevel, l.GetLevel(), "Invalid requests should not change the level")
+
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodPost, "/loglevel", nil))
+	ls.Equal(http.StatusMethodNotAllowed, recorder.Code)
+	ls.Equal("GET, HEAD, PUT", recorder.Header().Get("Allow"))
+
+	body := map[string]string{}
+	recorder = httptest.NewRecorder()
+	handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/loglevel", nil))
+	ls.NoError(json.Unmarshal(recorder.Body.Bytes(), &body))
+	ls.Equal("debug", body["level"])
+}
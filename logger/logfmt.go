@@ -0,0 +1,112 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/sirupsen/logrus"
+)
+
+// LogfmtFormatter is a logrus.Formatter which formats the entries as logfmt lines: the time, level and msg keys,
+// then the fields of the entries sorted by key, e.g. time=2024-01-31T12:00:00Z level=info msg="Order paid"
+// order_id=42. The values are quoted if they contain spaces, quotes, equal signs or control characters.
+type LogfmtFormatter struct {
+	// TimestampFormat is the layout of the time key, time.RFC3339 if empty.
+	TimestampFormat string
+}
+
+// Format implements the logrus.Formatter interface.
+func (f *LogfmtFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	layout := f.TimestampFormat
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var buffer bytes.Buffer
+	writeLogfmtPair(&buffer, logrus.FieldKeyTime, timestamp.Format(layout))
+	writeLogfmtPair(&buffer, logrus.FieldKeyLevel, entry.Level.String())
+	writeLogfmtPair(&buffer, logrus.FieldKeyMsg, entry.Message)
+	if entry.HasCaller() {
+		writeLogfmtPair(&buffer, logrus.FieldKeyFunc, entry.Caller.Function)
+		writeLogfmtPair(&buffer, logrus.FieldKeyFile, fmt.Sprintf("%s:%d", entry.Caller.File, entry.Caller.Line))
+	}
+	for _, key := range keys {
+		switch key {
+		case logrus.FieldKeyTime, logrus.FieldKeyLevel, logrus.FieldKeyMsg:
+			// The same as the prefixed clashing fields of the Logrus formatters
+			writeLogfmtPair(&buffer, "fields."+key, logfmtValue(entry.Data[key]))
+		default:
+			writeLogfmtPair(&buffer, key, logfmtValue(entry.Data[key]))
+		}
+	}
+	buffer.WriteByte('\n')
+	return buffer.Bytes(), nil
+}
+
+// writeLogfmtPair writes a key=value pair, separated by a space from the previous pair.
+func writeLogfmtPair(buffer *bytes.Buffer, key, value string) {
+	if buffer.Len() > 0 {
+		buffer.WriteByte(' ')
+	}
+	buffer.WriteString(logfmtKey(key))
+	buffer.WriteByte('=')
+	if logfmtNeedsQuoting(value) {
+		buffer.WriteString(strconv.Quote(value))
+	} else {
+		buffer.WriteString(value)
+	}
+}
+
+// logfmtKey replaces the characters not allowed in the keys with underscores.
+func logfmtKey(key string) string {
+	key = strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || r == unicode.ReplacementChar || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, key)
+	if key == "" {
+		return "_"
+	}
+	return key
+}
+
+// logfmtValue returns the string of a field value, the message of the errors.
+func logfmtValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case error:
+		return value.Error()
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	}
+	return fmt.Sprint(value)
+}
+
+// logfmtNeedsQuoting returns whether the value must be quoted.
+func logfmtNeedsQuoting(value string) bool {
+	if value == "" {
+		return true
+	}
+	for _, r := range value {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == unicode.ReplacementChar || unicode.IsControl(r) {
+			return true
+		}
+	}
+	return false
+}
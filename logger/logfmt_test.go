@@ -0,0 +1,28 @@
+package logger
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestLogfmtFormatter() {
+	output := &strings.Builder{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:        constants.ENV_TEST,
+		constants.APP_LOG_FORMAT: constants.LOG_FORMAT_LOGFMT,
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	timestamp := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	commonLog.WithError(errors.New("connection refused")).WithTime(timestamp).
+		WithFields(logrus.Fields{"order_id": 42, "note": `said "hi"`, "empty": "", "level": "high", "bad key": "x=y"}).
+		Error("Payment failed")
+
+	ls.Equal(`time=2024-01-31T12:00:00Z level=error msg="Payment failed" bad_key="x=y" empty="" `+
+		`env=test error="connection refused" host=`+config.StaticGetter{}.Hostname()+
+		` fields.level=high note="said \"hi\"" order_id=42 service=test-service version=v1.2.3`+"\n", output.String())
+}
@@ -1,15 +1,18 @@
 // Package logger provides a wrapper around Sirupsen's Logrus with mandatory default fields
-// Use the NewCommonLogger constructor to create your application's logger
+// Use the NewCommonLoggerFromConfiguration constructor to create your application's logger
 // Use the NewComponentLogger method to create child loggers for components of your application
 // Use Entry WithField WithFields and WithError to create new log entries
+// Use WithContext to create log entries with a context, e.g. for the trace_id and span_id of otelhook
 package logger
 
 import (
 	"fmt"
+	"io"
 	"os"
 	"regexp"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -39,7 +42,12 @@ type Logger struct {
 	log           logrus.FieldLogger
 	defaultFields logrus.Fields
 	formatErrors  bool
+	gormMu        sync.RWMutex
 	gormConf      *gormLog.Config
+	backend       Backend
+	dedup         *deduplicator
+	shippers      []Shipper
+	logFile       *os.File
 }
 
 // NewLogger creates a new logger instance with the supplied Logrus FieldLogger and default fields
@@ -81,61 +89,261 @@ func isFormatErrors() bool {
 // semantic version (+ commit hash)
 // environment
 // host (EC2 Identifier)
+// The legacy LOG_LEVEL, LOG_DEV and LOG_FORMAT_ERRORS environment variables are translated to their APP_*
+// counterparts, and the logger is created by NewCommonLoggerFromConfiguration.
+// Services without an AppConfig can switch to NewCommonLoggerFromConfiguration with a config.StaticGetter.
 func NewCommonLogger(service, version, env, host string, debug bool) *Logger {
-	log := logrus.New()
-	log.SetLevel(getLogLevel(debug))
-	log.SetOutput(os.Stdout)
-	log.SetReportCaller(debug)
-	if isDevLog() {
-		log.SetFormatter(BasicTextFormatter)
-	} else {
-		log.SetFormatter(BasicJSONFormatter)
-	}
-	return NewLogger(log, logrus.Fields{
-		"service": service,
-		"version": version,
-		"env":     env,
-		"host":    host,
+	return NewCommonLoggerFromConfiguration(service, version, legacyConfig{
+		values: map[string]string{
+			constants.APP_DEBUG:             strconv.FormatBool(debug),
+			constants.APP_LOG_LEVEL:         getLogLevel(debug).String(),
+			constants.APP_LOG_DEV:           strconv.FormatBool(isDevLog()),
+			constants.APP_LOG_FORMAT_ERRORS: strconv.FormatBool(isFormatErrors()),
+			constants.APP_ENV:               env,
+		},
+		host: host,
 	})
 }
 
-// configGetter is a structure that can get config items by name and tell the hostname
-type configGetter interface {
+// ConfigGetter is a structure that can get config items by name and tell the hostname, e.g. a config.AppConfig
+// or a config.StaticGetter.
+type ConfigGetter interface {
 	Get(string) string
 	Hostname() string
 }
 
+// legacyConfig is the ConfigGetter of NewCommonLogger built from its arguments.
+type legacyConfig struct {
+	values map[string]string
+	host   string
+}
+
+// Get implements the ConfigGetter interface.
+func (c legacyConfig) Get(name string) string {
+	return c.values[name]
+}
+
+// Hostname implements the ConfigGetter interface.
+func (c legacyConfig) Hostname() string {
+	return c.host
+}
+
 // NewCommonLoggerFromConfiguration is the prefferred way to create the Common Logger
-func NewCommonLoggerFromConfiguration(serviceName, serviceVersion string, config configGetter) *Logger {
+// Without an AppConfig use a config.StaticGetter as conf.
+// The entries are written to APP_LOG_FILE if set (and can be opened), to stdout otherwise.
+// The entries are formatted by Logrus, unless an other Backend is selected by WithBackend. The format is JSON,
+// text if APP_LOG_DEV is enabled, or the APP_LOG_FORMAT if set (see constants.ValidLogFormats). The traces of the
+// gcp format are linked to the Cloud Trace of the APP_LOG_GCP_PROJECT, see GCPFormatter. The keys of the JSON
+// entries are set by APP_LOG_TIME_KEY, APP_LOG_LEVEL_KEY and APP_LOG_MESSAGE_KEY, the timestamp format by
+// APP_LOG_TIMESTAMP_FORMAT (a time layout, or epoch or epoch_millis), see WithJSONFormat.
+// The timestamps are in UTC if APP_LOG_UTC is enabled, in the timezone of the host otherwise, see UTCHook.
+// The callers reported by APP_DEBUG are relative to the module if APP_LOG_CALLER is relative, see RelativeCaller.
+// The entries are sampled if APP_LOG_SAMPLING_INITIAL is set: the first N entries of every level and message
+// are logged per second, then every Mth set by APP_LOG_SAMPLING_THEREAFTER (none if not set).
+// The duplicate entries are collapsed within the APP_LOG_DEDUP_WINDOW duration if set, see RepeatedField.
+// The personal data is masked if APP_LOG_MASK_PII is enabled, see PIIMaskingFormatter.
+// The Logrus hooks of WithHooks are fired on the entries, e.g. the otelhook adding the trace fields.
+// The Datadog correlation fields are added if APP_LOG_DATADOG is enabled, see DatadogHook.
+// The entries are also sent to the syslog server of APP_LOG_SYSLOG_ADDR if set (and reachable), see NewSyslogHook.
+// The entries are also pushed to the Loki server of APP_LOG_LOKI_URL if set, with the comma separated fields of
+// APP_LOG_LOKI_LABELS (DefaultLokiLabels if not set) as labels, see LokiHook.
+// The entries are also indexed into the daily indices of APP_LOG_ELASTICSEARCH_INDEX (logs-service if not set) on
+// the Elasticsearch or OpenSearch cluster of APP_LOG_ELASTICSEARCH_URL if set, in bulk requests of
+// APP_LOG_ELASTICSEARCH_BUFFER_SIZE entries every APP_LOG_ELASTICSEARCH_FLUSH_INTERVAL, see ElasticsearchHook.
+// The entries are also sent to the Graylog GELF input of APP_LOG_GELF_ADDR if set, see GELFHook.
+// The entries are also shipped by the Shippers of WithShippers, e.g. to CloudWatch Logs, fluentd, Kafka or Sentry
+// by the FromConfiguration factories of the cloudwatchhook, fluentdhook, kafkahook and sentryhook packages. The
+// Shippers get the entries after the deduplication, the sampling and the PII masking, encoded as JSON with the
+// keys and the timestamp format above.
+// Without a log file, a Backend and an APP_LOG_FORMAT the entries are written to journald under systemd, see
+// JournaldBackend.
+func NewCommonLoggerFromConfiguration(serviceName, serviceVersion string, conf ConfigGetter, opts ...LoggerOption) *Logger {
+	options := &loggerOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+
 	log := logrus.New()
-	log.SetOutput(os.Stdout)
+	var output io.Writer = os.Stdout
+	var logFileErr error
+	if filename := conf.Get(constants.APP_LOG_FILE); filename != "" {
+		file, err := os.OpenFile(filename, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			logFileErr = errors.Wrapf(err, "Failed to open log file %s", filename)
+		} else {
+			output = file
+		}
+	}
+	log.SetOutput(output)
 
-	ok, _ := strconv.ParseBool(config.Get(constants.APP_DEBUG))
+	ok, _ := strconv.ParseBool(conf.Get(constants.APP_DEBUG))
 	log.SetReportCaller(ok)
 
-	level, err := logrus.ParseLevel(config.Get(constants.APP_LOG_LEVEL))
+	level, err := logrus.ParseLevel(conf.Get(constants.APP_LOG_LEVEL))
 	if err != nil {
 		level = logrus.InfoLevel
 	}
 	log.SetLevel(level)
 
+	devLog, _ := strconv.ParseBool(conf.Get(constants.APP_LOG_DEV))
 	log.SetFormatter(BasicJSONFormatter)
-	if ok, _ := strconv.ParseBool(config.Get(constants.APP_LOG_DEV)); ok {
+	if devLog {
+		log.SetFormatter(BasicTextFormatter)
+	}
+	switch conf.Get(constants.APP_LOG_FORMAT) {
+	case constants.LOG_FORMAT_JSON:
+		log.SetFormatter(BasicJSONFormatter)
+	case constants.LOG_FORMAT_TEXT:
 		log.SetFormatter(BasicTextFormatter)
+	case constants.LOG_FORMAT_GELF:
+		log.SetFormatter(&GELFFormatter{Host: conf.Hostname()})
+	case constants.LOG_FORMAT_GCP:
+		log.SetFormatter(&GCPFormatter{ProjectID: conf.Get(constants.APP_LOG_GCP_PROJECT)})
+	case constants.LOG_FORMAT_LOGFMT:
+		log.SetFormatter(&LogfmtFormatter{})
+	case constants.LOG_FORMAT_PRETTY:
+		log.SetFormatter(&PrettyFormatter{DisableColors: !isTerminal(output)})
+	}
+	jsonFormatter := NewJSONFormatter(JSONFormat{
+		TimeKey:         conf.Get(constants.APP_LOG_TIME_KEY),
+		LevelKey:        conf.Get(constants.APP_LOG_LEVEL_KEY),
+		MessageKey:      conf.Get(constants.APP_LOG_MESSAGE_KEY),
+		TimestampFormat: conf.Get(constants.APP_LOG_TIMESTAMP_FORMAT),
+	}.merge(options.jsonFormat))
+	if log.Formatter == BasicJSONFormatter {
+		log.SetFormatter(jsonFormatter)
+	}
+
+	if ok, _ := strconv.ParseBool(conf.Get(constants.APP_LOG_UTC)); ok || options.utc {
+		log.AddHook(NewUTCHook())
+	}
+	if options.callerFormatter == nil {
+		options.callerFormatter = callerFormatterFromConfiguration(conf.Get(constants.APP_LOG_CALLER))
+	}
+	if options.callerFormatter != nil {
+		log.AddHook(NewCallerHook(options.callerFormatter))
+	}
+	log.AddHook(NewRedactionHook())
+	for _, hook := range options.hooks {
+		log.AddHook(hook)
+	}
+	if ok, _ := strconv.ParseBool(conf.Get(constants.APP_LOG_DATADOG)); ok || options.datadog {
+		log.AddHook(NewDatadogHook())
+	}
+	if ok, _ := strconv.ParseBool(conf.Get(constants.APP_LOG_SCAN_SECRETS)); ok {
+		log.AddHook(NewSensitiveDataHook())
+	}
+	shippers := []Shipper{}
+	var syslogErr error
+	if addr := conf.Get(constants.APP_LOG_SYSLOG_ADDR); addr != "" {
+		if hook, err := NewSyslogHook(addr, serviceName, options.syslogTLSConfig); err != nil {
+			syslogErr = err
+		} else {
+			shippers = append(shippers, hook)
+		}
+	}
+
+	var lokiErr error
+	if lokiURL := conf.Get(constants.APP_LOG_LOKI_URL); lokiURL != "" {
+		if hook, err := NewLokiHook(lokiURL, lokiLabelsFromConfiguration(conf.Get(constants.APP_LOG_LOKI_LABELS)), 0); err != nil {
+			lokiErr = err
+		} else {
+			shippers = append(shippers, hook)
+		}
+	}
+	var elasticsearchErr error
+	if elasticsearchURL := conf.Get(constants.APP_LOG_ELASTICSEARCH_URL); elasticsearchURL != "" {
+		index := conf.Get(constants.APP_LOG_ELASTICSEARCH_INDEX)
+		if index == "" {
+			index = "logs-" + strings.ToLower(serviceName)
+		}
+		bufferSize, _ := strconv.Atoi(conf.Get(constants.APP_LOG_ELASTICSEARCH_BUFFER_SIZE))
+		flushInterval, _ := time.ParseDuration(conf.Get(constants.APP_LOG_ELASTICSEARCH_FLUSH_INTERVAL))
+		if hook, err := NewElasticsearchHook(elasticsearchURL, index, bufferSize, flushInterval); err != nil {
+			elasticsearchErr = err
+		} else {
+			if output == os.Stdout {
+				// The entries are written to stdout anyway
+				hook.Fallback = nil
+			}
+			shippers = append(shippers, hook)
+		}
+	}
+	var gelfErr error
+	if addr := conf.Get(constants.APP_LOG_GELF_ADDR); addr != "" {
+		if hook, err := NewGELFHook(addr, conf.Hostname()); err != nil {
+			gelfErr = err
+		} else {
+			shippers = append(shippers, hook)
+		}
+	}
+
+	var shipperErrs []error
+	for _, factory := range options.shipperFactories {
+		if shipper, err := factory(serviceName, serviceVersion, conf); err != nil {
+			shipperErrs = append(shipperErrs, err)
+		} else if shipper != nil {
+			shippers = append(shippers, shipper)
+		}
 	}
 
 	commonLog := NewLogger(log, logrus.Fields{
 		"service": serviceName,
 		"version": serviceVersion,
-		"env":     config.Get(constants.APP_ENV),
-		"host":    config.Hostname(),
+		"env":     conf.Get(constants.APP_ENV),
+		"host":    conf.Hostname(),
 	})
+	if formatErrors, err := strconv.ParseBool(conf.Get(constants.APP_LOG_FORMAT_ERRORS)); err == nil {
+		commonLog.formatErrors = formatErrors
+	}
+	filters := []entryFilter{}
+	if window, err := time.ParseDuration(conf.Get(constants.APP_LOG_DEDUP_WINDOW)); err == nil && window > 0 {
+		commonLog.dedup = newDeduplicator(window)
+		filters = append(filters, commonLog.dedup)
+	}
+	if sampler := samplerFromConfiguration(conf.Get(constants.APP_LOG_SAMPLING_INITIAL), conf.Get(constants.APP_LOG_SAMPLING_THEREAFTER)); sampler != nil {
+		filters = append(filters, sampler)
+	}
+	if ok, _ := strconv.ParseBool(conf.Get(constants.APP_LOG_MASK_PII)); ok {
+		filters = append(filters, NewPIIMaskingFormatter(log.Formatter, options.piiPatterns...))
+	}
+	commonLog.shippers = shippers
+	if file, ok := output.(*os.File); ok && file != os.Stdout {
+		commonLog.logFile = file
+	}
+	if options.backend == nil && output == os.Stdout && useJournald(conf.Get(constants.APP_LOG_JOURNALD), conf.Get(constants.APP_LOG_FORMAT)) {
+		options.backend = JournaldBackend
+	}
+	if options.backend != nil {
+		commonLog.backend = options.backend(output, devLog)
+		if len(filters) > 0 || len(shippers) > 0 {
+			useBackend(log, &filteringBackend{Backend: commonLog.backend, pipeline: newPipeline(filters, jsonFormatter, shippers)})
+		} else {
+			useBackend(log, commonLog.backend)
+		}
+	} else if len(filters) > 0 || len(shippers) > 0 {
+		log.SetFormatter(&filteringFormatter{pipeline: newPipeline(filters, jsonFormatter, shippers), formatter: log.Formatter})
+	}
 
-	switch level {
-	case logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel:
-		commonLog.gormConf.LogLevel = gormLog.Error
-	case logrus.WarnLevel:
-		commonLog.gormConf.LogLevel = gormLog.Warn
+	commonLog.gormConf.LogLevel = gormLogLevel(level)
+
+	if logFileErr != nil {
+		commonLog.WithError(logFileErr).Warn("Logging to stdout instead of the log file")
+	}
+	if syslogErr != nil {
+		commonLog.WithError(syslogErr).Warn("Logging without syslog")
+	}
+	if lokiErr != nil {
+		commonLog.WithError(lokiErr).Warn("Logging without Loki")
+	}
+	if elasticsearchErr != nil {
+		commonLog.WithError(elasticsearchErr).Warn("Logging without Elasticsearch")
+	}
+	if gelfErr != nil {
+		commonLog.WithError(gelfErr).Warn("Logging without GELF")
+	}
+	for _, err := range shipperErrs {
+		commonLog.WithError(err).Warn("Logging without a shipper")
 	}
 
 	return commonLog
@@ -150,8 +358,15 @@ func (l *Logger) NewComponentLogger(componentName string) *Logger {
 	}
 	newFields["component"] = componentName
 	newLogger := NewLogger(l.log, newFields)
+	l.gormMu.RLock()
 	newLogger.gormConf.SlowThreshold = l.gormConf.SlowThreshold
 	newLogger.gormConf.LogLevel = l.gormConf.LogLevel
+	l.gormMu.RUnlock()
+	newLogger.formatErrors = l.formatErrors
+	newLogger.backend = l.backend
+	newLogger.dedup = l.dedup
+	newLogger.shippers = l.shippers
+	newLogger.logFile = l.logFile
 	return newLogger
 }
 
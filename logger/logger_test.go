@@ -2,7 +2,9 @@ package logger
 
 import (
 	"context"
+	"io/ioutil"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/pkg/errors"
@@ -11,6 +13,7 @@ import (
 	"github.com/stretchr/testify/suite"
 	"github.com/universal-devs/go-utilities/config"
 	"github.com/universal-devs/go-utilities/constants"
+	gormLog "gorm.io/gorm/logger"
 )
 
 // LoggerSuite extends testify's Suite.
@@ -74,6 +77,68 @@ func (ls *LoggerSuite) TestCreateCommonLogger() {
 	ls.Equal(fields, commonLog.defaultFields, "Default field should have been set")
 }
 
+func (ls *LoggerSuite) TestCommonLoggerShim() {
+	ls.NoError(os.Setenv("LOG_LEVEL", "error"), "LOG_LEVEL should have been set")
+	ls.NoError(os.Setenv("LOG_DEV", "true"), "LOG_DEV should have been set")
+	defer func() {
+		ls.NoError(os.Unsetenv("LOG_LEVEL"), "LOG_LEVEL should have been unset")
+		ls.NoError(os.Unsetenv("LOG_DEV"), "LOG_DEV should have been unset")
+	}()
+
+	legacy := NewCommonLogger("test-service", "v1.2.3", "test", "docker", false)
+	static := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_ERROR,
+		constants.APP_LOG_DEV:   "true",
+		constants.APP_ENV:       "test",
+		constants.EC2_ID:        "docker",
+	})
+	ls.Equal(static.defaultFields, legacy.defaultFields, "Default fields should match")
+	ls.Equal(static.gormConf, legacy.gormConf, "Gorm config should follow the log level on both paths")
+	ls.Equal(gormLog.Error, legacy.gormConf.LogLevel)
+	ls.Equal(logrus.ErrorLevel, legacy.log.(*logrus.Logger).GetLevel())
+	ls.Equal(BasicTextFormatter, legacy.log.(*logrus.Logger).Formatter, "LOG_DEV should select the text formatter")
+
+	debug := NewCommonLogger("test-service", "v1.2.3", "test", "docker", true)
+	ls.Equal(logrus.DebugLevel, debug.log.(*logrus.Logger).GetLevel(), "Debug should override LOG_LEVEL")
+	ls.True(debug.log.(*logrus.Logger).ReportCaller, "Debug should report the caller")
+}
+
+func (ls *LoggerSuite) TestFormatErrorsFromConfiguration() {
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FORMAT_ERRORS: "true",
+	})
+	ls.True(commonLog.formatErrors, "APP_LOG_FORMAT_ERRORS should enable error formatting")
+
+	ls.T().Setenv("LOG_FORMAT_ERRORS", "true")
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FORMAT_ERRORS: "false",
+	})
+	ls.False(commonLog.formatErrors, "APP_LOG_FORMAT_ERRORS should disable error formatting")
+
+	ls.T().Setenv("LOG_FORMAT_ERRORS", "false")
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FORMAT_ERRORS: "true",
+	})
+	parsed := commonLog.NewComponentLogger("worker").parseError(errors.Wrap(errors.New("connection refused"), "Failed to connect"))
+	ls.NotContains(parsed, "\n", "Component loggers should format the errors like their parent")
+	ls.Contains(parsed, " --- ")
+}
+
+func (ls *LoggerSuite) TestLogFile() {
+	filename := filepath.Join(ls.T().TempDir(), "app.log")
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FILE: filename,
+	})
+	commonLog.Entry().Info("Written to file")
+	content, err := ioutil.ReadFile(filename)
+	ls.NoError(err, "Log file should have been created")
+	ls.Contains(string(content), "Written to file")
+
+	ls.NoError(commonLog.NewComponentLogger("worker").Close())
+	ls.ErrorIs(commonLog.logFile.Close(), os.ErrClosed, "The log file should be closed with the Logger")
+	ls.NoError(commonLog.Close(), "Closing the Logger again should not fail")
+}
+
 func (ls *LoggerSuite) TestCreateComponentLogger() {
 	l := logrus.New()
 	fields := logrus.Fields{
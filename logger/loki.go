@@ -0,0 +1,276 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/logger/internal/batch"
+)
+
+// DefaultLokiFlushInterval is the interval of the batches pushed by the LokiHook if no interval is set.
+const DefaultLokiFlushInterval = time.Second
+
+// DefaultLokiLabels are the fields of the entries used as Loki labels if no labels are set. The other fields
+// stay in the JSON lines, as every distinct label value creates a new stream in Loki.
+var DefaultLokiLabels = []string{"service", "env", "level"}
+
+// lokiPushPath is the path of the push API, used if the URL of the LokiHook has no path.
+const lokiPushPath = "/loki/api/v1/push"
+
+// The limits of the batches of the LokiHook.
+const (
+	lokiMaxBatchEntries = 1000
+	lokiMaxBatchBytes   = 1048576
+	lokiMaxBuffered     = 100 * lokiMaxBatchEntries
+	lokiTimeout         = 30 * time.Second
+)
+
+// lokiEntry is a buffered entry of the LokiHook.
+type lokiEntry struct {
+	labels    map[string]string
+	timestamp time.Time
+	line      string
+}
+
+// lokiStream is a stream of a push request, the values are the nanosecond timestamps and the lines.
+type lokiStream struct {
+	Stream map[string]string `json:"stream"`
+	Values [][2]string       `json:"values"`
+}
+
+// LokiHook is a Shipper which pushes the entries as JSON lines to Grafana Loki. The allowed fields (and the
+// level) of the entries are the labels of the streams, see DefaultLokiLabels. The entries are buffered and pushed
+// in batches every flush interval, or as soon as a batch is full. The failed pushes are retried with an
+// exponential backoff, unless Loki rejects the batch.
+type LokiHook struct {
+	// URL is the push URL of Loki, e.g. http://loki:3100/loki/api/v1/push.
+	URL string
+
+	// Retries is the number of retries of a failed push.
+	Retries int
+
+	labels  []string
+	client  *http.Client
+	backoff time.Duration
+
+	// mu guards the buffered entries
+	mu      sync.Mutex
+	entries []lokiEntry
+	size    int
+	dropped int
+
+	// sendMu serializes the pushes
+	sendMu sync.Mutex
+
+	loop *batch.Loop
+}
+
+// NewLokiHook creates a new LokiHook which pushes the entries to the Loki server at rawURL every flushInterval
+// (DefaultLokiFlushInterval if zero). The push API path is added to the URL if it has no path, the credentials
+// of the URL are sent by basic authentication. labels are the fields used as labels, DefaultLokiLabels if
+// empty; the level label is always added if no labels are set in an entry. Close the hook to push the buffered
+// entries on shutdown.
+func NewLokiHook(rawURL string, labels []string, flushInterval time.Duration) (*LokiHook, error) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+		return nil, errors.Errorf("Invalid Loki URL %s", rawURL)
+	}
+	if parsed.Path == "" || parsed.Path == "/" {
+		parsed.Path = lokiPushPath
+	}
+	if len(labels) == 0 {
+		labels = DefaultLokiLabels
+	}
+	if flushInterval <= 0 {
+		flushInterval = DefaultLokiFlushInterval
+	}
+	hook := &LokiHook{
+		URL:     parsed.String(),
+		Retries: 5,
+		labels:  labels,
+		client:  &http.Client{Timeout: lokiTimeout},
+		backoff: 500 * time.Millisecond,
+	}
+	hook.loop = batch.Start("Loki", flushInterval, hook.Flush)
+	return hook, nil
+}
+
+// Levels implements the Shipper interface, every level is shipped.
+func (hook *LokiHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Ship implements the Shipper interface, the entry is buffered until the next batch.
+func (hook *LokiHook) Ship(entry *logrus.Entry, encoded []byte) error {
+	line := string(encoded)
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	if len(hook.entries) >= lokiMaxBuffered {
+		hook.dropped++
+		return errors.Errorf("Loki buffer is full, %d entries dropped", hook.dropped)
+	}
+	hook.entries = append(hook.entries, lokiEntry{labels: hook.entryLabels(entry), timestamp: timestamp, line: line})
+	hook.size += len(line)
+	if len(hook.entries) >= lokiMaxBatchEntries || hook.size >= lokiMaxBatchBytes {
+		hook.loop.Full()
+	}
+	return nil
+}
+
+// entryLabels returns the labels of the entry, the level label if the entry has none of the allowed fields.
+func (hook *LokiHook) entryLabels(entry *logrus.Entry) map[string]string {
+	labels := map[string]string{}
+	for _, name := range hook.labels {
+		var value string
+		if name == "level" {
+			value = entry.Level.String()
+		} else if field, ok := entry.Data[name]; ok {
+			value = fmt.Sprint(field)
+		}
+		if value != "" {
+			labels[lokiLabelName(name)] = value
+		}
+	}
+	if len(labels) == 0 {
+		labels["level"] = entry.Level.String()
+	}
+	return labels
+}
+
+// Flush pushes the buffered entries, it is called by Logger.Sync.
+func (hook *LokiHook) Flush() error {
+	hook.mu.Lock()
+	entries := hook.entries
+	hook.entries, hook.size, hook.dropped = nil, 0, 0
+	hook.mu.Unlock()
+
+	hook.sendMu.Lock()
+	defer hook.sendMu.Unlock()
+	for len(entries) > 0 {
+		n, size := 0, 0
+		for n < len(entries) && (n == 0 || size+len(entries[n].line) <= lokiMaxBatchBytes) {
+			size += len(entries[n].line)
+			n++
+		}
+		if err := hook.push(entries[:n]); err != nil {
+			return errors.Wrapf(err, "Failed to push %d entries to Loki", len(entries))
+		}
+		entries = entries[n:]
+	}
+	return nil
+}
+
+// Close stops the hook and pushes the buffered entries.
+func (hook *LokiHook) Close() error {
+	hook.loop.Stop()
+	return hook.Flush()
+}
+
+// push sends a batch of entries to Loki, grouped into streams by the labels. The caller must hold sendMu.
+func (hook *LokiHook) push(entries []lokiEntry) error {
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].timestamp.Before(entries[j].timestamp)
+	})
+	streams := map[string]*lokiStream{}
+	keys := []string{}
+	for _, entry := range entries {
+		key := lokiStreamKey(entry.labels)
+		stream, ok := streams[key]
+		if !ok {
+			stream = &lokiStream{Stream: entry.labels}
+			streams[key] = stream
+			keys = append(keys, key)
+		}
+		stream.Values = append(stream.Values, [2]string{strconv.FormatInt(entry.timestamp.UnixNano(), 10), entry.line})
+	}
+	sort.Strings(keys)
+	request := struct {
+		Streams []*lokiStream `json:"streams"`
+	}{}
+	for _, key := range keys {
+		request.Streams = append(request.Streams, streams[key])
+	}
+	body, err := json.Marshal(request)
+	if err != nil {
+		return errors.Wrap(err, "Failed to encode the Loki push request")
+	}
+
+	return batch.Retry(hook.Retries, hook.backoff, func() (bool, error) {
+		ctx, cancel := context.WithTimeout(context.Background(), lokiTimeout)
+		defer cancel()
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+		if err != nil {
+			return false, errors.Wrap(err, "Failed to create the Loki push request")
+		}
+		req.Header.Set("Content-Type", "application/json")
+		resp, err := hook.client.Do(req)
+		if err != nil {
+			return true, errors.Wrap(err, "Failed to push to Loki")
+		}
+		defer resp.Body.Close()
+		message, _ := ioutil.ReadAll(io.LimitReader(resp.Body, 512))
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			return false, nil
+		}
+		// Loki rejects the invalid batches with a 4xx status, e.g. the entries too old, those are not retried
+		retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+		return retryable, errors.Errorf("Loki responded %s: %s", resp.Status, strings.TrimSpace(string(message)))
+	})
+}
+
+// lokiStreamKey returns the key of the stream of the labels, the labels in the Prometheus format.
+func lokiStreamKey(labels map[string]string) string {
+	names := make([]string, 0, len(labels))
+	for name := range labels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		pairs = append(pairs, name+"="+strconv.Quote(labels[name]))
+	}
+	return "{" + strings.Join(pairs, ", ") + "}"
+}
+
+// lokiLabelName returns the field name as a valid label name, the invalid characters are replaced by underscores.
+func lokiLabelName(name string) string {
+	label := strings.Map(func(r rune) rune {
+		if r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') {
+			return r
+		}
+		return '_'
+	}, name)
+	if label == "" || (label[0] >= '0' && label[0] <= '9') {
+		label = "_" + label
+	}
+	return label
+}
+
+// lokiLabelsFromConfiguration returns the comma separated labels of APP_LOG_LOKI_LABELS, nil if not set.
+func lokiLabelsFromConfiguration(value string) []string {
+	var labels []string
+	for _, label := range strings.Split(value, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
@@ -0,0 +1,105 @@
+package logger
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// lokiServer records the push requests, after responding with the queued failure statuses
+type lokiServer struct {
+	mu       sync.Mutex
+	paths    []string
+	streams  []lokiStream
+	failures []int
+}
+
+func (s *lokiServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.paths = append(s.paths, r.URL.Path)
+	if len(s.failures) > 0 {
+		w.WriteHeader(s.failures[0])
+		s.failures = s.failures[1:]
+		return
+	}
+	request := struct {
+		Streams []lokiStream `json:"streams"`
+	}{}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.streams = append(s.streams, request.Streams...)
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (ls *LoggerSuite) TestLokiHook() {
+	server := &lokiServer{}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:             constants.ENV_TEST,
+		constants.APP_LOG_LOKI_URL:    httpServer.URL,
+		constants.APP_LOG_LOKI_LABELS: "service, level, tenant-id",
+		constants.APP_LOG_MASK_PII:    "true",
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(&strings.Builder{})
+
+	commonLog.WithFields(logrus.Fields{"tenant-id": "acme", "request_id": "r-1"}).Info("Order created")
+	commonLog.WithFields(logrus.Fields{"tenant-id": "acme", "request_id": "r-2", "email": "jane@example.org"}).Info("Order paid")
+	commonLog.Entry().Error("Payment failed")
+	ls.NoError(commonLog.Sync())
+
+	server.mu.Lock()
+	defer server.mu.Unlock()
+	ls.Equal([]string{lokiPushPath}, server.paths, "Entries should be pushed in a single batch")
+	ls.Require().Len(server.streams, 2, "Entries should be grouped into streams by the labels")
+	ls.Equal(map[string]string{"service": "test-service", "level": "error"}, server.streams[0].Stream)
+	ls.Equal(map[string]string{"service": "test-service", "level": "info", "tenant_id": "acme"}, server.streams[1].Stream,
+		"Only the allowed fields should be labels")
+	ls.Require().Len(server.streams[1].Values, 2)
+	ls.Contains(server.streams[1].Values[0][1], `"request_id":"r-1"`, "Other fields should stay in the line")
+	ls.Contains(server.streams[1].Values[1][1], `"msg":"Order paid"`)
+	ls.Contains(server.streams[1].Values[1][1], `"email":"*****"`, "The lines should be masked")
+}
+
+func (ls *LoggerSuite) TestLokiHookRetry() {
+	server := &lokiServer{failures: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests}}
+	httpServer := httptest.NewServer(server)
+	defer httpServer.Close()
+
+	hook, err := NewLokiHook(httpServer.URL+"/custom/push", nil, time.Hour)
+	ls.Require().NoError(err)
+	defer hook.Close()
+	hook.backoff = time.Millisecond
+	ls.NoError(ship(hook, &logrus.Entry{Level: logrus.WarnLevel, Message: "Retrying", Data: logrus.Fields{"env": "test"}}))
+	ls.NoError(hook.Flush(), "Push should be retried on 5xx and 429")
+	ls.Equal([]string{"/custom/push", "/custom/push", "/custom/push"}, server.paths)
+	ls.Equal(map[string]string{"env": "test", "level": "warning"}, server.streams[0].Stream)
+
+	server.mu.Lock()
+	server.failures = []int{http.StatusBadRequest}
+	server.mu.Unlock()
+	ls.NoError(ship(hook, &logrus.Entry{Level: logrus.InfoLevel, Message: "Too old", Data: logrus.Fields{}}))
+	ls.EqualError(hook.Flush(), "Failed to push 1 entries to Loki: Loki responded 400 Bad Request: ",
+		"Rejected batches should not be retried")
+	ls.Len(server.paths, 4)
+
+	_, err = NewLokiHook("loki:3100", nil, 0)
+	ls.EqualError(err, "Invalid Loki URL loki:3100")
+}
+
+func (ls *LoggerSuite) TestLokiLabelName() {
+	ls.Equal("service", lokiLabelName("service"))
+	ls.Equal("http_status", lokiLabelName("http.status"))
+	ls.Equal("_1st", lokiLabelName("1st"))
+}
@@ -0,0 +1,41 @@
+// Package otelhook provides a Logrus Hook which correlates the entries of the Common Logger with the OpenTelemetry
+// traces.
+// Use logger.WithHooks(otelhook.New()) to add the Hook to the Common Logger
+// Use logger.Logger.WithContext to create the entries of the span in the context
+package otelhook
+
+import (
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Hook is a Logrus Hook which adds the trace_id, span_id and trace_sampled fields (see logger.TraceIDField) of the
+// OpenTelemetry span in the context of the entry (see logger.Logger.WithContext), so the logs can be correlated with the traces.
+// The entries without a context or a valid span are not changed.
+type Hook struct{}
+
+// New creates a new Hook, add it to the Common Logger by logger.WithHooks.
+func New() *Hook {
+	return &Hook{}
+}
+
+// Levels implements the logrus.Hook interface, the hook is fired on all levels.
+func (hook *Hook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (hook *Hook) Fire(entry *logrus.Entry) error {
+	if entry.Context == nil {
+		return nil
+	}
+	spanContext := trace.SpanContextFromContext(entry.Context)
+	if !spanContext.IsValid() {
+		return nil
+	}
+	entry.Data[logger.TraceIDField] = spanContext.TraceID().String()
+	entry.Data[logger.SpanIDField] = spanContext.SpanID().String()
+	entry.Data[logger.TraceSampledField] = spanContext.IsSampled()
+	return nil
+}
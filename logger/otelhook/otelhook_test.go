@@ -0,0 +1,69 @@
+package otelhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// OtelHookSuite extends testify's Suite.
+type OtelHookSuite struct {
+	suite.Suite
+}
+
+// lastEntry returns the fields of the last entry of the JSON log file.
+func (hs *OtelHookSuite) lastEntry(filename string) logrus.Fields {
+	content, err := ioutil.ReadFile(filename)
+	hs.Require().NoError(err)
+	lines := bytes.Split(bytes.TrimSpace(content), []byte("\n"))
+	fields := logrus.Fields{}
+	hs.Require().NoError(json.Unmarshal(lines[len(lines)-1], &fields), "Entry should be logged in JSON")
+	return fields
+}
+
+func (hs *OtelHookSuite) TestHook() {
+	filename := filepath.Join(hs.T().TempDir(), "app.log")
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FILE:  filename,
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_INFO,
+	}, logger.WithHooks(New()))
+
+	traceID, err := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	hs.NoError(err)
+	spanID, err := trace.SpanIDFromHex("00f067aa0ba902b7")
+	hs.NoError(err)
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{
+		TraceID:    traceID,
+		SpanID:     spanID,
+		TraceFlags: trace.FlagsSampled,
+	}))
+
+	commonLog.WithContext(ctx).WithField("key", "value").Info("Traced")
+	fields := hs.lastEntry(filename)
+	hs.Equal("4bf92f3577b34da6a3ce929d0e0e4736", fields[logger.TraceIDField])
+	hs.Equal("00f067aa0ba902b7", fields[logger.SpanIDField])
+	hs.Equal(true, fields[logger.TraceSampledField])
+	hs.Equal("value", fields["key"])
+	hs.Equal("test-service", fields["service"], "Default fields should be kept")
+
+	commonLog.WithContext(context.Background()).Info("Not traced")
+	fields = hs.lastEntry(filename)
+	hs.Equal("Not traced", fields["msg"])
+	hs.NotContains(fields, logger.TraceIDField, "Entries without a span should not have a trace_id")
+	hs.NotContains(fields, logger.SpanIDField)
+	hs.NoError(commonLog.Close())
+}
+
+func TestOtelHook(t *testing.T) {
+	suite.Run(t, new(OtelHookSuite))
+}
@@ -0,0 +1,97 @@
+package logger
+
+import (
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPIIPatterns are the patterns of the personal data masked by NewPIIMaskingFormatter.
+var DefaultPIIPatterns = []SensitivePattern{
+	{
+		Name:   "email",
+		Regexp: regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`),
+	},
+	{
+		Name:   "credit_card",
+		Regexp: regexp.MustCompile(`\b\d(?:[ -]?\d){12,18}\b`),
+		Match:  luhnValid,
+	},
+}
+
+// luhnValid returns whether the digits of number pass the Luhn checksum of the card numbers,
+// so other long numbers (e.g. timestamps) are not masked.
+func luhnValid(number string) bool {
+	sum, double := 0, false
+	for i := len(number) - 1; i >= 0; i-- {
+		if number[i] < '0' || number[i] > '9' {
+			continue
+		}
+		digit := int(number[i] - '0')
+		if double {
+			digit *= 2
+			if digit > 9 {
+				digit -= 9
+			}
+		}
+		sum += digit
+		double = !double
+	}
+	return sum%10 == 0
+}
+
+// PIIMaskingFormatter is a logrus.Formatter wrapper which masks the personal data (emails, card numbers and
+// custom patterns) in the message and the string fields of the entries before formatting them, for the
+// GDPR-sensitive services. The Common Logger uses it if APP_LOG_MASK_PII is enabled.
+type PIIMaskingFormatter struct {
+	formatter logrus.Formatter
+	patterns  []SensitivePattern
+}
+
+// NewPIIMaskingFormatter creates a new PIIMaskingFormatter wrapping formatter, which masks the
+// DefaultPIIPatterns and the supplied custom patterns.
+func NewPIIMaskingFormatter(formatter logrus.Formatter, patterns ...SensitivePattern) *PIIMaskingFormatter {
+	return &PIIMaskingFormatter{
+		formatter: formatter,
+		patterns:  append(append([]SensitivePattern{}, DefaultPIIPatterns...), patterns...),
+	}
+}
+
+// Format implements the logrus.Formatter interface.
+func (f *PIIMaskingFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	f.admit(entry)
+	return f.formatter.Format(entry)
+}
+
+// admit implements the entryFilter interface, it masks the entry in place and admits it,
+// so the entries written by a Backend are masked too.
+func (f *PIIMaskingFormatter) admit(entry *logrus.Entry) bool {
+	entry.Message = f.mask(entry.Message)
+	for key, value := range entry.Data {
+		if text, ok := value.(string); ok {
+			entry.Data[key] = f.mask(text)
+		}
+	}
+	return true
+}
+
+// mask replaces every match of the patterns in text.
+func (f *PIIMaskingFormatter) mask(text string) string {
+	for _, pattern := range f.patterns {
+		text = pattern.Regexp.ReplaceAllStringFunc(text, func(match string) string {
+			if pattern.Match != nil && !pattern.Match(match) {
+				return match
+			}
+			return MaskedValue
+		})
+	}
+	return text
+}
+
+// WithPIIPatterns adds custom patterns to the DefaultPIIPatterns masked by the Common Logger
+// if APP_LOG_MASK_PII is enabled.
+func WithPIIPatterns(patterns ...SensitivePattern) LoggerOption {
+	return func(o *loggerOptions) {
+		o.piiPatterns = append(o.piiPatterns, patterns...)
+	}
+}
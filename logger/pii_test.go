@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestPIIMaskingFormatter() {
+	output := &bytes.Buffer{}
+	l := logrus.New()
+	l.SetOutput(output)
+	l.SetFormatter(NewPIIMaskingFormatter(BasicJSONFormatter, SensitivePattern{
+		Name:   "iban",
+		Regexp: regexp.MustCompile(`\bHU\d{2}(?: ?\d{4}){6}\b`),
+	}))
+
+	l.WithFields(logrus.Fields{
+		"email":     "john.doe@example.com",
+		"card":      "4111 1111 1111 1111",
+		"timestamp": "1700000000000",
+		"iban":      "HU42 1177 3016 1111 1018 0000 0000",
+		"attempt":   3,
+	}).Info("Payment of jane@example.org with 4111-1111-1111-1111 failed")
+
+	fields := logrus.Fields{}
+	ls.NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("Payment of ***** with ***** failed", fields[logrus.FieldKeyMsg])
+	ls.Equal(MaskedValue, fields["email"])
+	ls.Equal(MaskedValue, fields["card"])
+	ls.Equal("1700000000000", fields["timestamp"], "Numbers failing the Luhn checksum should not be masked")
+	ls.Equal(MaskedValue, fields["iban"], "Custom patterns should be masked")
+	ls.Equal(float64(3), fields["attempt"])
+}
+
+func (ls *LoggerSuite) TestPIIMaskingFromConfiguration() {
+	for name, opts := range map[string][]LoggerOption{
+		"logrus":  {WithPIIPatterns(SensitivePattern{Name: "user_id", Regexp: regexp.MustCompile(`\buid-\d+\b`)})},
+		"backend": {WithBackend(jsonBackend), WithPIIPatterns(SensitivePattern{Name: "user_id", Regexp: regexp.MustCompile(`\buid-\d+\b`)})},
+	} {
+		filename := filepath.Join(ls.T().TempDir(), "app.log")
+		commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+			constants.APP_LOG_FILE:     filename,
+			constants.APP_LOG_MASK_PII: "true",
+		}, opts...)
+		commonLog.WithField("user", "uid-42").Info("Mail sent to john.doe@example.com")
+		ls.NoError(commonLog.Sync())
+
+		content, err := ioutil.ReadFile(filename)
+		ls.NoError(err)
+		fields := logrus.Fields{}
+		ls.NoError(json.Unmarshal(content, &fields))
+		ls.Equal("Mail sent to *****", fields[logrus.FieldKeyMsg], "PII should be masked with %s", name)
+		ls.Equal(MaskedValue, fields["user"], "Custom patterns should be masked with %s", name)
+		ls.Equal("test-service", fields["service"])
+	}
+}
@@ -0,0 +1,150 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// DefaultPrettyHiddenFields are the common fields hidden by the PrettyFormatter if no fields are set, they are
+// the same in every entry of a local run.
+var DefaultPrettyHiddenFields = []string{"service", "version", "env", "host"}
+
+// DefaultPrettyComponentWidth is the width of the component column of the PrettyFormatter if no width is set.
+const DefaultPrettyComponentWidth = 12
+
+// prettyLevelColors are the ANSI colors of the levels.
+var prettyLevelColors = map[logrus.Level]string{
+	logrus.PanicLevel: "1;31",
+	logrus.FatalLevel: "1;31",
+	logrus.ErrorLevel: "31",
+	logrus.WarnLevel:  "33",
+	logrus.InfoLevel:  "36",
+	logrus.DebugLevel: "90",
+	logrus.TraceLevel: "90",
+}
+
+// PrettyFormatter is a logrus.Formatter for the local development: every entry starts with the time, the color
+// coded level, the right-aligned component and the message, followed by the fields indented on their own lines.
+// The multi-line values, e.g. the error field with its stack trace, are rendered line by line.
+type PrettyFormatter struct {
+	// DisableColors disables the ANSI colors, e.g. if the output is not a terminal.
+	DisableColors bool
+
+	// ComponentWidth is the width of the component column, DefaultPrettyComponentWidth if zero.
+	ComponentWidth int
+
+	// HiddenFields are the fields not rendered, DefaultPrettyHiddenFields if nil.
+	HiddenFields []string
+}
+
+// Format implements the logrus.Formatter interface.
+func (f *PrettyFormatter) Format(entry *logrus.Entry) ([]byte, error) {
+	hidden := f.HiddenFields
+	if hidden == nil {
+		hidden = DefaultPrettyHiddenFields
+	}
+	width := f.ComponentWidth
+	if width <= 0 {
+		width = DefaultPrettyComponentWidth
+	}
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+	level := strings.ToUpper(entry.Level.String())
+	if entry.Level == logrus.WarnLevel {
+		level = "WARN"
+	}
+	component := ""
+	if name, ok := entry.Data["component"].(string); ok && name != "" {
+		component = "[" + name + "]"
+	}
+
+	var buffer bytes.Buffer
+	buffer.WriteString(f.color("90", timestamp.Format("15:04:05.000")))
+	buffer.WriteByte(' ')
+	buffer.WriteString(f.color(prettyLevelColors[entry.Level], fmt.Sprintf("%-5s", level)))
+	fmt.Fprintf(&buffer, " %*s ", width, component)
+	buffer.WriteString(entry.Message)
+	buffer.WriteByte('\n')
+
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		if key != "component" && !containsString(hidden, key) {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	if entry.HasCaller() {
+		f.writeField(&buffer, entry.Level, "caller", fmt.Sprintf("%s:%d", entry.Caller.File, entry.Caller.Line))
+	}
+	for _, key := range keys {
+		f.writeField(&buffer, entry.Level, key, prettyValue(entry.Data[key]))
+	}
+	return buffer.Bytes(), nil
+}
+
+// writeField writes an indented field, the lines of a multi-line value indented below its key.
+func (f *PrettyFormatter) writeField(buffer *bytes.Buffer, level logrus.Level, key, value string) {
+	buffer.WriteString("    ")
+	buffer.WriteString(f.color(prettyLevelColors[level], key))
+	buffer.WriteByte(':')
+	// The errors formatted by APP_LOG_FORMAT_ERRORS are rendered on their lines as well
+	value = strings.ReplaceAll(value, " --- ", "\n")
+	if !strings.Contains(value, "\n") {
+		buffer.WriteString(" " + value + "\n")
+		return
+	}
+	buffer.WriteByte('\n')
+	for _, line := range strings.Split(strings.TrimRight(value, "\n"), "\n") {
+		buffer.WriteString("        ")
+		buffer.WriteString(strings.ReplaceAll(line, "\t", "    "))
+		buffer.WriteByte('\n')
+	}
+}
+
+// color returns text in the ANSI color, unless the colors are disabled.
+func (f *PrettyFormatter) color(color, text string) string {
+	if f.DisableColors || color == "" {
+		return text
+	}
+	return "\x1b[" + color + "m" + text + "\x1b[0m"
+}
+
+// prettyValue returns the string of a field value, the message of the errors.
+func prettyValue(value interface{}) string {
+	switch value := value.(type) {
+	case string:
+		return value
+	case error:
+		return value.Error()
+	}
+	return fmt.Sprint(value)
+}
+
+// isTerminal returns whether output is a terminal, honouring the NO_COLOR convention.
+func isTerminal(output io.Writer) bool {
+	file, ok := output.(*os.File)
+	if !ok || os.Getenv("NO_COLOR") != "" {
+		return false
+	}
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+// containsString returns whether values contains value.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package logger
+
+import (
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestPrettyFormatter() {
+	output := &strings.Builder{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:        constants.ENV_TEST,
+		constants.APP_LOG_FORMAT: constants.LOG_FORMAT_PRETTY,
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	timestamp := time.Date(2024, 1, 31, 12, 0, 0, 0, time.UTC)
+	commonLog.NewComponentLogger("payments").WithField("error", "connection refused\nmain.pay\n\t/app/pay.go:12").
+		WithField("order_id", 42).WithTime(timestamp).Warn("Payment failed")
+
+	ls.Equal("12:00:00.000 WARN    [payments] Payment failed\n"+
+		"    error:\n"+
+		"        connection refused\n"+
+		"        main.pay\n"+
+		"            /app/pay.go:12\n"+
+		"    order_id: 42\n", output.String(), "Colors should be disabled without a terminal")
+
+	formatter := &PrettyFormatter{HiddenFields: []string{}}
+	formatted, err := formatter.Format(&logrus.Entry{
+		Time:    timestamp,
+		Level:   logrus.ErrorLevel,
+		Message: "Payment failed",
+		Data:    logrus.Fields{"service": "test-service"},
+	})
+	ls.Require().NoError(err)
+	ls.Equal("\x1b[90m12:00:00.000\x1b[0m \x1b[31mERROR\x1b[0m              Payment failed\n"+
+		"    \x1b[31mservice\x1b[0m: test-service\n", string(formatted))
+}
@@ -0,0 +1,79 @@
+package logger
+
+import (
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Redactor returns the redacted value of a field, e.g. MaskValue.
+type Redactor func(value interface{}) interface{}
+
+// DefaultRedactedFields are the fields redacted by every RedactionHook with MaskValue.
+var DefaultRedactedFields = []string{"password", "authorization", "token"}
+
+// MaskValue is the default Redactor, it replaces every value with MaskedValue.
+func MaskValue(interface{}) interface{} {
+	return MaskedValue
+}
+
+// RedactionHook is a logrus.Hook which redacts the fields of every entry by name before the formatting,
+// so the accidentally logged secrets are neutralized centrally. The field names are case-insensitive.
+type RedactionHook struct {
+	mu        sync.RWMutex
+	redactors map[string]Redactor
+}
+
+// NewRedactionHook creates a new RedactionHook masking the DefaultRedactedFields,
+// it is added to the Common Logger by NewCommonLoggerFromConfiguration.
+func NewRedactionHook() *RedactionHook {
+	hook := &RedactionHook{redactors: map[string]Redactor{}}
+	for _, name := range DefaultRedactedFields {
+		hook.Add(name, MaskValue)
+	}
+	return hook
+}
+
+// Add sets the Redactor of the named field, replacing the previous one.
+func (h *RedactionHook) Add(fieldName string, redactor Redactor) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.redactors[strings.ToLower(fieldName)] = redactor
+}
+
+// Levels implements the logrus.Hook interface, the hook fires on every level.
+func (h *RedactionHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (h *RedactionHook) Fire(entry *logrus.Entry) error {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	for key, value := range entry.Data {
+		if redactor, ok := h.redactors[strings.ToLower(key)]; ok {
+			entry.Data[key] = redactor(value)
+		}
+	}
+	return nil
+}
+
+// AddRedactor redacts the named field of every entry of the Logger (and its component loggers) with
+// redactor, e.g. commonLog.AddRedactor("card_number", MaskValue). The password, authorization and token
+// fields are redacted by default. It has no effect if the Logger is not backed by a Logrus Logger.
+func (l *Logger) AddRedactor(fieldName string, redactor Redactor) {
+	log := l.logrusLogger()
+	if log == nil {
+		return
+	}
+	for _, hook := range log.Hooks[logrus.InfoLevel] {
+		if redaction, ok := hook.(*RedactionHook); ok {
+			redaction.Add(fieldName, redactor)
+			return
+		}
+	}
+	redaction := NewRedactionHook()
+	redaction.Add(fieldName, redactor)
+	log.AddHook(redaction)
+}
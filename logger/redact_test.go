@@ -0,0 +1,53 @@
+package logger
+
+import (
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestRedactionHook() {
+	l, hook := logrusTest.NewNullLogger()
+	log := NewLogger(l, logrus.Fields{"service": "test-service"})
+	componentLog := log.NewComponentLogger("auth")
+	log.AddRedactor("card_number", func(value interface{}) interface{} {
+		number, _ := value.(string)
+		return strings.Repeat("*", len(number)-4) + number[len(number)-4:]
+	})
+
+	componentLog.WithFields(logrus.Fields{
+		"Password":      "hunter2",
+		"authorization": "Bearer abc",
+		"token":         12345,
+		"card_number":   "4111111111111111",
+		"user":          "alice",
+	}).Info("Login")
+	ls.Equal(logrus.Fields{
+		"service":       "test-service",
+		"component":     "auth",
+		"Password":      MaskedValue,
+		"authorization": MaskedValue,
+		"token":         MaskedValue,
+		"card_number":   "************1111",
+		"user":          "alice",
+	}, hook.LastEntry().Data, "The default and the added fields should be redacted")
+
+	componentLog.AddRedactor("user", MaskValue)
+	log.WithField("user", "alice").Info("Logout")
+	ls.Len(l.Hooks[logrus.InfoLevel], 2, "A single RedactionHook should be added next to the test hook")
+	ls.Equal(MaskedValue, hook.LastEntry().Data["user"], "The redactors should be shared by the component loggers")
+}
+
+func (ls *LoggerSuite) TestRedactionFromConfiguration() {
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_INFO,
+	})
+	backend := &recordingBackend{}
+	useBackend(commonLog.log.(*logrus.Logger), backend)
+	commonLog.WithField("password", "hunter2").Info("Login")
+	ls.Require().Len(backend.entries, 1)
+	ls.Equal(MaskedValue, backend.entries[0].Data["password"], "The Common Logger should redact the default fields")
+}
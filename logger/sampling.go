@@ -0,0 +1,84 @@
+package logger
+
+import (
+	"hash/fnv"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// samplerBuckets is the number of the counters of a sampler, the keys are hashed into the buckets
+// so the memory of the sampler is bounded.
+const samplerBuckets = 4096
+
+// samplerCounter counts the entries of a key in the current tick.
+type samplerCounter struct {
+	resetAt time.Time
+	count   int
+}
+
+// sampler keeps the first initial entries of every level and message in a tick, and every thereafter-th
+// entry after them (none if thereafter is 0), to keep the log volume sane during incident storms.
+type sampler struct {
+	initial    int
+	thereafter int
+	tick       time.Duration
+	now        func() time.Time
+
+	mu       sync.Mutex
+	counters [samplerBuckets]samplerCounter
+}
+
+// newSampler creates a new sampler with the supplied limits per tick.
+func newSampler(initial, thereafter int, tick time.Duration) *sampler {
+	return &sampler{
+		initial:    initial,
+		thereafter: thereafter,
+		tick:       tick,
+		now:        time.Now,
+	}
+}
+
+// admit implements the entryFilter interface.
+func (s *sampler) admit(entry *logrus.Entry) bool {
+	if _, ok := entry.Data[RepeatedField]; ok {
+		// The summaries of the deduplication are not sampled
+		return true
+	}
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(strconv.Itoa(int(entry.Level))))
+	_, _ = hash.Write([]byte(entry.Message))
+
+	now := s.now()
+	s.mu.Lock()
+	counter := &s.counters[hash.Sum32()%samplerBuckets]
+	if !now.Before(counter.resetAt) {
+		counter.resetAt = now.Add(s.tick)
+		counter.count = 0
+	}
+	counter.count++
+	count := counter.count
+	s.mu.Unlock()
+
+	if count <= s.initial {
+		return true
+	}
+	return s.thereafter > 0 && (count-s.initial)%s.thereafter == 0
+}
+
+// samplerFromConfiguration creates the sampler configured by APP_LOG_SAMPLING_INITIAL (the number of the
+// entries of a level and message logged per second) and APP_LOG_SAMPLING_THEREAFTER (every Mth entry is
+// logged after them), nil if sampling is disabled (APP_LOG_SAMPLING_INITIAL is not a positive number).
+func samplerFromConfiguration(initial, thereafter string) *sampler {
+	initialCount, err := strconv.Atoi(initial)
+	if err != nil || initialCount <= 0 {
+		return nil
+	}
+	thereafterCount, err := strconv.Atoi(thereafter)
+	if err != nil || thereafterCount < 0 {
+		thereafterCount = 0
+	}
+	return newSampler(initialCount, thereafterCount, time.Second)
+}
@@ -0,0 +1,63 @@
+package logger
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestSampler() {
+	now := time.Now()
+	s := newSampler(2, 3, time.Second)
+	s.now = func() time.Time { return now }
+
+	entry := &logrus.Entry{Level: logrus.ErrorLevel, Message: "Connection refused"}
+	sampled := []bool{}
+	for i := 0; i < 8; i++ {
+		sampled = append(sampled, s.admit(entry))
+	}
+	ls.Equal([]bool{true, true, false, false, true, false, false, true}, sampled, "The first 2, then every 3rd entry should be kept")
+	ls.True(s.admit(&logrus.Entry{Level: logrus.WarnLevel, Message: "Connection refused"}), "The levels should be sampled separately")
+	ls.True(s.admit(&logrus.Entry{Level: logrus.ErrorLevel, Message: "Timeout"}), "The messages should be sampled separately")
+
+	now = now.Add(time.Second)
+	ls.True(s.admit(entry), "The counters should be reset after a tick")
+
+	s = newSampler(1, 0, time.Second)
+	s.now = func() time.Time { return now }
+	ls.True(s.admit(entry))
+	ls.False(s.admit(entry), "Every entry after the initial ones should be dropped without thereafter")
+
+	ls.Nil(samplerFromConfiguration("", "10"), "Sampling should be disabled by default")
+	ls.Nil(samplerFromConfiguration("many", "10"))
+	ls.Equal(0, samplerFromConfiguration("5", "").thereafter)
+}
+
+func (ls *LoggerSuite) TestSamplingFromConfiguration() {
+	for name, opts := range map[string][]LoggerOption{
+		"logrus":  nil,
+		"backend": {WithBackend(jsonBackend)},
+	} {
+		filename := filepath.Join(ls.T().TempDir(), "app.log")
+		commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+			constants.APP_LOG_FILE:                filename,
+			constants.APP_LOG_SAMPLING_INITIAL:    "2",
+			constants.APP_LOG_SAMPLING_THEREAFTER: "3",
+		}, opts...)
+		for i := 0; i < 8; i++ {
+			commonLog.Entry().Error("Connection refused")
+		}
+		commonLog.Entry().Info("Other message")
+		ls.NoError(commonLog.Sync())
+
+		content, err := ioutil.ReadFile(filename)
+		ls.NoError(err)
+		ls.Equal(4, strings.Count(string(content), "Connection refused"), "Entries should be sampled with %s", name)
+		ls.Contains(string(content), "Other message")
+	}
+}
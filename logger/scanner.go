@@ -0,0 +1,102 @@
+package logger
+
+import (
+	"expvar"
+	"fmt"
+	"regexp"
+
+	"github.com/sirupsen/logrus"
+)
+
+// MaskedValue replaces the sensitive data in the log entries.
+const MaskedValue = "*****"
+
+// MaskedSecrets counts the masked sensitive data by pattern name, published as the
+// "logger_masked_secrets_total" expvar.
+var MaskedSecrets = expvar.NewMap("logger_masked_secrets_total")
+
+// SensitivePattern is a named regular expression matching sensitive data.
+type SensitivePattern struct {
+	Name   string
+	Regexp *regexp.Regexp
+
+	// Match checks the matches of Regexp further (e.g. the checksum of a card number), nil accepts every match.
+	Match func(match string) bool
+}
+
+// DefaultSensitivePatterns are the patterns used by NewSensitiveDataHook if none supplied.
+var DefaultSensitivePatterns = []SensitivePattern{
+	{
+		Name:   "aws_access_key_id",
+		Regexp: regexp.MustCompile(`\b(?:AKIA|ASIA|AGPA|AIDA|AROA|ANPA|ANVA)[0-9A-Z]{16}\b`),
+	},
+	{
+		Name:   "aws_secret_access_key",
+		Regexp: regexp.MustCompile(`(?i)aws_?secret_?access_?key["']?\s*[:=]\s*["']?[A-Za-z0-9/+=]{40}`),
+	},
+	{
+		Name:   "jwt",
+		Regexp: regexp.MustCompile(`\beyJ[A-Za-z0-9_-]+\.eyJ[A-Za-z0-9_-]+\.[A-Za-z0-9_-]*`),
+	},
+	{
+		Name:   "private_key",
+		Regexp: regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----(?s:.*?)(?:-----END [A-Z ]*PRIVATE KEY-----|$)`),
+	},
+}
+
+// SensitiveDataHook is a logrus.Hook which scans the message and the fields of every entry for
+// sensitive data (AWS keys, JWTs, private keys, ...) and masks the matches.
+// It is a safety net for the cases where a whole object (e.g. a request) is logged.
+// Every masked match increments the MaskedSecrets counter of its pattern.
+type SensitiveDataHook struct {
+	patterns []SensitivePattern
+}
+
+// NewSensitiveDataHook creates a new SensitiveDataHook with the supplied patterns,
+// or with the DefaultSensitivePatterns if none supplied.
+func NewSensitiveDataHook(patterns ...SensitivePattern) *SensitiveDataHook {
+	if len(patterns) == 0 {
+		patterns = DefaultSensitivePatterns
+	}
+	return &SensitiveDataHook{patterns: patterns}
+}
+
+// Levels implements the logrus.Hook interface, the hook fires on every level.
+func (h *SensitiveDataHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface. String fields are masked in place, other fields are
+// replaced by their masked string representation only if they contain sensitive data.
+func (h *SensitiveDataHook) Fire(entry *logrus.Entry) error {
+	entry.Message, _ = h.mask(entry.Message)
+	for key, value := range entry.Data {
+		switch typed := value.(type) {
+		case string:
+			entry.Data[key], _ = h.mask(typed)
+		case nil, bool, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+			// Scalars cannot contain sensitive data
+		default:
+			if masked, found := h.mask(fmt.Sprintf("%+v", typed)); found {
+				entry.Data[key] = masked
+			}
+		}
+	}
+	return nil
+}
+
+// mask replaces every match of the patterns in text, and reports if anything was masked.
+func (h *SensitiveDataHook) mask(text string) (string, bool) {
+	found := false
+	for _, pattern := range h.patterns {
+		text = pattern.Regexp.ReplaceAllStringFunc(text, func(match string) string {
+			if pattern.Match != nil && !pattern.Match(match) {
+				return match
+			}
+			found = true
+			MaskedSecrets.Add(pattern.Name, 1)
+			return MaskedValue
+		})
+	}
+	return text, found
+}
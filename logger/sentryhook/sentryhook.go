@@ -0,0 +1,202 @@
+// Package sentryhook provides a logger.Shipper which sends the error entries of the Common Logger to Sentry.
+// Use FromConfiguration with logger.WithShippers to send to the project of APP_LOG_SENTRY_DSN
+// Use New to create the Hook with a custom Sentry client
+package sentryhook
+
+import (
+	"fmt"
+	"regexp"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// Tags are the fields of the entries sent as Sentry tags, the other fields are sent as extra data.
+var Tags = []string{"service", "version", "env", "host", "component", logger.TraceIDField}
+
+// flushTimeout is the time waited for the events to be sent, before the fatal entries exit.
+const flushTimeout = 5 * time.Second
+
+// frameLocation matches the file:line lines of a pkg/errors stack trace.
+var frameLocation = regexp.MustCompile(`^\t?(\S+\.\w+):(\d+)$`)
+
+// Hook is a logger.Shipper which sends the error, fatal and panic entries as events to Sentry. The common
+// fields are the tags of the events (see Tags), the error field is the exception of the event, with the
+// stack trace of the pkg/errors errors unwrapped by Logger.WithError. The events are sent in the background,
+// except the fatal and panic entries, which are sent before the application exits.
+type Hook struct {
+	client    *sentry.Client
+	captured  atomic.Bool
+	closeOnce sync.Once
+}
+
+// New creates a new Hook which sends the events by client, e.g.
+// sentry.NewClient(sentry.ClientOptions{Dsn: dsn}).
+func New(client *sentry.Client) *Hook {
+	return &Hook{client: client}
+}
+
+// Levels implements the logger.Shipper interface, the error, fatal and panic entries are shipped.
+func (hook *Hook) Levels() []logrus.Level {
+	return []logrus.Level{logrus.PanicLevel, logrus.FatalLevel, logrus.ErrorLevel}
+}
+
+// Ship implements the logger.Shipper interface, the entry is sent as an event of its fields.
+func (hook *Hook) Ship(entry *logrus.Entry, _ []byte) error {
+	hook.client.CaptureEvent(entryEvent(entry), nil, nil)
+	hook.captured.Store(true)
+	if entry.Level <= logrus.FatalLevel {
+		return hook.Flush()
+	}
+	return nil
+}
+
+// Flush waits until the events are sent, it is called by Logger.Sync.
+func (hook *Hook) Flush() error {
+	// The transport waits for the timeout if no event was ever sent
+	if !hook.captured.Swap(false) {
+		return nil
+	}
+	if !hook.client.Flush(flushTimeout) {
+		return errors.New("Failed to send the events to Sentry in time")
+	}
+	return nil
+}
+
+// Close sends the pending events and stops the client.
+func (hook *Hook) Close() error {
+	var err error
+	hook.closeOnce.Do(func() {
+		err = hook.Flush()
+		hook.client.Close()
+	})
+	return err
+}
+
+// entryEvent returns the Sentry event of the entry.
+func entryEvent(entry *logrus.Entry) *sentry.Event {
+	event := sentry.NewEvent()
+	event.Level = sentry.LevelError
+	if entry.Level <= logrus.FatalLevel {
+		event.Level = sentry.LevelFatal
+	}
+	event.Message = entry.Message
+	event.Timestamp = entry.Time
+	event.Logger = "logrus"
+
+	for key, value := range entry.Data {
+		if key == logrus.ErrorKey {
+			continue
+		}
+		if isTag(key) {
+			event.Tags[key] = fmt.Sprint(value)
+		} else if err, ok := value.(error); ok {
+			event.Extra[key] = err.Error()
+		} else {
+			event.Extra[key] = value
+		}
+	}
+	event.Release, _ = entry.Data["version"].(string)
+	event.Environment, _ = entry.Data["env"].(string)
+	event.ServerName, _ = entry.Data["host"].(string)
+
+	switch err := entry.Data[logrus.ErrorKey].(type) {
+	case error:
+		// The error of logrus.Entry.WithError keeps its stack trace
+		event.Exception = []sentry.Exception{{
+			Type:       entry.Message,
+			Value:      err.Error(),
+			Stacktrace: sentry.ExtractStacktrace(err),
+		}}
+	case string:
+		// The error of Logger.WithError is the formatted pkg/errors error, with its stack trace
+		value, stacktrace := parseErrorStack(err)
+		event.Exception = []sentry.Exception{{Type: entry.Message, Value: value, Stacktrace: stacktrace}}
+	}
+	if len(event.Exception) > 0 && event.Exception[0].Stacktrace == nil && entry.HasCaller() {
+		event.Exception[0].Stacktrace = &sentry.Stacktrace{Frames: []sentry.Frame{sentry.NewFrame(runtime.Frame{
+			Function: entry.Caller.Function,
+			File:     entry.Caller.File,
+			Line:     entry.Caller.Line,
+		})}}
+	}
+	return event
+}
+
+// isTag returns whether the field is sent as a tag.
+func isTag(key string) bool {
+	for _, tag := range Tags {
+		if key == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// parseErrorStack splits an error formatted by logger.Logger.WithError into its message and the stack trace of its origin,
+// nil if the error has no stack trace. Both the multi-line and the APP_LOG_FORMAT_ERRORS formats are parsed.
+func parseErrorStack(formatted string) (string, *sentry.Stacktrace) {
+	lines := strings.Split(formatted, "\n")
+	if strings.Contains(formatted, " --- ") {
+		lines = strings.Split(formatted, " --- ")
+	}
+	// The messages of the wrapped errors precede the messages of their wrappers, each followed by its stack trace
+	var messages []string
+	var frames []sentry.Frame
+	stackDone := false
+	for i := 0; i < len(lines); i++ {
+		if i+1 < len(lines) && !strings.ContainsAny(lines[i], " \t") {
+			if location := frameLocation.FindStringSubmatch(lines[i+1]); location != nil {
+				if !stackDone {
+					line, _ := strconv.Atoi(location[2])
+					frames = append(frames, sentry.NewFrame(runtime.Frame{Function: lines[i], File: location[1], Line: line}))
+				}
+				i++
+				continue
+			}
+		}
+		if len(frames) > 0 {
+			stackDone = true
+		}
+		if message := strings.TrimSpace(lines[i]); message != "" {
+			messages = append([]string{message}, messages...)
+		}
+	}
+	if len(frames) == 0 {
+		return formatted, nil
+	}
+	// Sentry expects the frames from the outermost call
+	for i, j := 0, len(frames)-1; i < j; i, j = i+1, j-1 {
+		frames[i], frames[j] = frames[j], frames[i]
+	}
+	return strings.Join(messages, ": "), &sentry.Stacktrace{Frames: frames}
+}
+
+// FromConfiguration is a logger.ShipperFactory which creates the Hook of the Common Logger sending to the project
+// of APP_LOG_SENTRY_DSN, with the environment, the release and the server name of the service. No Hook is created
+// if the DSN is not set.
+func FromConfiguration(_, serviceVersion string, conf logger.ConfigGetter) (logger.Shipper, error) {
+	dsn := conf.Get(constants.APP_LOG_SENTRY_DSN)
+	if dsn == "" {
+		return nil, nil
+	}
+	client, err := sentry.NewClient(sentry.ClientOptions{
+		Dsn:         dsn,
+		Environment: conf.Get(constants.APP_ENV),
+		Release:     serviceVersion,
+		ServerName:  conf.Hostname(),
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "Failed to create the Sentry client")
+	}
+	return New(client), nil
+}
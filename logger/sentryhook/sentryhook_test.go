@@ -0,0 +1,126 @@
+package sentryhook
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// SentryHookSuite extends testify's Suite.
+type SentryHookSuite struct {
+	suite.Suite
+}
+
+// sentryTransport records the sent events
+type sentryTransport struct {
+	mu      sync.Mutex
+	events  []*sentry.Event
+	flushed int
+}
+
+func (t *sentryTransport) Configure(sentry.ClientOptions) {}
+
+func (t *sentryTransport) SendEvent(event *sentry.Event) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.events = append(t.events, event)
+}
+
+func (t *sentryTransport) Flush(time.Duration) bool {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.flushed++
+	return true
+}
+
+func (t *sentryTransport) FlushWithContext(context.Context) bool {
+	return t.Flush(0)
+}
+
+func (t *sentryTransport) Close() {}
+
+func (ss *SentryHookSuite) TestHook() {
+	transport := &sentryTransport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{Dsn: "https://key@sentry.example.com/1", Transport: transport})
+	ss.Require().NoError(err)
+
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:      constants.ENV_TEST,
+		constants.APP_LOG_FILE: filepath.Join(ss.T().TempDir(), "app.log"),
+	}, logger.WithShippers(func(string, string, logger.ConfigGetter) (logger.Shipper, error) {
+		return New(client), nil
+	}))
+
+	commonLog.Entry().Warn("Payment slow")
+	commonLog.WithError(errors.Wrap(errors.New("connection refused"), "Failed to pay")).
+		WithField("order_id", 42).Error("Payment failed")
+	ss.NoError(commonLog.Sync())
+
+	transport.mu.Lock()
+	defer transport.mu.Unlock()
+	ss.Equal(1, transport.flushed, "Sync should flush the client")
+	ss.Require().Len(transport.events, 1, "Only the error entries should be sent")
+	event := transport.events[0]
+	ss.Equal(sentry.LevelError, event.Level)
+	ss.Equal("Payment failed", event.Message)
+	ss.Equal("test-service", event.Tags["service"])
+	ss.Equal(constants.ENV_TEST, event.Tags["env"])
+	ss.Equal(constants.ENV_TEST, event.Environment)
+	ss.Equal("v1.2.3", event.Release)
+	ss.Equal(42, event.Extra["order_id"], "Other fields should be extra data")
+	ss.Require().Len(event.Exception, 1)
+	ss.Equal("Failed to pay: connection refused", event.Exception[0].Value)
+	ss.Require().NotNil(event.Exception[0].Stacktrace, "Stack trace of the error should be kept")
+	frames := event.Exception[0].Stacktrace.Frames
+	ss.Require().NotEmpty(frames)
+	ss.Equal("(*SentryHookSuite).TestHook", frames[len(frames)-1].Function, "Last frame should be the origin of the error")
+}
+
+func (ss *SentryHookSuite) TestParseErrorStack() {
+	formatted := "connection refused --- main.pay --- \t/app/pay.go:12 --- main.main --- \t/app/main.go:5" +
+		" --- Failed to pay --- main.pay --- \t/app/pay.go:13 --- main.main --- \t/app/main.go:5"
+	value, stacktrace := parseErrorStack(formatted)
+	ss.Equal("Failed to pay: connection refused", value)
+	ss.Require().NotNil(stacktrace)
+	ss.Require().Len(stacktrace.Frames, 2, "Only the stack trace of the origin should be kept")
+	ss.Equal("/app/main.go", stacktrace.Frames[0].AbsPath)
+	ss.Equal(12, stacktrace.Frames[1].Lineno)
+
+	value, stacktrace = parseErrorStack("connection refused")
+	ss.Equal("connection refused", value)
+	ss.Nil(stacktrace)
+}
+
+func (ss *SentryHookSuite) TestFromConfiguration() {
+	shipper, err := FromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:            constants.ENV_TEST,
+		constants.APP_LOG_SENTRY_DSN: "https://key@sentry.example.com/1",
+	})
+	ss.Require().NoError(err)
+	ss.IsType(&Hook{}, shipper)
+
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FILE: filepath.Join(ss.T().TempDir(), "app.log"),
+	}, logger.WithShippers(func(string, string, logger.ConfigGetter) (logger.Shipper, error) {
+		return shipper, nil
+	}))
+	ss.NoError(commonLog.Close())
+	ss.NoError(commonLog.Close(), "Closing again should do nothing")
+
+	shipper, err = FromConfiguration("test-service", "v1.2.3", config.StaticGetter{})
+	ss.NoError(err)
+	ss.Nil(shipper, "No hook should be created without a DSN")
+}
+
+func TestSentryHook(t *testing.T) {
+	suite.Run(t, new(SentryHookSuite))
+}
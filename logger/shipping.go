@@ -0,0 +1,86 @@
+package logger
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Shipper sends the entries of the Common Logger to a remote destination, e.g. the LokiHook. The entries are
+// shipped after the filters of the Logger (the deduplication, the sampling and the PII masking) while they are
+// written, so Ship must not block, e.g. it buffers the entries and sends them in the background.
+// The Shippers implementing Flush() error are flushed by Logger.Sync, the io.Closer ones are closed by Logger.Close.
+type Shipper interface {
+	// Levels returns the levels of the shipped entries.
+	Levels() []logrus.Level
+
+	// Ship sends the entry, encoded is the entry encoded as a JSON line (without the newline) with the keys and
+	// the timestamp format of the Logger, see WithJSONFormat. encoded is shared by the Shippers, it must not be
+	// modified.
+	Ship(entry *logrus.Entry, encoded []byte) error
+}
+
+// ShipperFactory creates a Shipper of the Common Logger from its configuration, it returns nil if the Shipper
+// is not configured, e.g. its address is not set.
+type ShipperFactory func(serviceName, serviceVersion string, conf ConfigGetter) (Shipper, error)
+
+// WithShippers adds the Shippers created by the factories to the Common Logger,
+// e.g. WithShippers(cloudwatchhook.FromConfiguration).
+func WithShippers(factories ...ShipperFactory) LoggerOption {
+	return func(o *loggerOptions) {
+		o.shipperFactories = append(o.shipperFactories, factories...)
+	}
+}
+
+// pipeline filters the entries of the Common Logger, and passes the admitted ones to the shippers before they
+// are written, so every output gets the same filtered entries.
+type pipeline struct {
+	filters  []entryFilter
+	encoder  logrus.Formatter
+	shippers map[logrus.Level][]Shipper
+}
+
+// newPipeline creates a new pipeline of the filters, shipping the entries encoded by encoder.
+func newPipeline(filters []entryFilter, encoder logrus.Formatter, shippers []Shipper) pipeline {
+	p := pipeline{
+		filters:  filters,
+		encoder:  encoder,
+		shippers: map[logrus.Level][]Shipper{},
+	}
+	for _, shipper := range shippers {
+		for _, level := range shipper.Levels() {
+			p.shippers[level] = append(p.shippers[level], shipper)
+		}
+	}
+	return p
+}
+
+// process returns whether the filters admit the entry, and ships the admitted entries. The errors of the
+// shippers are written to stderr, the same as the errors of the Logrus hooks.
+func (p *pipeline) process(entry *logrus.Entry) bool {
+	if !admitAll(p.filters, entry) {
+		return false
+	}
+	shippers := p.shippers[entry.Level]
+	if len(shippers) == 0 {
+		return true
+	}
+	// The formatters write into the buffer of the entry, which holds the output of the Logger
+	buffer := entry.Buffer
+	entry.Buffer = nil
+	encoded, err := p.encoder.Format(entry)
+	entry.Buffer = buffer
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to encode the entry for shipping: %v\n", err)
+		return true
+	}
+	encoded = bytes.TrimSuffix(encoded, []byte("\n"))
+	for _, shipper := range shippers {
+		if err := shipper.Ship(entry, encoded); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to ship the entry: %v\n", err)
+		}
+	}
+	return true
+}
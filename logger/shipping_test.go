@@ -0,0 +1,94 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"strings"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// recordingShipper records the shipped entries
+type recordingShipper struct {
+	mu      sync.Mutex
+	encoded []string
+	flushed int
+	closed  bool
+}
+
+func (r *recordingShipper) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (r *recordingShipper) Ship(entry *logrus.Entry, encoded []byte) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.encoded = append(r.encoded, string(encoded))
+	return nil
+}
+
+func (r *recordingShipper) Flush() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.flushed++
+	return nil
+}
+
+func (r *recordingShipper) Close() error {
+	r.closed = true
+	return nil
+}
+
+// shippingLogger returns a Logrus logger shipping its entries to the shippers, encoded by BasicJSONFormatter.
+func shippingLogger(shippers ...Shipper) *logrus.Logger {
+	l := logrus.New()
+	l.SetOutput(io.Discard)
+	l.SetFormatter(&filteringFormatter{pipeline: newPipeline(nil, BasicJSONFormatter, shippers), formatter: BasicJSONFormatter})
+	return l
+}
+
+// ship ships the entry to the shipper, encoded by BasicJSONFormatter.
+func ship(shipper Shipper, entry *logrus.Entry) error {
+	encoded, err := BasicJSONFormatter.Format(entry)
+	if err != nil {
+		return err
+	}
+	return shipper.Ship(entry, bytes.TrimSuffix(encoded, []byte("\n")))
+}
+
+func (ls *LoggerSuite) TestShippers() {
+	shipper := &recordingShipper{}
+	output := &strings.Builder{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_ENV:                  constants.ENV_TEST,
+		constants.APP_LOG_MASK_PII:         "true",
+		constants.APP_LOG_DEDUP_WINDOW:     "1m",
+		constants.APP_LOG_MESSAGE_KEY:      "message",
+		constants.APP_LOG_TIMESTAMP_FORMAT: TimestampEpoch,
+	}, WithShippers(func(serviceName, serviceVersion string, conf ConfigGetter) (Shipper, error) {
+		ls.Equal("test-service", serviceName)
+		ls.Equal(constants.ENV_TEST, conf.Get(constants.APP_ENV))
+		return shipper, nil
+	}, func(string, string, ConfigGetter) (Shipper, error) {
+		return nil, nil
+	}))
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+
+	for i := 0; i < 3; i++ {
+		commonLog.Entry().Info("Invoice sent to john.doe@example.com")
+	}
+	ls.Require().Len(shipper.encoded, 1, "The duplicates should not be shipped")
+	shipped := logrus.Fields{}
+	ls.NoError(json.Unmarshal([]byte(shipper.encoded[0]), &shipped))
+	ls.Equal("Invoice sent to *****", shipped["message"], "The shipped entries should be masked and use the JSON keys")
+	ls.IsType(float64(0), shipped[logrus.FieldKeyTime], "The shipped entries should use the timestamp format")
+	ls.Equal(shipper.encoded[0]+"\n", output.String(), "The output should be the same entry")
+
+	ls.NoError(commonLog.NewComponentLogger("worker").Close())
+	ls.Equal(1, shipper.flushed)
+	ls.True(shipper.closed, "The shippers should be closed with the Logger")
+}
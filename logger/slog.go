@@ -0,0 +1,199 @@
+package logger
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+)
+
+// slogHandler is the slog.Handler of a Logger, see SlogHandler.
+type slogHandler struct {
+	logger *Logger
+	fields logrus.Fields
+	group  string
+}
+
+// SlogHandler returns an slog.Handler routing the records through the Logger, so the libraries logging with
+// log/slog get the default fields, the hooks and the formatting of the Logger, e.g.
+// slog.SetDefault(slog.New(commonLog.SlogHandler())). The slog levels are mapped to the nearest Logrus level,
+// the attributes of the groups are prefixed by the group names, e.g. request.method.
+func (l *Logger) SlogHandler() slog.Handler {
+	return &slogHandler{logger: l, fields: logrus.Fields{}}
+}
+
+// Enabled implements the slog.Handler interface, by the level of the Logger.
+func (h *slogHandler) Enabled(_ context.Context, level slog.Level) bool {
+	return h.logger.isLevelEnabled(logrusLevel(level))
+}
+
+// Handle implements the slog.Handler interface.
+func (h *slogHandler) Handle(ctx context.Context, record slog.Record) error {
+	fields := make(logrus.Fields, len(h.fields)+record.NumAttrs())
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+	record.Attrs(func(attr slog.Attr) bool {
+		addAttr(fields, h.group, attr)
+		return true
+	})
+	entry := h.logger.WithFields(fields)
+	if ctx != nil {
+		entry = entry.WithContext(ctx)
+	}
+	if !record.Time.IsZero() {
+		entry = entry.WithTime(record.Time)
+	}
+	entry.Log(logrusLevel(record.Level), record.Message)
+	return nil
+}
+
+// WithAttrs implements the slog.Handler interface.
+func (h *slogHandler) WithAttrs(attrs []slog.Attr) slog.Handler {
+	fields := make(logrus.Fields, len(h.fields)+len(attrs))
+	for key, value := range h.fields {
+		fields[key] = value
+	}
+	for _, attr := range attrs {
+		addAttr(fields, h.group, attr)
+	}
+	return &slogHandler{logger: h.logger, fields: fields, group: h.group}
+}
+
+// WithGroup implements the slog.Handler interface.
+func (h *slogHandler) WithGroup(name string) slog.Handler {
+	if name == "" {
+		return h
+	}
+	return &slogHandler{logger: h.logger, fields: h.fields, group: groupKey(h.group, name)}
+}
+
+// addAttr adds the slog attribute to fields, the attributes of the groups are flattened.
+func addAttr(fields logrus.Fields, group string, attr slog.Attr) {
+	value := attr.Value.Resolve()
+	if value.Kind() == slog.KindGroup {
+		// The attributes of a nested group are prefixed by its key, or inlined if it has no key
+		for _, groupAttr := range value.Group() {
+			addAttr(fields, groupKey(group, attr.Key), groupAttr)
+		}
+		return
+	}
+	if attr.Key == "" {
+		return
+	}
+	fields[groupKey(group, attr.Key)] = value.Any()
+}
+
+// groupKey returns the key of an attribute in the group.
+func groupKey(group, key string) string {
+	if group == "" {
+		return key
+	}
+	if key == "" {
+		return group
+	}
+	return group + "." + key
+}
+
+// logrusLevel returns the Logrus level of an slog level.
+func logrusLevel(level slog.Level) logrus.Level {
+	switch {
+	case level >= slog.LevelError:
+		return logrus.ErrorLevel
+	case level >= slog.LevelWarn:
+		return logrus.WarnLevel
+	case level >= slog.LevelInfo:
+		return logrus.InfoLevel
+	case level >= slog.LevelDebug:
+		return logrus.DebugLevel
+	default:
+		return logrus.TraceLevel
+	}
+}
+
+// slogLevel returns the slog level of a Logrus level, the fatal and panic levels are above the error level.
+func slogLevel(level logrus.Level) slog.Level {
+	switch level {
+	case logrus.PanicLevel:
+		return slog.LevelError + 8
+	case logrus.FatalLevel:
+		return slog.LevelError + 4
+	case logrus.ErrorLevel:
+		return slog.LevelError
+	case logrus.WarnLevel:
+		return slog.LevelWarn
+	case logrus.InfoLevel:
+		return slog.LevelInfo
+	case logrus.DebugLevel:
+		return slog.LevelDebug
+	default:
+		return slog.LevelDebug - 4
+	}
+}
+
+// isLevelEnabled returns whether the entries of level are logged by the Logger.
+func (l *Logger) isLevelEnabled(level logrus.Level) bool {
+	if log := l.logrusLogger(); log != nil {
+		return log.IsLevelEnabled(level)
+	}
+	return true
+}
+
+// slogBackend is the Backend of SlogBackend.
+type slogBackend struct {
+	handler slog.Handler
+}
+
+// SlogBackend returns a BackendFactory writing the entries to slogger, so a Logger can be used by the
+// applications (or the tests) logging with log/slog. The fields of the entries are the attributes of the
+// records, the levels are filtered by the handler of slogger.
+func SlogBackend(slogger *slog.Logger) BackendFactory {
+	return func(io.Writer, bool) Backend {
+		return &slogBackend{handler: slogger.Handler()}
+	}
+}
+
+// NewSlogLogger creates a new Logger with the supplied default fields, which writes its entries to slogger
+// (see SlogBackend).
+func NewSlogLogger(slogger *slog.Logger, defaultFields logrus.Fields) *Logger {
+	log := logrus.New()
+	// The levels are filtered by the handler of slogger
+	log.SetLevel(logrus.TraceLevel)
+	commonLog := NewLogger(log, defaultFields)
+	commonLog.backend = SlogBackend(slogger)(nil, false)
+	useBackend(log, commonLog.backend)
+	return commonLog
+}
+
+// Write implements the Backend interface.
+func (backend *slogBackend) Write(entry *logrus.Entry) error {
+	ctx := entry.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	level := slogLevel(entry.Level)
+	if !backend.handler.Enabled(ctx, level) {
+		return nil
+	}
+	var pc uintptr
+	if entry.HasCaller() {
+		pc = entry.Caller.PC
+	}
+	record := slog.NewRecord(entry.Time, level, entry.Message, pc)
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		record.AddAttrs(slog.Any(key, entry.Data[key]))
+	}
+	return backend.handler.Handle(ctx, record)
+}
+
+// Sync implements the Backend interface, the records are not buffered.
+func (backend *slogBackend) Sync() error {
+	return nil
+}
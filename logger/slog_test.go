@@ -0,0 +1,58 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+
+	"github.com/sirupsen/logrus"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func (ls *LoggerSuite) TestSlogHandler() {
+	l, hook := logrusTest.NewNullLogger()
+	l.SetLevel(logrus.InfoLevel)
+	log := NewLogger(l, logrus.Fields{"service": "test-service"})
+	slogger := slog.New(log.SlogHandler())
+
+	slogger.Debug("Filtered by the level")
+	ls.Nil(hook.LastEntry(), "Disabled levels should not be logged")
+
+	slogger.With("component", "worker").WithGroup("request").Warn("Slow request", "method", "GET", slog.Group("client", "ip", "10.0.0.1"))
+	entry := hook.LastEntry()
+	ls.Require().NotNil(entry)
+	ls.Equal(logrus.WarnLevel, entry.Level)
+	ls.Equal("Slow request", entry.Message)
+	ls.Equal(logrus.Fields{
+		"service":           "test-service",
+		"component":         "worker",
+		"request.method":    "GET",
+		"request.client.ip": "10.0.0.1",
+	}, entry.Data)
+
+	ctx := context.WithValue(context.Background(), struct{}{}, "value")
+	slogger.ErrorContext(ctx, "Failed", "attempt", 3)
+	ls.Equal(logrus.ErrorLevel, hook.LastEntry().Level)
+	ls.Equal(ctx, hook.LastEntry().Context, "The context should be passed to the hooks")
+	ls.Equal(int64(3), hook.LastEntry().Data["attempt"])
+}
+
+func (ls *LoggerSuite) TestSlogLogger() {
+	output := &bytes.Buffer{}
+	slogger := slog.New(slog.NewJSONHandler(output, &slog.HandlerOptions{Level: slog.LevelInfo}))
+	log := NewSlogLogger(slogger, logrus.Fields{"service": "test-service"}).NewComponentLogger("worker")
+
+	log.Entry().Debug("Filtered by the handler")
+	ls.Empty(output.String(), "The levels should be filtered by the handler")
+
+	log.WithField("attempt", 3).Warn("Written by slog")
+	ls.NoError(log.Sync())
+	record := map[string]interface{}{}
+	ls.NoError(json.Unmarshal(output.Bytes(), &record), "Record should be encoded by the slog handler")
+	ls.Equal("WARN", record[slog.LevelKey])
+	ls.Equal("Written by slog", record[slog.MessageKey])
+	ls.Equal("test-service", record["service"])
+	ls.Equal("worker", record["component"])
+	ls.Equal(float64(3), record["attempt"])
+}
@@ -0,0 +1,209 @@
+package logger
+
+import (
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+)
+
+// SyslogStructuredDataID is the SD-ID of the structured data element holding the fields of the entries.
+const SyslogStructuredDataID = "fields@32473"
+
+// syslogFacility is the local0 facility of the syslog messages.
+const syslogFacility = 16
+
+// syslogSocketPaths are the local syslog sockets, in the order of preference.
+var syslogSocketPaths = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+// syslogSeverities are the syslog severities of the Logrus levels.
+var syslogSeverities = map[logrus.Level]int{
+	logrus.PanicLevel: 0,
+	logrus.FatalLevel: 2,
+	logrus.ErrorLevel: 3,
+	logrus.WarnLevel:  4,
+	logrus.InfoLevel:  6,
+	logrus.DebugLevel: 7,
+	logrus.TraceLevel: 7,
+}
+
+// SyslogHook is a Shipper which sends the entries to a syslog server in the RFC 5424 format, with the
+// fields of the entries (including the common service, version, env and host fields) as structured data.
+// The entries are sent while the Logger holds its lock, so the connections and the writes time out after a
+// second, and the entries are dropped for a few seconds after a failed reconnect.
+type SyslogHook struct {
+	network   string
+	address   string
+	tlsConfig *tls.Config
+	appName   string
+	hostname  string
+
+	mu   sync.Mutex
+	conn dialedConn
+}
+
+// NewSyslogHook creates a new SyslogHook and connects to the syslog server at addr:
+//   - local: the local syslog socket (/dev/log, /var/run/syslog or /var/run/log)
+//   - udp://host:port: a remote server over UDP
+//   - tcp://host:port: a remote server over TCP, with octet-counting framing
+//   - tls://host:port: a remote server over TLS (RFC 5425), verified by tlsConfig (the system roots if nil)
+//
+// appName is the APP-NAME of the messages, e.g. the service name.
+func NewSyslogHook(addr, appName string, tlsConfig *tls.Config) (*SyslogHook, error) {
+	hook := &SyslogHook{appName: appName, tlsConfig: tlsConfig}
+	if addr == "local" {
+		hook.network = "unixgram"
+	} else {
+		parsed, err := url.Parse(addr)
+		if err != nil {
+			return nil, errors.Wrapf(err, "Invalid syslog address %s", addr)
+		}
+		switch parsed.Scheme {
+		case "udp", "tcp", "tls":
+		default:
+			return nil, errors.Errorf("Invalid syslog address %s: the scheme must be udp, tcp or tls", addr)
+		}
+		hook.network, hook.address = parsed.Scheme, parsed.Host
+	}
+	hook.hostname, _ = os.Hostname()
+	hook.conn = dialedConn{name: "syslog", dial: hook.dial}
+
+	if err := hook.conn.connect(); err != nil {
+		return nil, err
+	}
+	return hook, nil
+}
+
+// dial connects to the syslog server by dialer.
+func (hook *SyslogHook) dial(dialer *net.Dialer) (net.Conn, error) {
+	switch hook.network {
+	case "unixgram":
+		var err error
+		for _, path := range syslogSocketPaths {
+			var conn net.Conn
+			if conn, err = dialer.Dial("unixgram", path); err == nil {
+				return conn, nil
+			}
+			if conn, err = dialer.Dial("unix", path); err == nil {
+				return conn, nil
+			}
+		}
+		return nil, err
+	case "tls":
+		return tls.DialWithDialer(dialer, "tcp", hook.address, hook.tlsConfig)
+	default:
+		return dialer.Dial(hook.network, hook.address)
+	}
+}
+
+// Levels implements the Shipper interface, every level is shipped.
+func (hook *SyslogHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Ship implements the Shipper interface, the entry is sent as an RFC 5424 message. The entry is sent again after
+// a reconnect if the connection was lost.
+func (hook *SyslogHook) Ship(entry *logrus.Entry, _ []byte) error {
+	message := hook.format(entry)
+	if hook.network == "tcp" || hook.network == "tls" {
+		// Octet-counting framing of RFC 6587 and RFC 5425
+		message = strconv.Itoa(len(message)) + " " + message
+	}
+
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	return hook.conn.write([]byte(message), true)
+}
+
+// Close closes the connection to the syslog server.
+func (hook *SyslogHook) Close() error {
+	hook.mu.Lock()
+	defer hook.mu.Unlock()
+	return hook.conn.close()
+}
+
+// format formats the entry as an RFC 5424 syslog message.
+func (hook *SyslogHook) format(entry *logrus.Entry) string {
+	severity, ok := syslogSeverities[entry.Level]
+	if !ok {
+		severity = syslogSeverities[logrus.InfoLevel]
+	}
+	hostname := hook.hostname
+	if host, ok := entry.Data["host"].(string); ok && host != "" {
+		hostname = host
+	}
+	timestamp := entry.Time
+	if timestamp.IsZero() {
+		timestamp = time.Now()
+	}
+
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "<%d>1 %s %s %s %d - ", syslogFacility*8+severity,
+		timestamp.Format("2006-01-02T15:04:05.000000Z07:00"), syslogHeader(hostname, 255),
+		syslogHeader(hook.appName, 48), os.Getpid())
+	if len(entry.Data) == 0 {
+		builder.WriteString("-")
+	} else {
+		keys := make([]string, 0, len(entry.Data))
+		for key := range entry.Data {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		builder.WriteString("[" + SyslogStructuredDataID)
+		for _, key := range keys {
+			fmt.Fprintf(&builder, ` %s="%s"`, syslogParamName(key), syslogParamValue(fmt.Sprint(entry.Data[key])))
+		}
+		builder.WriteString("]")
+	}
+	builder.WriteString(" " + entry.Message)
+	return builder.String()
+}
+
+// syslogHeader returns value as a header field of at most maxLen printable ASCII characters, - if empty.
+func syslogHeader(value string, maxLen int) string {
+	value = strings.Map(func(r rune) rune {
+		if r <= ' ' || r > '~' {
+			return -1
+		}
+		return r
+	}, value)
+	if value == "" {
+		return "-"
+	}
+	if len(value) > maxLen {
+		return value[:maxLen]
+	}
+	return value
+}
+
+// syslogParamName returns the field name as a PARAM-NAME, without the characters not allowed by RFC 5424.
+func syslogParamName(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '=' || r == ']' || r == '"' {
+			return '_'
+		}
+		return r
+	}, syslogHeader(name, 32))
+}
+
+// syslogParamValue escapes the characters of a PARAM-VALUE, as required by RFC 5424.
+func syslogParamValue(value string) string {
+	return strings.NewReplacer(`\`, `\\`, `"`, `\"`, `]`, `\]`).Replace(value)
+}
+
+// WithSyslogTLSConfig sets the TLS configuration of the tls:// syslog addresses of APP_LOG_SYSLOG_ADDR,
+// e.g. the CA of the syslog server or a client certificate.
+func WithSyslogTLSConfig(tlsConfig *tls.Config) LoggerOption {
+	return func(o *loggerOptions) {
+		o.syslogTLSConfig = tlsConfig
+	}
+}
@@ -0,0 +1,116 @@
+package logger
+
+import (
+	"bufio"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"io"
+	"math/big"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+// readFrame reads an octet-counted syslog message.
+func (ls *LoggerSuite) readFrame(reader *bufio.Reader) string {
+	length, err := reader.ReadString(' ')
+	ls.Require().NoError(err)
+	size, err := strconv.Atoi(strings.TrimSpace(length))
+	ls.Require().NoError(err)
+	message := make([]byte, size)
+	_, err = io.ReadFull(reader, message)
+	ls.Require().NoError(err)
+	return string(message)
+}
+
+// selfSignedTLSConfig returns the server and the client TLS configurations of a self-signed certificate.
+func (ls *LoggerSuite) selfSignedTLSConfig() (*tls.Config, *tls.Config) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	ls.Require().NoError(err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		IPAddresses:  []net.IP{net.ParseIP("127.0.0.1")},
+		NotBefore:    time.Now(),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	ls.Require().NoError(err)
+	cert, err := x509.ParseCertificate(der)
+	ls.Require().NoError(err)
+	roots := x509.NewCertPool()
+	roots.AddCert(cert)
+	return &tls.Config{Certificates: []tls.Certificate{{Certificate: [][]byte{der}, PrivateKey: key}}},
+		&tls.Config{RootCAs: roots}
+}
+
+func (ls *LoggerSuite) TestSyslogHookTCP() {
+	serverConf, clientConf := ls.selfSignedTLSConfig()
+	for _, scheme := range []string{"tcp", "tls"} {
+		listener, err := net.Listen("tcp", "127.0.0.1:0")
+		ls.Require().NoError(err)
+		if scheme == "tls" {
+			listener = tls.NewListener(listener, serverConf)
+		}
+		accepted := make(chan net.Conn, 1)
+		go func() {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			if tlsConn, ok := conn.(*tls.Conn); ok {
+				// The client waits for the handshake while connecting
+				_ = tlsConn.Handshake()
+			}
+			accepted <- conn
+		}()
+
+		commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+			constants.APP_ENV:             constants.ENV_TEST,
+			constants.APP_LOG_SYSLOG_ADDR: scheme + "://" + listener.Addr().String(),
+		}, WithSyslogTLSConfig(clientConf))
+		commonLog.log.(*logrus.Logger).SetOutput(&strings.Builder{})
+		commonLog.WithField("quote", `say "hi"]`).Warn("Disk almost full")
+
+		conn := <-accepted
+		ls.Require().NoError(conn.SetReadDeadline(time.Now().Add(5 * time.Second)))
+		message := ls.readFrame(bufio.NewReader(conn))
+		pattern := regexp.MustCompile(fmt.Sprintf(`^<132>1 \d{4}-\d\d-\d\dT\d\d:\d\d:\d\d\.\d{6}\S+ \S+ test-service %d - `+
+			`\[fields@32473 env="test" host="\S+" quote="say \\"hi\\"\\]" service="test-service" version="v1\.2\.3"\] Disk almost full$`, os.Getpid()))
+		ls.Regexp(pattern, message, "Message should be formatted by RFC 5424 over %s", scheme)
+		ls.NoError(conn.Close())
+		ls.NoError(listener.Close())
+	}
+}
+
+func (ls *LoggerSuite) TestSyslogHookUDP() {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	ls.Require().NoError(err)
+	defer server.Close()
+
+	hook, err := NewSyslogHook("udp://"+server.LocalAddr().String(), "worker", nil)
+	ls.Require().NoError(err)
+	defer hook.Close()
+	ls.NoError(ship(hook, &logrus.Entry{Level: logrus.ErrorLevel, Message: "Failed", Data: logrus.Fields{}}))
+
+	buffer := make([]byte, 2048)
+	ls.Require().NoError(server.SetReadDeadline(time.Now().Add(5 * time.Second)))
+	n, _, err := server.ReadFrom(buffer)
+	ls.Require().NoError(err)
+	ls.Regexp(`^<131>1 \S+ \S+ worker \d+ - - Failed$`, string(buffer[:n]), "UDP messages should not be framed")
+
+	_, err = NewSyslogHook("http://localhost:514", "worker", nil)
+	ls.EqualError(err, "Invalid syslog address http://localhost:514: the scheme must be udp, tcp or tls")
+}
@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+// TraceIDField and SpanIDField are the fields of the trace and the span of the entries, in hexadecimal, and
+// TraceSampledField whether the trace is sampled, e.g. added by the otelhook from the OpenTelemetry span in the
+// context of the entry.
+const (
+	TraceIDField      = "trace_id"
+	SpanIDField       = "span_id"
+	TraceSampledField = "trace_sampled"
+)
+
+// WithContext creates a new log entry with the default fields and ctx, e.g. the otelhook added by WithHooks adds
+// the trace_id and span_id fields of the OpenTelemetry span in ctx to the entry.
+func (l *Logger) WithContext(ctx context.Context) *logrus.Entry {
+	return l.log.WithFields(l.defaultFields).WithContext(ctx)
+}
@@ -0,0 +1,33 @@
+package logger
+
+import (
+	"github.com/sirupsen/logrus"
+)
+
+// UTCHook is a Logrus Hook which converts the timestamps of the entries to UTC, so the entries of the hosts in
+// different timezones are comparable regardless of the timezone of the host. It is the first hook of the Common
+// Logger, so the formatters, the Backends and the other hooks get the UTC timestamps.
+type UTCHook struct{}
+
+// NewUTCHook creates a new UTCHook, it is added to the Common Logger if APP_LOG_UTC is enabled, or by WithUTC.
+func NewUTCHook() *UTCHook {
+	return &UTCHook{}
+}
+
+// Levels implements the logrus.Hook interface, the hook is fired on all levels.
+func (hook *UTCHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+// Fire implements the logrus.Hook interface.
+func (hook *UTCHook) Fire(entry *logrus.Entry) error {
+	entry.Time = entry.Time.UTC()
+	return nil
+}
+
+// WithUTC adds the UTCHook to the Common Logger, the same as enabling APP_LOG_UTC.
+func WithUTC() LoggerOption {
+	return func(o *loggerOptions) {
+		o.utc = true
+	}
+}
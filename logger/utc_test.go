@@ -0,0 +1,42 @@
+package logger
+
+import (
+	"bytes"
+	"encoding/json"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+)
+
+func (ls *LoggerSuite) TestUTCHook() {
+	output := &bytes.Buffer{}
+	commonLog := NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_UTC: "true",
+	})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	timestamp := time.Date(2024, 1, 31, 14, 0, 0, 0, time.FixedZone("CET", 2*60*60))
+	commonLog.Entry().WithTime(timestamp).Info("Order paid")
+
+	fields := logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields), "Entry should be logged in JSON")
+	ls.Equal("2024-01-31T12:00:00Z", fields["time"], "Timestamp should be in UTC")
+
+	output.Reset()
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{})
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().WithTime(timestamp).Info("Order paid")
+	fields = logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("2024-01-31T14:00:00+02:00", fields["time"], "Timezone should be kept without APP_LOG_UTC")
+
+	output.Reset()
+	commonLog = NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{}, WithUTC(), WithCallerFormatter(RelativeCaller))
+	ls.IsType(&UTCHook{}, commonLog.log.(*logrus.Logger).Hooks[logrus.InfoLevel][0], "The UTCHook should be the first hook")
+	commonLog.log.(*logrus.Logger).SetOutput(output)
+	commonLog.Entry().WithTime(timestamp).Info("Order paid")
+	fields = logrus.Fields{}
+	ls.Require().NoError(json.Unmarshal(output.Bytes(), &fields))
+	ls.Equal("2024-01-31T12:00:00Z", fields["time"])
+}
@@ -0,0 +1,115 @@
+// Package zapbackend provides a logger.Backend which encodes and writes the entries of the Common Logger with zap.
+// Use logger.WithBackend(zapbackend.New) to write the entries of the Common Logger with zap
+package zapbackend
+
+import (
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/logger"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// traceLevel is the zap level of the Logrus trace level, below the zap debug level.
+const traceLevel = zapcore.DebugLevel - 1
+
+// backend is the logger.Backend of New.
+type backend struct {
+	core zapcore.Core
+}
+
+// New is a logger.BackendFactory encoding and writing the entries with zap, e.g. for the services whose other
+// logs are written by zap. The entries are still created by Logrus, so the Backend is not faster than the Logrus
+// formatters.
+// The entries have the same fields as the ones of the Logrus formatters (time, level, msg and the caller
+// in the func and file fields), with the JSON encoder, or with the console encoder in dev mode.
+func New(output io.Writer, dev bool) logger.Backend {
+	encoderConf := zapcore.EncoderConfig{
+		TimeKey:        logrus.FieldKeyTime,
+		LevelKey:       logrus.FieldKeyLevel,
+		MessageKey:     logrus.FieldKeyMsg,
+		LineEnding:     zapcore.DefaultLineEnding,
+		EncodeLevel:    encodeLevel,
+		EncodeTime:     zapcore.RFC3339TimeEncoder,
+		EncodeDuration: zapcore.StringDurationEncoder,
+	}
+	encoder := zapcore.NewJSONEncoder(encoderConf)
+	if dev {
+		encoder = zapcore.NewConsoleEncoder(encoderConf)
+	}
+	// The entries are already filtered by the level of the Logger
+	enabled := zap.LevelEnablerFunc(func(zapcore.Level) bool { return true })
+	return &backend{core: zapcore.NewCore(encoder, zapcore.AddSync(output), enabled)}
+}
+
+// Write implements the logger.Backend interface.
+func (b *backend) Write(entry *logrus.Entry) error {
+	keys := make([]string, 0, len(entry.Data))
+	for key := range entry.Data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	fields := make([]zapcore.Field, 0, len(keys)+2)
+	if entry.HasCaller() {
+		fields = append(fields,
+			zap.String(logrus.FieldKeyFunc, entry.Caller.Function),
+			zap.String(logrus.FieldKeyFile, fmt.Sprintf("%s:%d", entry.Caller.File, entry.Caller.Line)),
+		)
+	}
+	for _, key := range keys {
+		fields = append(fields, zap.Any(key, entry.Data[key]))
+	}
+	return b.core.Write(zapcore.Entry{
+		Level:   zapLevel(entry.Level),
+		Time:    entry.Time,
+		Message: entry.Message,
+	}, fields)
+}
+
+// Sync implements the logger.Backend interface.
+func (b *backend) Sync() error {
+	return b.core.Sync()
+}
+
+// zapLevel returns the zap level of a Logrus level.
+func zapLevel(level logrus.Level) zapcore.Level {
+	switch level {
+	case logrus.PanicLevel:
+		return zapcore.PanicLevel
+	case logrus.FatalLevel:
+		return zapcore.FatalLevel
+	case logrus.ErrorLevel:
+		return zapcore.ErrorLevel
+	case logrus.WarnLevel:
+		return zapcore.WarnLevel
+	case logrus.InfoLevel:
+		return zapcore.InfoLevel
+	case logrus.DebugLevel:
+		return zapcore.DebugLevel
+	default:
+		return traceLevel
+	}
+}
+
+// encodeLevel encodes the zap levels with the names of the Logrus levels, e.g. warning instead of warn.
+func encodeLevel(level zapcore.Level, enc zapcore.PrimitiveArrayEncoder) {
+	switch level {
+	case zapcore.PanicLevel, zapcore.DPanicLevel:
+		enc.AppendString(logrus.PanicLevel.String())
+	case zapcore.FatalLevel:
+		enc.AppendString(logrus.FatalLevel.String())
+	case zapcore.ErrorLevel:
+		enc.AppendString(logrus.ErrorLevel.String())
+	case zapcore.WarnLevel:
+		enc.AppendString(logrus.WarnLevel.String())
+	case zapcore.InfoLevel:
+		enc.AppendString(logrus.InfoLevel.String())
+	case zapcore.DebugLevel:
+		enc.AppendString(logrus.DebugLevel.String())
+	default:
+		enc.AppendString(logrus.TraceLevel.String())
+	}
+}
@@ -0,0 +1,71 @@
+package zapbackend
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/config"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// ZapBackendSuite extends testify's Suite.
+type ZapBackendSuite struct {
+	suite.Suite
+}
+
+func (zs *ZapBackendSuite) TestBackend() {
+	filename := filepath.Join(zs.T().TempDir(), "app.log")
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FILE:  filename,
+		constants.APP_LOG_LEVEL: constants.LOG_LEVEL_WARN,
+		constants.APP_ENV:       constants.ENV_TEST,
+	}, logger.WithBackend(New))
+	componentLog := commonLog.NewComponentLogger("worker")
+	componentLog.Entry().Info("Filtered by the level")
+	componentLog.WithField("attempt", 3).WithError(errors.New("timeout")).Warn("Written by zap")
+	zs.NoError(componentLog.Sync())
+
+	content, err := ioutil.ReadFile(filename)
+	zs.NoError(err, "Log file should have been created")
+	lines := strings.Split(strings.TrimSpace(string(content)), "\n")
+	zs.Require().Len(lines, 1, "Only the entries of the enabled levels should be written")
+	fields := logrus.Fields{}
+	zs.NoError(json.Unmarshal([]byte(lines[0]), &fields), "Entry should be encoded in JSON")
+	zs.Equal("Written by zap", fields[logrus.FieldKeyMsg])
+	zs.Equal("warning", fields[logrus.FieldKeyLevel], "Levels should be named like the Logrus levels")
+	zs.NotEmpty(fields[logrus.FieldKeyTime])
+	zs.Equal("test-service", fields["service"], "Default fields should be kept")
+	zs.Equal("v1.2.3", fields["version"])
+	zs.Equal(constants.ENV_TEST, fields["env"])
+	zs.Equal("worker", fields["component"])
+	zs.Equal(float64(3), fields["attempt"])
+	zs.Equal("timeout", fields["error"])
+}
+
+func (zs *ZapBackendSuite) TestDev() {
+	filename := filepath.Join(zs.T().TempDir(), "app.log")
+	commonLog := logger.NewCommonLoggerFromConfiguration("test-service", "v1.2.3", config.StaticGetter{
+		constants.APP_LOG_FILE: filename,
+		constants.APP_LOG_DEV:  "true",
+		constants.APP_DEBUG:    "true",
+	}, logger.WithBackend(New))
+	commonLog.Entry().Info("Human readable")
+	zs.NoError(commonLog.Sync())
+
+	content, err := ioutil.ReadFile(filename)
+	zs.NoError(err)
+	zs.Contains(string(content), "\tinfo\tHuman readable\t")
+	zs.Contains(string(content), `"file":`, "Debug mode should report the caller")
+	zs.Contains(string(content), `"service": "test-service"`)
+}
+
+func TestZapBackend(t *testing.T) {
+	suite.Run(t, new(ZapBackendSuite))
+}
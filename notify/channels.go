@@ -0,0 +1,190 @@
+package notify
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net"
+	"net/http"
+	"net/smtp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/pkg/errors"
+)
+
+// WebhookChannel posts the Events as JSON to a generic webhook URL.
+type WebhookChannel struct {
+	// URL is the endpoint of the webhook.
+	URL string
+
+	// Headers are added to every request, e.g. an Authorization header.
+	Headers map[string]string
+
+	// Client is the HTTP client used for the requests, http.DefaultClient if nil.
+	Client *http.Client
+}
+
+// Name implements the Channel interface.
+func (w *WebhookChannel) Name() string {
+	return "webhook"
+}
+
+// Send implements the Channel interface. Any non 2xx response is an error.
+func (w *WebhookChannel) Send(ctx context.Context, event Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return errors.Wrap(err, "Failed to marshal event")
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return errors.Wrap(err, "Failed to create webhook request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	for key, value := range w.Headers {
+		req.Header.Set(key, value)
+	}
+
+	client := w.Client
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "Failed to call webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return errors.Errorf("Webhook responded with status code %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// sendMail is the function used to send emails, replaced in tests.
+var sendMail = smtp.SendMail
+
+// SMTPChannel sends the Events as plain text emails.
+type SMTPChannel struct {
+	// Addr is the address of the SMTP server (host:port).
+	Addr string
+
+	// From is the sender address.
+	From string
+
+	// To are the recipient addresses.
+	To []string
+
+	// Username and Password are used for PLAIN authentication if Username is set.
+	Username string
+	Password string
+}
+
+// Name implements the Channel interface.
+func (s *SMTPChannel) Name() string {
+	return "smtp"
+}
+
+// Send implements the Channel interface. The context is only checked before sending,
+// net/smtp does not support cancellation.
+func (s *SMTPChannel) Send(ctx context.Context, event Event) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(s.To) == 0 {
+		return errors.New("No email recipient is configured")
+	}
+	for _, address := range append([]string{s.From}, s.To...) {
+		if strings.ContainsAny(address, "\r\n") {
+			return errors.Errorf("Invalid email address %q", address)
+		}
+	}
+
+	var auth smtp.Auth
+	if s.Username != "" {
+		host, _, err := net.SplitHostPort(s.Addr)
+		if err != nil {
+			return errors.Wrapf(err, "Invalid SMTP address %s", s.Addr)
+		}
+		auth = smtp.PlainAuth("", s.Username, s.Password, host)
+	}
+
+	if err := sendMail(s.Addr, auth, s.From, s.To, s.message(event)); err != nil {
+		return errors.Wrap(err, "Failed to send email")
+	}
+	return nil
+}
+
+// message renders the Event into an email.
+func (s *SMTPChannel) message(event Event) []byte {
+	msg := &strings.Builder{}
+	fmt.Fprintf(msg, "From: %s\r\n", s.From)
+	fmt.Fprintf(msg, "To: %s\r\n", strings.Join(s.To, ", "))
+	fmt.Fprintf(msg, "Subject: %s\r\n", headerValue(subject(event)))
+	fmt.Fprintf(msg, "Date: %s\r\n", event.Time.Format(time.RFC1123Z))
+	msg.WriteString("Content-Type: text/plain; charset=UTF-8\r\n\r\n")
+	msg.WriteString(body(event))
+	return []byte(msg.String())
+}
+
+// headerValue replaces the line breaks of an email header value with spaces, so the value cannot inject headers.
+func headerValue(value string) string {
+	return strings.NewReplacer("\r\n", " ", "\r", " ", "\n", " ").Replace(value)
+}
+
+// snsPublisher is the part of the SNS client used by the SNSChannel.
+type snsPublisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// SNSChannel publishes the Events to an AWS SNS topic.
+type SNSChannel struct {
+	// TopicARN is the ARN of the SNS topic.
+	TopicARN string
+
+	// Client is the SNS client, e.g. sns.NewFromConfig(awsConf).
+	Client snsPublisher
+}
+
+// Name implements the Channel interface.
+func (s *SNSChannel) Name() string {
+	return "sns"
+}
+
+// Send implements the Channel interface.
+func (s *SNSChannel) Send(ctx context.Context, event Event) error {
+	_, err := s.Client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(s.TopicARN),
+		Subject:  aws.String(subject(event)),
+		Message:  aws.String(body(event)),
+	})
+	return errors.Wrap(err, "Failed to publish to SNS topic")
+}
+
+// subject renders the one line summary of an Event.
+func subject(event Event) string {
+	return fmt.Sprintf("[%s] %s", strings.ToUpper(string(event.Level)), event.Name)
+}
+
+// body renders the message and the fields of an Event, the fields in alphabetic order.
+func body(event Event) string {
+	text := &strings.Builder{}
+	text.WriteString(event.Message)
+	text.WriteString("\n")
+	keys := []string{}
+	for key := range event.Fields {
+		keys = append(keys, key)
+	}
+	// Sort is needed because maps always return values in random order
+	sort.Strings(keys)
+	if len(keys) > 0 {
+		text.WriteString("\n")
+	}
+	for _, key := range keys {
+		fmt.Fprintf(text, "%s: %s\n", key, event.Fields[key])
+	}
+	return text.String()
+}
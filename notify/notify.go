@@ -0,0 +1,166 @@
+// Package notify provides pluggable notification channels (webhook, SMTP, SNS) which can be used
+// to announce operational events like "migrations applied" or "job failed 3 times".
+// Use the New or NewFromConfiguration constructors to create a Notifier
+// Use the Notify method to send an Event to every channel, each delivery is logged for audit
+package notify
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	awsConfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// Level is the severity of an Event.
+type Level string
+
+const (
+	// LevelInfo is used for events which need no action, e.g. "migrations applied".
+	LevelInfo Level = "info"
+
+	// LevelWarning is used for events which may need attention.
+	LevelWarning Level = "warning"
+
+	// LevelError is used for events which need action, e.g. "job failed 3 times".
+	LevelError Level = "error"
+)
+
+// Event is a single operational event.
+type Event struct {
+	// Name is the short identifier of the event, e.g. "migrations-applied".
+	Name string `json:"name"`
+
+	// Message is the human readable description of the event.
+	Message string `json:"message"`
+
+	// Level is the severity of the event, LevelInfo by default.
+	Level Level `json:"level"`
+
+	// Fields are the extra details of the event.
+	Fields map[string]string `json:"fields,omitempty"`
+
+	// Time is the time of the event, Notify sets it to now if it is zero.
+	Time time.Time `json:"time"`
+}
+
+// Channel delivers Events to a single destination.
+type Channel interface {
+	// Name identifies the channel in the log entries and errors.
+	Name() string
+
+	// Send delivers the Event.
+	Send(ctx context.Context, event Event) error
+}
+
+// configGetter is a structure that can get config items by name
+type configGetter interface {
+	Get(string) string
+}
+
+// Notifier sends Events to all of its Channels.
+type Notifier struct {
+	channels []Channel
+	log      *logger.Logger
+}
+
+// New creates a new Notifier with the supplied Channels.
+// If log is not nil every delivery will be logged.
+func New(log *logger.Logger, channels ...Channel) *Notifier {
+	return &Notifier{
+		channels: channels,
+		log:      log,
+	}
+}
+
+// NewFromConfiguration creates a new Notifier with a Channel for every configured destination:
+// a WebhookChannel if APP_NOTIFY_WEBHOOK_URL is set, an SMTPChannel if APP_NOTIFY_SMTP_ADDR is set
+// and an SNSChannel if APP_NOTIFY_SNS_TOPIC_ARN is set. The SNS client uses the default AWS configuration.
+func NewFromConfiguration(ctx context.Context, config configGetter, log *logger.Logger) (*Notifier, error) {
+	channels := []Channel{}
+
+	if url := config.Get(constants.APP_NOTIFY_WEBHOOK_URL); url != "" {
+		channels = append(channels, &WebhookChannel{URL: url})
+	}
+
+	if addr := config.Get(constants.APP_NOTIFY_SMTP_ADDR); addr != "" {
+		channels = append(channels, &SMTPChannel{
+			Addr:     addr,
+			From:     config.Get(constants.APP_NOTIFY_SMTP_FROM),
+			To:       splitList(config.Get(constants.APP_NOTIFY_SMTP_TO)),
+			Username: config.Get(constants.APP_NOTIFY_SMTP_USERNAME),
+			Password: config.Get(constants.APP_NOTIFY_SMTP_PASSWORD),
+		})
+	}
+
+	if topicARN := config.Get(constants.APP_NOTIFY_SNS_TOPIC_ARN); topicARN != "" {
+		awsConf, err := awsConfig.LoadDefaultConfig(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "Failed to load AWS configuration")
+		}
+		channels = append(channels, &SNSChannel{
+			TopicARN: topicARN,
+			Client:   sns.NewFromConfig(awsConf),
+		})
+	}
+
+	return New(log, channels...), nil
+}
+
+// Notify sends the Event to every Channel. A failing Channel does not stop the delivery to the others,
+// the returned error lists all failed Channels.
+func (n *Notifier) Notify(ctx context.Context, event Event) error {
+	if event.Time.IsZero() {
+		event.Time = time.Now()
+	}
+	if event.Level == "" {
+		event.Level = LevelInfo
+	}
+
+	failed := []string{}
+	for _, channel := range n.channels {
+		err := channel.Send(ctx, event)
+		n.logDelivery(channel, event, err)
+		if err != nil {
+			failed = append(failed, channel.Name())
+		}
+	}
+
+	if len(failed) > 0 {
+		return errors.Errorf("Failed to send %s notification via: %s", event.Name, strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// logDelivery writes the audit log entry of a single delivery.
+func (n *Notifier) logDelivery(channel Channel, event Event, err error) {
+	if n.log == nil {
+		return
+	}
+	entry := n.log.WithFields(logrus.Fields{
+		"notification": event.Name,
+		"event_level":  event.Level,
+		"channel":      channel.Name(),
+	})
+	if err != nil {
+		entry.WithField("error", err.Error()).Error("Failed to send notification")
+		return
+	}
+	entry.Info("Notification sent")
+}
+
+// splitList splits a comma separated list and drops the empty items.
+func splitList(list string) []string {
+	items := []string{}
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,136 @@
+package notify
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/pkg/errors"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// NotifySuite extends testify's Suite.
+type NotifySuite struct {
+	suite.Suite
+}
+
+// staticConfig implements configGetter
+type staticConfig map[string]string
+
+func (s staticConfig) Get(name string) string {
+	return s[name]
+}
+
+// fakeSNS records the published messages
+type fakeSNS struct {
+	inputs []*sns.PublishInput
+	err    error
+}
+
+func (f *fakeSNS) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	f.inputs = append(f.inputs, params)
+	return &sns.PublishOutput{}, f.err
+}
+
+func (ns *NotifySuite) TestWebhook() {
+	received := Event{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ns.Equal("Bearer token", r.Header.Get("Authorization"))
+		ns.NoError(json.NewDecoder(r.Body).Decode(&received))
+	}))
+	defer server.Close()
+
+	nullLogger, hook := logrusTest.NewNullLogger()
+	notifier := New(logger.NewLogger(nullLogger, nil), &WebhookChannel{
+		URL:     server.URL,
+		Headers: map[string]string{"Authorization": "Bearer token"},
+	})
+	err := notifier.Notify(context.Background(), Event{Name: "migrations-applied", Message: "3 migrations applied"})
+	ns.NoError(err, "Notification should have been sent")
+	ns.Equal("migrations-applied", received.Name)
+	ns.Equal(LevelInfo, received.Level, "Level should default to info")
+	ns.False(received.Time.IsZero(), "Time should have been set")
+	ns.Equal("Notification sent", hook.LastEntry().Message, "Delivery should have been logged")
+	ns.Equal("webhook", hook.LastEntry().Data["channel"])
+}
+
+func (ns *NotifySuite) TestFailingChannel() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	publisher := &fakeSNS{}
+	nullLogger, hook := logrusTest.NewNullLogger()
+	notifier := New(logger.NewLogger(nullLogger, nil),
+		&WebhookChannel{URL: server.URL},
+		&SNSChannel{TopicARN: "arn:aws:sns:eu-west-1:123456789012:ops", Client: publisher},
+	)
+	err := notifier.Notify(context.Background(), Event{
+		Name:    "job-failed",
+		Message: "Job failed 3 times",
+		Level:   LevelError,
+		Fields:  map[string]string{"job": "cleanup", "attempts": "3"},
+	})
+	ns.EqualError(err, "Failed to send job-failed notification via: webhook")
+	ns.Len(publisher.inputs, 1, "A failing channel should not stop the others")
+	ns.Equal("[ERROR] job-failed", *publisher.inputs[0].Subject)
+	ns.Equal("Job failed 3 times\n\nattempts: 3\njob: cleanup\n", *publisher.inputs[0].Message)
+	ns.Len(hook.AllEntries(), 2, "Every delivery should have been logged")
+	ns.Equal("Failed to send notification", hook.AllEntries()[0].Message)
+
+	publisher.err = errors.New("throttled")
+	ns.Error(New(nil, &SNSChannel{Client: publisher}).Notify(context.Background(), Event{Name: "x"}))
+}
+
+func (ns *NotifySuite) TestSMTP() {
+	defer func(original func(string, smtp.Auth, string, []string, []byte) error) {
+		sendMail = original
+	}(sendMail)
+
+	var sentTo []string
+	var sentMsg string
+	sendMail = func(addr string, auth smtp.Auth, from string, to []string, msg []byte) error {
+		ns.Equal("smtp.example.com:587", addr)
+		ns.NotNil(auth, "Auth should be set when username is configured")
+		sentTo = to
+		sentMsg = string(msg)
+		return nil
+	}
+
+	notifier, err := NewFromConfiguration(context.Background(), staticConfig{
+		constants.APP_NOTIFY_SMTP_ADDR:     "smtp.example.com:587",
+		constants.APP_NOTIFY_SMTP_FROM:     "service@example.com",
+		constants.APP_NOTIFY_SMTP_TO:       "ops@example.com, dev@example.com,",
+		constants.APP_NOTIFY_SMTP_USERNAME: "service",
+		constants.APP_NOTIFY_SMTP_PASSWORD: "secret",
+	}, nil)
+	ns.NoError(err, "Notifier should have been created")
+	ns.Len(notifier.channels, 1, "Only the SMTP channel is configured")
+
+	ns.NoError(notifier.Notify(context.Background(), Event{Name: "migrations-applied", Message: "Done"}))
+	ns.Equal([]string{"ops@example.com", "dev@example.com"}, sentTo)
+	ns.Contains(sentMsg, "Subject: [INFO] migrations-applied\r\n")
+	ns.Contains(sentMsg, "\r\n\r\nDone\n")
+
+	ns.Error((&SMTPChannel{Addr: "smtp.example.com:25"}).Send(context.Background(), Event{}), "Missing recipients should fail")
+
+	ns.NoError(notifier.Notify(context.Background(), Event{Name: "deploy\r\nBcc: attacker@example.com"}))
+	ns.Contains(sentMsg, "Subject: [INFO] deploy Bcc: attacker@example.com\r\n", "Line breaks should be removed from the subject")
+	ns.NotContains(sentMsg, "\r\nBcc:")
+	err = (&SMTPChannel{Addr: "smtp.example.com:25", From: "service@example.com", To: []string{"ops@example.com\r\nBcc: attacker@example.com"}}).
+		Send(context.Background(), Event{})
+	ns.EqualError(err, `Invalid email address "ops@example.com\r\nBcc: attacker@example.com"`)
+}
+
+// TestNotify runs the whole test suite
+func TestNotify(t *testing.T) {
+	suite.Run(t, new(NotifySuite))
+}
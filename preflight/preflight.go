@@ -0,0 +1,283 @@
+// Package preflight provides a startup dependency checker, which verifies the external dependencies
+// of an application (database, secrets, queues, migrations, ...) before the application reports ready.
+// Use the NewChecker or NewCheckerFromConfiguration constructors to create a Checker
+// Use the Add method to declare the dependencies and Run to check all of them
+package preflight
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// DefaultTimeout is the timeout of a check if neither the check nor the Checker defines one.
+const DefaultTimeout = 5 * time.Second
+
+// Mode defines how the Checker reacts to failing checks.
+type Mode string
+
+const (
+	// ModeFailFast stops the remaining checks on the first failure and Run returns an error.
+	ModeFailFast Mode = "fail-fast"
+
+	// ModeDegrade runs every check, failures are reported but Run does not return an error.
+	ModeDegrade Mode = "degrade"
+)
+
+// ValidModes are the valid preflight modes. Used in validation.
+var ValidModes = []interface{}{
+	string(ModeFailFast),
+	string(ModeDegrade),
+}
+
+// CheckFunc verifies a single dependency, it should respect the cancellation of the context.
+type CheckFunc func(ctx context.Context) error
+
+// Check is a single named dependency check.
+type Check struct {
+	// Name identifies the dependency in the results and the log entries.
+	Name string
+
+	// Timeout is the maximum duration of the check, zero means the Checker's timeout.
+	Timeout time.Duration
+
+	// Run is the function which verifies the dependency.
+	Run CheckFunc
+}
+
+// Result is the outcome of a single Check.
+type Result struct {
+	Name     string        `json:"name"`
+	OK       bool          `json:"ok"`
+	Skipped  bool          `json:"skipped,omitempty"`
+	Error    string        `json:"error,omitempty"`
+	Duration time.Duration `json:"duration"`
+}
+
+// Report is the collection of the Results in the order of the checks' declaration.
+type Report struct {
+	Results []Result `json:"results"`
+}
+
+// OK returns true if every check succeeded.
+func (r *Report) OK() bool {
+	return len(r.Failed()) == 0
+}
+
+// Failed returns the Results of the failed or skipped checks.
+func (r *Report) Failed() []Result {
+	failed := []Result{}
+	for _, result := range r.Results {
+		if !result.OK {
+			failed = append(failed, result)
+		}
+	}
+	return failed
+}
+
+// configGetter is a structure that can get config items by name
+type configGetter interface {
+	Get(string) string
+}
+
+// Checker runs the declared dependency checks.
+type Checker struct {
+	mode    Mode
+	timeout time.Duration
+	checks  []Check
+	log     *logger.Logger
+}
+
+// NewChecker creates a new Checker with the supplied mode and default per-check timeout.
+// If log is not nil every result will be logged. Run fails if the mode is not one of the ValidModes.
+func NewChecker(mode Mode, timeout time.Duration, log *logger.Logger) *Checker {
+	if timeout <= 0 {
+		timeout = DefaultTimeout
+	}
+	return &Checker{
+		mode:    mode,
+		timeout: timeout,
+		log:     log,
+	}
+}
+
+// NewCheckerFromConfiguration creates a new Checker with the mode set by APP_PREFLIGHT_MODE (fail-fast by default)
+// and the timeout set by APP_PREFLIGHT_TIMEOUT (a time.Duration string, 5s by default).
+func NewCheckerFromConfiguration(config configGetter, log *logger.Logger) *Checker {
+	mode := ModeFailFast
+	if value := config.Get(constants.APP_PREFLIGHT_MODE); value != "" {
+		mode = Mode(value)
+	}
+	timeout, err := time.ParseDuration(config.Get(constants.APP_PREFLIGHT_TIMEOUT))
+	if err != nil {
+		timeout = DefaultTimeout
+	}
+	return NewChecker(mode, timeout, log)
+}
+
+// Add declares a new dependency check, a zero timeout means the Checker's timeout.
+func (c *Checker) Add(name string, timeout time.Duration, run CheckFunc) *Checker {
+	c.checks = append(c.checks, Check{Name: name, Timeout: timeout, Run: run})
+	return c
+}
+
+// Run executes all checks concurrently and returns the Report.
+// In ModeFailFast the first failure cancels the remaining checks and an error is returned.
+// In ModeDegrade the failures are only reported in the Report and the log.
+// If ctx is done before the checks complete, ctx.Err() is returned in both modes.
+func (c *Checker) Run(ctx context.Context) (*Report, error) {
+	if err := validation.Validate(string(c.mode), validation.In(ValidModes...)); err != nil {
+		return nil, errors.Wrapf(err, "Invalid preflight mode %s", c.mode)
+	}
+	checkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	report := &Report{Results: make([]Result, len(c.checks))}
+	wg := sync.WaitGroup{}
+	for i, check := range c.checks {
+		wg.Add(1)
+		go func(i int, check Check) {
+			defer wg.Done()
+			report.Results[i] = c.runCheck(checkCtx, check)
+			if !report.Results[i].OK && c.mode == ModeFailFast {
+				cancel()
+			}
+		}(i, check)
+	}
+	wg.Wait()
+
+	for _, result := range report.Results {
+		c.logResult(result)
+	}
+
+	if err := ctx.Err(); err != nil && !report.OK() {
+		return report, err
+	}
+	if c.mode == ModeFailFast && !report.OK() {
+		names := []string{}
+		for _, result := range report.Failed() {
+			if !result.Skipped {
+				names = append(names, result.Name)
+			}
+		}
+		return report, errors.Errorf("Preflight checks failed: %s", strings.Join(names, ", "))
+	}
+	return report, nil
+}
+
+// runCheck executes a single check with its timeout.
+func (c *Checker) runCheck(ctx context.Context, check Check) Result {
+	result := Result{Name: check.Name}
+	if ctx.Err() != nil {
+		result.Skipped = true
+		result.Error = ctx.Err().Error()
+		return result
+	}
+
+	timeout := check.Timeout
+	if timeout <= 0 {
+		timeout = c.timeout
+	}
+	checkCtx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	start := time.Now()
+	err := check.Run(checkCtx)
+	result.Duration = time.Since(start)
+	if err != nil {
+		// A check interrupted by another check's failure is reported as skipped
+		result.Skipped = errors.Is(ctx.Err(), context.Canceled) && checkCtx.Err() != nil
+		result.Error = err.Error()
+		return result
+	}
+	result.OK = true
+	return result
+}
+
+// logResult writes the result of a check into the log.
+func (c *Checker) logResult(result Result) {
+	if c.log == nil {
+		return
+	}
+	entry := c.log.WithFields(logrus.Fields{
+		"check":    result.Name,
+		"duration": result.Duration.String(),
+	})
+	switch {
+	case result.OK:
+		entry.Info("Preflight check succeeded")
+	case result.Skipped:
+		entry.WithField("error", result.Error).Warn("Preflight check skipped")
+	case c.mode == ModeDegrade:
+		entry.WithField("error", result.Error).Warn("Preflight check failed, running degraded")
+	default:
+		entry.WithField("error", result.Error).Error("Preflight check failed")
+	}
+}
+
+// PingSQL returns a CheckFunc which verifies that the database is reachable.
+func PingSQL(db *sql.DB) CheckFunc {
+	return func(ctx context.Context) error {
+		return errors.Wrap(db.PingContext(ctx), "Failed to ping database")
+	}
+}
+
+// DialTCP returns a CheckFunc which verifies that a TCP connection can be opened to address (host:port).
+func DialTCP(address string) CheckFunc {
+	return func(ctx context.Context) error {
+		dialer := net.Dialer{}
+		conn, err := dialer.DialContext(ctx, "tcp", address)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to connect to %s", address)
+		}
+		return conn.Close()
+	}
+}
+
+// HTTPGet returns a CheckFunc which verifies that url responds with a 2xx status code.
+// If client is nil the http.DefaultClient is used.
+func HTTPGet(client *http.Client, url string) CheckFunc {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	return func(ctx context.Context) error {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to create request to %s", url)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			return errors.Wrapf(err, "Failed to request %s", url)
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode < 200 || resp.StatusCode > 299 {
+			return errors.Errorf("Unexpected status code from %s: %d", url, resp.StatusCode)
+		}
+		return nil
+	}
+}
+
+// Expect returns a CheckFunc which verifies that the value returned by get equals expected,
+// e.g. that the applied database migration version is the current one.
+func Expect(get func(ctx context.Context) (string, error), expected string) CheckFunc {
+	return func(ctx context.Context) error {
+		actual, err := get(ctx)
+		if err != nil {
+			return err
+		}
+		if actual != expected {
+			return errors.Errorf("Expected %q, got %q", expected, actual)
+		}
+		return nil
+	}
+}
@@ -0,0 +1,139 @@
+package preflight
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/constants"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// PreflightSuite extends testify's Suite.
+type PreflightSuite struct {
+	suite.Suite
+}
+
+// staticConfig implements configGetter
+type staticConfig map[string]string
+
+func (s staticConfig) Get(name string) string {
+	return s[name]
+}
+
+func succeed(ctx context.Context) error {
+	return nil
+}
+
+func fail(ctx context.Context) error {
+	return errors.New("dependency is down")
+}
+
+func block(ctx context.Context) error {
+	<-ctx.Done()
+	return ctx.Err()
+}
+
+func (ps *PreflightSuite) TestAllSucceed() {
+	nullLogger, hook := logrusTest.NewNullLogger()
+	checker := NewChecker(ModeFailFast, time.Second, logger.NewLogger(nullLogger, nil))
+	checker.Add("db", 0, succeed).Add("queue", 0, succeed)
+
+	report, err := checker.Run(context.Background())
+	ps.NoError(err, "All checks should have succeeded")
+	ps.True(report.OK(), "Report should be OK")
+	ps.Len(report.Results, 2)
+	ps.Equal("db", report.Results[0].Name, "Results should keep the declaration order")
+	ps.Len(hook.AllEntries(), 2, "Every result should have been logged")
+}
+
+func (ps *PreflightSuite) TestFailFast() {
+	nullLogger, hook := logrusTest.NewNullLogger()
+	checker := NewChecker(ModeFailFast, time.Minute, logger.NewLogger(nullLogger, nil))
+	checker.Add("db", 0, fail).Add("queue", 0, block)
+
+	report, err := checker.Run(context.Background())
+	ps.EqualError(err, "Preflight checks failed: db")
+	ps.False(report.OK(), "Report should not be OK")
+	ps.Equal("dependency is down", report.Results[0].Error)
+	ps.True(report.Results[1].Skipped, "Blocked check should have been canceled")
+
+	levels := []logrus.Level{}
+	for _, entry := range hook.AllEntries() {
+		levels = append(levels, entry.Level)
+	}
+	ps.Contains(levels, logrus.ErrorLevel, "Failure should have been logged as error")
+}
+
+func (ps *PreflightSuite) TestDegradeAndTimeout() {
+	checker := NewChecker(ModeDegrade, time.Minute, nil)
+	checker.Add("db", 10*time.Millisecond, block).Add("queue", 0, succeed)
+
+	report, err := checker.Run(context.Background())
+	ps.NoError(err, "Degraded mode should not return an error")
+	ps.Len(report.Failed(), 1)
+	ps.Equal("db", report.Failed()[0].Name)
+	ps.False(report.Failed()[0].Skipped, "Timed out check should be failed, not skipped")
+	ps.Contains(report.Failed()[0].Error, "deadline exceeded")
+}
+
+func (ps *PreflightSuite) TestFromConfiguration() {
+	checker := NewCheckerFromConfiguration(staticConfig{
+		constants.APP_PREFLIGHT_MODE:    "degrade",
+		constants.APP_PREFLIGHT_TIMEOUT: "2s",
+	}, nil)
+	ps.Equal(ModeDegrade, checker.mode)
+	ps.Equal(2*time.Second, checker.timeout)
+
+	checker = NewCheckerFromConfiguration(staticConfig{}, nil)
+	ps.Equal(ModeFailFast, checker.mode)
+	ps.Equal(DefaultTimeout, checker.timeout)
+
+	checker = NewCheckerFromConfiguration(staticConfig{constants.APP_PREFLIGHT_MODE: "degraded"}, nil)
+	_, err := checker.Add("db", 0, succeed).Run(context.Background())
+	ps.EqualError(err, "Invalid preflight mode degraded: must be a valid value", "Unknown modes should be rejected")
+}
+
+func (ps *PreflightSuite) TestCanceled() {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, mode := range []Mode{ModeFailFast, ModeDegrade} {
+		report, err := NewChecker(mode, time.Minute, nil).Add("db", 0, block).Run(ctx)
+		ps.ErrorIs(err, context.Canceled, "%s: the error of the context should be returned", mode)
+		ps.True(report.Results[0].Skipped, string(mode))
+	}
+}
+
+func (ps *PreflightSuite) TestBuiltinChecks() {
+	ctx := context.Background()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+	ps.NoError(HTTPGet(nil, server.URL+"/health")(ctx))
+	ps.Error(HTTPGet(server.Client(), server.URL+"/down")(ctx))
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	ps.NoError(err, "Listener should have been created")
+	ps.NoError(DialTCP(listener.Addr().String())(ctx))
+	ps.NoError(listener.Close())
+	ps.Error(DialTCP(listener.Addr().String())(ctx))
+
+	version := func(ctx context.Context) (string, error) { return "42", nil }
+	ps.NoError(Expect(version, "42")(ctx))
+	ps.EqualError(Expect(version, "43")(ctx), `Expected "43", got "42"`)
+}
+
+// TestPreflight runs the whole test suite
+func TestPreflight(t *testing.T) {
+	suite.Run(t, new(PreflightSuite))
+}
@@ -0,0 +1,195 @@
+// Package timing provides a context based per-request timing recorder, which collects the durations of
+// the phases of a request (database, cache, rendering, ...) without a full tracing setup.
+// Use the Middleware (or NewContext) to attach a Recorder to the request's context
+// Use Start and Timer.Stop to measure a phase, the results are added to the access log entry
+// and optionally emitted as a Server-Timing response header
+package timing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// ServerTimingHeader is the name of the response header defined by https://www.w3.org/TR/server-timing/
+const ServerTimingHeader = "Server-Timing"
+
+// contextKey is the type of the context key of the Recorder
+type contextKey struct{}
+
+// Phase is a single measured phase of a request.
+type Phase struct {
+	Name     string
+	Duration time.Duration
+}
+
+// Recorder collects the Phases of a single request, it is safe for concurrent use.
+type Recorder struct {
+	mu     sync.Mutex
+	start  time.Time
+	phases []Phase
+}
+
+// NewContext creates a new Recorder and returns it with a copy of ctx carrying it.
+func NewContext(ctx context.Context) (context.Context, *Recorder) {
+	recorder := &Recorder{start: time.Now()}
+	return context.WithValue(ctx, contextKey{}, recorder), recorder
+}
+
+// FromContext returns the Recorder of ctx, or nil if there is none.
+func FromContext(ctx context.Context) *Recorder {
+	recorder, _ := ctx.Value(contextKey{}).(*Recorder)
+	return recorder
+}
+
+// Timer measures a single Phase, call Stop at the end of the phase.
+type Timer struct {
+	recorder *Recorder
+	name     string
+	start    time.Time
+}
+
+// Start starts measuring the named phase. If ctx has no Recorder the Timer still measures, but records nothing.
+func Start(ctx context.Context, name string) *Timer {
+	return &Timer{
+		recorder: FromContext(ctx),
+		name:     name,
+		start:    time.Now(),
+	}
+}
+
+// Stop ends the phase, records it and returns its duration.
+func (t *Timer) Stop() time.Duration {
+	duration := time.Since(t.start)
+	if t.recorder != nil {
+		t.recorder.Record(t.name, duration)
+	}
+	return duration
+}
+
+// Record adds a Phase measured by other means.
+func (r *Recorder) Record(name string, duration time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.phases = append(r.phases, Phase{Name: name, Duration: duration})
+}
+
+// Phases returns the recorded Phases in the order of their end.
+func (r *Recorder) Phases() []Phase {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]Phase{}, r.phases...)
+}
+
+// Total returns the time elapsed since the Recorder was created.
+func (r *Recorder) Total() time.Duration {
+	return time.Since(r.start)
+}
+
+// Fields returns the recorded Phases as log fields: "timing" is a map of the phase names and their
+// summed durations in milliseconds.
+func (r *Recorder) Fields() logrus.Fields {
+	timings := map[string]float64{}
+	for _, phase := range r.Phases() {
+		timings[phase.Name] += milliseconds(phase.Duration)
+	}
+	return logrus.Fields{"timing": timings}
+}
+
+// Header returns the value of the Server-Timing header, e.g. "db;dur=12.5, cache;dur=0.8, total;dur=20.1".
+func (r *Recorder) Header() string {
+	metrics := []string{}
+	for _, phase := range r.Phases() {
+		metrics = append(metrics, fmt.Sprintf("%s;dur=%.1f", headerToken(phase.Name), milliseconds(phase.Duration)))
+	}
+	metrics = append(metrics, fmt.Sprintf("total;dur=%.1f", milliseconds(r.Total())))
+	return strings.Join(metrics, ", ")
+}
+
+// Middleware returns an HTTP middleware which attaches a Recorder to every request's context.
+// If emitHeader is true the Server-Timing header is added to the response, containing the phases
+// stopped before the response header is written.
+// If log is not nil an access log entry is written after every request with the method, path,
+// status code, duration and the timing fields.
+func Middleware(log *logger.Logger, emitHeader bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx, recorder := NewContext(r.Context())
+			rw := &responseWriter{ResponseWriter: w, recorder: recorder, emitHeader: emitHeader}
+
+			next.ServeHTTP(rw, r.WithContext(ctx))
+
+			if log == nil {
+				return
+			}
+			if rw.status == 0 {
+				rw.status = http.StatusOK
+			}
+			log.WithFields(recorder.Fields()).WithFields(logrus.Fields{
+				"method":   r.Method,
+				"path":     r.URL.Path,
+				"status":   rw.status,
+				"duration": milliseconds(recorder.Total()),
+			}).Info("Request served")
+		})
+	}
+}
+
+// responseWriter adds the Server-Timing header right before the response header is written.
+type responseWriter struct {
+	http.ResponseWriter
+	recorder   *Recorder
+	emitHeader bool
+	status     int
+}
+
+// WriteHeader implements the http.ResponseWriter interface.
+func (rw *responseWriter) WriteHeader(status int) {
+	if rw.status != 0 {
+		return
+	}
+	rw.status = status
+	if rw.emitHeader {
+		rw.Header().Set(ServerTimingHeader, rw.recorder.Header())
+	}
+	rw.ResponseWriter.WriteHeader(status)
+}
+
+// Write implements the http.ResponseWriter interface.
+func (rw *responseWriter) Write(body []byte) (int, error) {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	return rw.ResponseWriter.Write(body)
+}
+
+// Flush implements the http.Flusher interface if the underlying ResponseWriter does.
+func (rw *responseWriter) Flush() {
+	if rw.status == 0 {
+		rw.WriteHeader(http.StatusOK)
+	}
+	if flusher, ok := rw.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// milliseconds converts a duration into fractional milliseconds.
+func milliseconds(duration time.Duration) float64 {
+	return float64(duration) / float64(time.Millisecond)
+}
+
+// headerToken replaces the characters which are not allowed in a Server-Timing metric name.
+func headerToken(name string) string {
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || strings.ContainsRune(`"(),/:;<=>?@[\]{}`, r) || r >= 0x7f {
+			return '_'
+		}
+		return r
+	}, name)
+}
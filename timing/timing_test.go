@@ -0,0 +1,79 @@
+package timing
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	logrusTest "github.com/sirupsen/logrus/hooks/test"
+	"github.com/stretchr/testify/suite"
+	"github.com/universal-devs/go-utilities/logger"
+)
+
+// TimingSuite extends testify's Suite.
+type TimingSuite struct {
+	suite.Suite
+}
+
+func (ts *TimingSuite) TestRecorder() {
+	ctx, recorder := NewContext(context.Background())
+	ts.Equal(recorder, FromContext(ctx), "Recorder should be attached to the context")
+
+	timer := Start(ctx, "db")
+	time.Sleep(2 * time.Millisecond)
+	ts.GreaterOrEqual(int64(timer.Stop()), int64(2*time.Millisecond))
+	recorder.Record("db", 3*time.Millisecond)
+	recorder.Record("cache hit", 500*time.Microsecond)
+
+	phases := recorder.Phases()
+	ts.Len(phases, 3)
+	ts.Equal("db", phases[0].Name)
+
+	timings := recorder.Fields()["timing"].(map[string]float64)
+	ts.GreaterOrEqual(timings["db"], 5.0, "Phases with the same name should be summed")
+	ts.Equal(0.5, timings["cache hit"])
+
+	ts.Contains(recorder.Header(), "cache_hit;dur=0.5, total;dur=")
+}
+
+func (ts *TimingSuite) TestWithoutRecorder() {
+	ts.Nil(FromContext(context.Background()))
+	ts.NotPanics(func() { Start(context.Background(), "db").Stop() }, "Timer without Recorder should be a no-op")
+}
+
+func (ts *TimingSuite) TestMiddleware() {
+	nullLogger, hook := logrusTest.NewNullLogger()
+	handler := Middleware(logger.NewLogger(nullLogger, nil), true)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		FromContext(r.Context()).Record("db", 12*time.Millisecond)
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte("created"))
+	}))
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/items", nil))
+	ts.Equal(http.StatusCreated, rec.Code)
+	ts.Contains(rec.Header().Get(ServerTimingHeader), "db;dur=12.0, total;dur=")
+
+	entry := hook.LastEntry()
+	ts.Equal("Request served", entry.Message)
+	ts.Equal(http.StatusCreated, entry.Data["status"])
+	ts.Equal("/items", entry.Data["path"])
+	ts.Equal(12.0, entry.Data["timing"].(map[string]float64)["db"])
+}
+
+func (ts *TimingSuite) TestMiddlewareWithoutHeader() {
+	handler := Middleware(nil, false)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	}))
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/", nil))
+	ts.Equal(http.StatusOK, rec.Code)
+	ts.Empty(rec.Header().Get(ServerTimingHeader))
+}
+
+// TestTiming runs the whole test suite
+func TestTiming(t *testing.T) {
+	suite.Run(t, new(TimingSuite))
+}